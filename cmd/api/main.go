@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/golang-jwt/jwt"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -12,13 +13,19 @@ import (
 	"gorm.io/plugin/dbresolver"
 	_ "ms/card/cmd/api/docs"
 	"ms/card/internal/api/handler"
-	"ms/card/pkg/persistence/entity"
+	"ms/card/internal/worker"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/common"
+	"ms/card/pkg/fx"
+	"ms/card/pkg/persistence/migration"
 	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/risk"
 	"ms/card/pkg/service"
 	"ms/card/pkg/telemetry/jaeger"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 )
 
@@ -26,6 +33,7 @@ func main() {
 	_ = godotenv.Load()
 	server := echo.New()
 	server.HideBanner = true
+	server.HTTPErrorHandler = handler.ErrorHandler
 
 	serviceName := os.Getenv("API_NAME")
 	serviceNamespace := os.Getenv("API_NAMESPACE")
@@ -34,6 +42,7 @@ func main() {
 	}
 
 	server.Use(middleware.Secure())
+	server.Use(handler.MaskQuery())
 	server.Use(middleware.Logger())
 	server.Use(middleware.Recover())
 	server.Use(middleware.Gzip())
@@ -59,28 +68,227 @@ func main() {
 		server.Logger.Fatalf("db.Use(dbresolver.Register(dbresolver.Config{}) failed with %s\n", err)
 	}
 
-	if err := db.AutoMigrate(&entity.Operation{}, &entity.Account{}, &entity.Transaction{}); err != nil {
-		server.Logger.Fatalf("DB.AutoMigrate() failed with %s\n", err)
+	migrations, err := migration.Load(migration.Files)
+	if err != nil {
+		server.Logger.Fatalf("migration.Load() failed with %s\n", err)
+	}
+
+	// the schema is migrated beforehand by cmd/migrate, replicas never race to change it
+	if err := migration.NewMigrator(server.Logger, db, migrations).Check(context.Background()); err != nil {
+		server.Logger.Fatalf("migrator.Check() failed with %s\n", err)
 	}
 
+	unitOfWork := repository.NewUnitOfWork(server.Logger, db)
 	accountRepository := repository.NewAccount(server.Logger, db)
+	accountChangeRepository := repository.NewAccountChange(server.Logger, db)
 	operationRepository := repository.NewOperation(server.Logger, db)
 	transactionRepository := repository.NewTransaction(server.Logger, db)
+	idempotencyKeyRepository := repository.NewIdempotencyKey(server.Logger, db)
+	holdRepository := repository.NewHold(server.Logger, db)
+	installmentRepository := repository.NewInstallment(server.Logger, db)
+	billingCycleRepository := repository.NewBillingCycle(server.Logger, db)
+	statementRepository := repository.NewStatement(server.Logger, db)
+	ledgerRepository := repository.NewLedger(server.Logger, db)
+	cardRepository := repository.NewCard(server.Logger, db)
+	spendingControlRepository := repository.NewSpendingControl(server.Logger, db)
+	riskDecisionRepository := repository.NewRiskDecision(server.Logger, db)
+	authorizationRepository := repository.NewAuthorization(server.Logger, db)
+	apiKeyRepository := repository.NewAPIKey(server.Logger, db)
+
+	idempotencyRetention, err := time.ParseDuration(os.Getenv("API_IDEMPOTENCY_RETENTION"))
+	if err != nil {
+		server.Logger.Fatalf("time.ParseDuration(API_IDEMPOTENCY_RETENTION) failed with %s\n", err)
+	}
+
+	holdTTL, err := time.ParseDuration(os.Getenv("API_HOLD_TTL"))
+	if err != nil {
+		server.Logger.Fatalf("time.ParseDuration(API_HOLD_TTL) failed with %s\n", err)
+	}
+
+	rates, err := fx.NewMemory(nil)
+	if path := os.Getenv("API_FX_RATES_FILE"); path != "" {
+		rates, err = fx.NewFile(path)
+	}
+
+	if err != nil {
+		server.Logger.Fatalf("fx.NewFile(API_FX_RATES_FILE) failed with %s\n", err)
+	}
+
+	markup, err := fx.Percent(os.Getenv("API_FX_MARKUP"))
+	if err != nil {
+		server.Logger.Fatalf("fx.Percent(API_FX_MARKUP) failed with %s\n", err)
+	}
+
+	tax, err := fx.Percent(os.Getenv("API_FX_TAX"))
+	if err != nil {
+		server.Logger.Fatalf("fx.Percent(API_FX_TAX) failed with %s\n", err)
+	}
+
+	riskTimeout, err := time.ParseDuration(os.Getenv("API_RISK_TIMEOUT"))
+	if err != nil {
+		server.Logger.Fatalf("time.ParseDuration(API_RISK_TIMEOUT) failed with %s\n", err)
+	}
+
+	riskFailOpen, err := strconv.ParseBool(os.Getenv("API_RISK_FAIL_OPEN"))
+	if err != nil {
+		server.Logger.Fatalf("strconv.ParseBool(API_RISK_FAIL_OPEN) failed with %s\n", err)
+	}
+
+	creditBalance, err := strconv.ParseBool(os.Getenv("API_ACCOUNT_CREDIT_BALANCE"))
+	if err != nil {
+		server.Logger.Fatalf("strconv.ParseBool(API_ACCOUNT_CREDIT_BALANCE) failed with %s\n", err)
+	}
+
+	bin, err := common.ParseBIN(os.Getenv("API_CARD_BIN"))
+	if err != nil {
+		server.Logger.Fatalf("common.ParseBIN(API_CARD_BIN) failed with %s\n", err)
+	}
+
+	tokenKey, err := common.ParseTokenKey(os.Getenv("API_CARD_TOKEN_KEY"))
+	if err != nil {
+		server.Logger.Fatalf("common.ParseTokenKey(API_CARD_TOKEN_KEY) failed with %s\n", err)
+	}
+
+	var tokens auth.Authenticator
+	secret := os.Getenv("API_AUTH_JWT_SECRET")
+	publicKeyFile := os.Getenv("API_AUTH_JWT_PUBLIC_KEY_FILE")
+	if secret != "" || publicKeyFile != "" {
+		jwtOpts := auth.JWTOpts{
+			Secret: []byte(secret),
+			Issuer: os.Getenv("API_AUTH_JWT_ISSUER"),
+		}
+
+		if publicKeyFile != "" {
+			pem, err := os.ReadFile(publicKeyFile)
+			if err != nil {
+				server.Logger.Fatalf("os.ReadFile(API_AUTH_JWT_PUBLIC_KEY_FILE) failed with %s\n", err)
+			}
+
+			jwtOpts.PublicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+			if err != nil {
+				server.Logger.Fatalf("jwt.ParseRSAPublicKeyFromPEM(API_AUTH_JWT_PUBLIC_KEY_FILE) failed with %s\n", err)
+			}
+		}
+
+		tokens = auth.NewJWT(jwtOpts)
+	}
+
+	authenticate := handler.Authenticate(handler.AuthOpts{
+		APIKeys: auth.NewAPIKey(auth.APIKeyOpts{
+			Logger:           server.Logger,
+			APIKeyRepository: apiKeyRepository,
+		}),
+		Tokens: tokens,
+	})
+
+	converter := fx.NewConverter(fx.ConverterOpts{
+		Provider: rates,
+		Markup:   markup,
+		Tax:      tax,
+	})
+
+	ledgerService := service.NewLedger(service.LedgerOpts{
+		Logger:           server.Logger,
+		LedgerRepository: ledgerRepository,
+	})
 
 	accountService := service.NewAccount(service.AccountOpts{
+		Logger:                  server.Logger,
+		UnitOfWork:              unitOfWork,
+		AccountRepository:       accountRepository,
+		AccountChangeRepository: accountChangeRepository,
+		Ledger:                  ledgerService,
+		CreditBalance:           creditBalance,
+	})
+
+	cardService := service.NewCard(service.CardOpts{
+		Logger:            server.Logger,
+		UnitOfWork:        unitOfWork,
 		AccountRepository: accountRepository,
+		CardRepository:    cardRepository,
+		BIN:               bin,
+		TokenKey:          tokenKey,
 	})
 
-	transactionService := service.NewTransaction(service.TransactionOpts{
+	spendingControlService := service.NewSpendingControl(service.SpendingControlOpts{
+		Logger:                    server.Logger,
+		AccountRepository:         accountRepository,
+		CardRepository:            cardRepository,
+		TransactionRepository:     transactionRepository,
+		SpendingControlRepository: spendingControlRepository,
+		HomeCountry:               os.Getenv("API_HOME_COUNTRY"),
+	})
+
+	riskService := service.NewRisk(service.RiskOpts{
+		Logger: server.Logger,
+		Scorer: risk.NewChain(risk.ChainOpts{
+			Logger:   server.Logger,
+			Scorers:  []risk.Scorer{risk.NewRules(risk.DefaultRulesOpts)},
+			Timeout:  riskTimeout,
+			FailOpen: riskFailOpen,
+		}),
+		TransactionRepository:  transactionRepository,
+		RiskDecisionRepository: riskDecisionRepository,
+	})
+
+	authorizationService := service.NewAuthorization(service.AuthorizationOpts{
+		Logger:                  server.Logger,
+		AuthorizationRepository: authorizationRepository,
+	})
+
+	installmentService := service.NewInstallment(service.InstallmentOpts{
 		Logger:                server.Logger,
-		AccountService:        accountService,
+		UnitOfWork:            unitOfWork,
+		InstallmentRepository: installmentRepository,
 		TransactionRepository: transactionRepository,
-		AccountRepository:     accountRepository,
-		Operation:             operationRepository,
+	})
+
+	transactionService := service.NewTransaction(service.TransactionOpts{
+		Logger:                 server.Logger,
+		UnitOfWork:             unitOfWork,
+		AccountService:         accountService,
+		TransactionRepository:  transactionRepository,
+		AccountRepository:      accountRepository,
+		Operation:              operationRepository,
+		IdempotencyKey:         idempotencyKeyRepository,
+		IdempotencyRetention:   idempotencyRetention,
+		InstallmentService:     installmentService,
+		Converter:              converter,
+		CardService:            cardService,
+		SpendingControlService: spendingControlService,
+		RiskService:            riskService,
+		AuthorizationService:   authorizationService,
+	})
+
+	holdService := service.NewHold(service.HoldOpts{
+		Logger:                 server.Logger,
+		UnitOfWork:             unitOfWork,
+		AccountService:         accountService,
+		AccountRepository:      accountRepository,
+		Operation:              operationRepository,
+		HoldRepository:         holdRepository,
+		TransactionRepository:  transactionRepository,
+		AuthorizationService:   authorizationService,
+		CardService:            cardService,
+		SpendingControlService: spendingControlService,
+		RiskService:            riskService,
+		TTL:                    holdTTL,
+	})
+
+	statementService := service.NewStatement(service.StatementOpts{
+		Logger:                 server.Logger,
+		UnitOfWork:             unitOfWork,
+		AccountRepository:      accountRepository,
+		Operation:              operationRepository,
+		TransactionRepository:  transactionRepository,
+		BillingCycleRepository: billingCycleRepository,
+		StatementRepository:    statementRepository,
 	})
 
 	accountHandler := handler.NewAccount(handler.AccountOpts{
-		AccountRepository: accountRepository,
+		AccountService:          accountService,
+		AccountRepository:       accountRepository,
+		AccountChangeRepository: accountChangeRepository,
 	})
 
 	operationTypeHandler := handler.NewOperation(handler.OperationOpts{
@@ -90,19 +298,160 @@ func main() {
 	transactionHandler := handler.NewTransaction(handler.TransactionOpts{
 		TransactionService:    transactionService,
 		TransactionRepository: transactionRepository,
+		AccountRepository:     accountRepository,
+	})
+
+	holdHandler := handler.NewHold(handler.HoldOpts{
+		HoldService:    holdService,
+		HoldRepository: holdRepository,
+	})
+
+	installmentHandler := handler.NewInstallment(handler.InstallmentOpts{
+		InstallmentRepository: installmentRepository,
+	})
+
+	statementHandler := handler.NewStatement(handler.StatementOpts{
+		StatementService:       statementService,
+		StatementRepository:    statementRepository,
+		BillingCycleRepository: billingCycleRepository,
+	})
+
+	ledgerHandler := handler.NewLedger(handler.LedgerOpts{
+		LedgerService:    ledgerService,
+		LedgerRepository: ledgerRepository,
+	})
+
+	cardHandler := handler.NewCard(handler.CardOpts{
+		CardService:    cardService,
+		CardRepository: cardRepository,
+	})
+
+	spendingControlHandler := handler.NewSpendingControl(handler.SpendingControlOpts{
+		SpendingControlService:    spendingControlService,
+		SpendingControlRepository: spendingControlRepository,
+	})
+
+	riskDecisionHandler := handler.NewRiskDecision(handler.RiskDecisionOpts{
+		RiskDecisionRepository: riskDecisionRepository,
+	})
+
+	authorizationHandler := handler.NewAuthorization(handler.AuthorizationOpts{
+		AuthorizationRepository: authorizationRepository,
 	})
 
 	server.GET("/docs/*", echoSwagger.WrapHandler)
-	server.GET(handler.AccountFindAllPath, accountHandler.FindAll)
-	server.GET(handler.AccountFindByIDPath, accountHandler.FindByID)
-	server.POST(handler.AccountCreatePath, accountHandler.Create)
+	server.GET(handler.AccountFindAllPath, accountHandler.FindAll, authenticate, handler.Scope(auth.ScopeAccountsRead))
+	server.GET(handler.AccountFindByIDPath, accountHandler.FindByID, authenticate, handler.Scope(auth.ScopeAccountsRead))
+	server.POST(handler.AccountCreatePath, accountHandler.Create, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.PATCH(handler.AccountUpdatePath, accountHandler.Update, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.DELETE(handler.AccountDeletePath, accountHandler.Delete, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.GET(handler.AccountChangesPath, accountHandler.Changes, authenticate, handler.Scope(auth.ScopeAccountsRead))
+
+	server.GET(handler.OperationFindAllPath, operationTypeHandler.FindAll, authenticate, handler.Scope(auth.ScopeOperationsRead))
+	server.GET(handler.OperationFindByIDPath, operationTypeHandler.FindByID, authenticate, handler.Scope(auth.ScopeOperationsRead))
+	server.POST(handler.OperationCreatePath, operationTypeHandler.Create, authenticate, handler.Scope(auth.ScopeOperationsAdmin))
+
+	server.GET(handler.TransactionFindAllPath, transactionHandler.FindAll, authenticate, handler.Scope(auth.ScopeTransactionsRead))
+	server.POST(handler.TransactionCreatePath, transactionHandler.Create, authenticate, handler.Scope(auth.ScopeTransactionsCreate))
+	server.POST(handler.TransactionReversePath, transactionHandler.Reverse, authenticate, handler.Scope(auth.ScopeTransactionsCreate))
+	server.GET(handler.TransactionExportPath, transactionHandler.Export, authenticate, handler.Scope(auth.ScopeTransactionsRead))
+
+	server.GET(handler.AuthorizationFindAllPath, authorizationHandler.FindAll, authenticate, handler.Scope(auth.ScopeTransactionsRead))
+
+	server.POST(handler.HoldAuthorizePath, holdHandler.Authorize, authenticate, handler.Scope(auth.ScopeTransactionsCreate))
+	server.GET(handler.HoldFindByIDPath, holdHandler.FindByID, authenticate, handler.Scope(auth.ScopeTransactionsRead))
+	server.POST(handler.HoldCapturePath, holdHandler.Capture, authenticate, handler.Scope(auth.ScopeTransactionsCreate))
+	server.POST(handler.HoldVoidPath, holdHandler.Void, authenticate, handler.Scope(auth.ScopeTransactionsCreate))
+
+	server.GET(handler.InstallmentFindAllPath, installmentHandler.FindAll, authenticate, handler.Scope(auth.ScopeTransactionsRead))
+
+	server.GET(handler.StatementBillingCyclePath, statementHandler.FindBillingCycle, authenticate, handler.Scope(auth.ScopeAccountsRead))
+	server.PUT(handler.StatementBillingCyclePath, statementHandler.Configure, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.GET(handler.StatementFindAllPath, statementHandler.FindAll, authenticate, handler.Scope(auth.ScopeAccountsRead))
+	server.GET(handler.StatementFindByPeriodPath, statementHandler.FindByPeriod, authenticate, handler.Scope(auth.ScopeAccountsRead))
+
+	server.GET(handler.LedgerFindAllPath, ledgerHandler.FindAll, authenticate, handler.Scope(auth.ScopeLedgerRead))
+	server.GET(handler.LedgerDriftPath, ledgerHandler.Drifts, authenticate, handler.Scope(auth.ScopeLedgerRead))
+
+	server.POST(handler.CardIssuePath, cardHandler.Issue, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.GET(handler.CardFindAllPath, cardHandler.FindAll, authenticate, handler.Scope(auth.ScopeAccountsRead))
+	server.GET(handler.CardFindByIDPath, cardHandler.FindByID, authenticate, handler.Scope(auth.ScopeAccountsRead))
+	server.POST(handler.CardBlockPath, cardHandler.Block, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.POST(handler.CardUnblockPath, cardHandler.Unblock, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.POST(handler.CardCancelPath, cardHandler.Cancel, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.POST(handler.CardLostPath, cardHandler.Lost, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+
+	server.GET(handler.SpendingControlAccountPath, spendingControlHandler.Find, authenticate, handler.Scope(auth.ScopeAccountsRead))
+	server.PUT(handler.SpendingControlAccountPath, spendingControlHandler.Configure, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+	server.GET(handler.SpendingControlCardPath, spendingControlHandler.Find, authenticate, handler.Scope(auth.ScopeAccountsRead))
+	server.PUT(handler.SpendingControlCardPath, spendingControlHandler.Configure, authenticate, handler.Scope(auth.ScopeAccountsWrite))
+
+	server.GET(handler.RiskDecisionFindAllPath, riskDecisionHandler.FindAll, authenticate, handler.Scope(auth.ScopeTransactionsRead))
+
+	// every replica starts the workers, the advisory lock lets a single one run each job
+	locker := worker.NewAdvisoryLocker(server.Logger, db)
+	workers, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go worker.New(worker.Opts{
+		Logger:   server.Logger,
+		Name:     "idempotency-key-purge",
+		Interval: time.Hour,
+		Timeout:  time.Minute,
+		Locker:   locker,
+		Job: func(ctx context.Context) error {
+			_, err := idempotencyKeyRepository.DeleteExpired(ctx, time.Now())
+			return err
+		},
+	}).Start(workers)
+
+	go worker.New(worker.Opts{
+		Logger:   server.Logger,
+		Name:     "hold-expire",
+		Interval: time.Minute,
+		Timeout:  time.Minute,
+		Locker:   locker,
+		Job: func(ctx context.Context) error {
+			_, err := holdService.Expire(ctx)
+			return err
+		},
+	}).Start(workers)
+
+	go worker.New(worker.Opts{
+		Logger:   server.Logger,
+		Name:     "installment-post",
+		Interval: time.Hour,
+		Timeout:  time.Minute,
+		Locker:   locker,
+		Job: func(ctx context.Context) error {
+			_, err := installmentService.Post(ctx)
+			return err
+		},
+	}).Start(workers)
 
-	server.GET(handler.OperationFindAllPath, operationTypeHandler.FindAll)
-	server.GET(handler.OperationFindByIDPath, operationTypeHandler.FindByID)
-	server.POST(handler.OperationCreatePath, operationTypeHandler.Create)
+	go worker.New(worker.Opts{
+		Logger:   server.Logger,
+		Name:     "statement-close",
+		Interval: time.Hour,
+		Timeout:  5 * time.Minute,
+		Locker:   locker,
+		Job: func(ctx context.Context) error {
+			_, err := statementService.Close(ctx)
+			return err
+		},
+	}).Start(workers)
 
-	server.GET(handler.TransactionFindAllPath, transactionHandler.FindAll)
-	server.POST(handler.TransactionCreatePath, transactionHandler.Create)
+	go worker.New(worker.Opts{
+		Logger:   server.Logger,
+		Name:     "ledger-reconcile",
+		Interval: time.Hour,
+		Timeout:  5 * time.Minute,
+		Locker:   locker,
+		Job: func(ctx context.Context) error {
+			_, err := ledgerService.Reconcile(ctx)
+			return err
+		},
+	}).Start(workers)
 
 	go func() {
 		binding := os.Getenv("API_PORT")
@@ -115,6 +464,7 @@ func main() {
 	signal.Notify(quit, os.Interrupt)
 
 	<-quit
+	stopWorkers()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/joho/godotenv"
+	"github.com/labstack/gommon/log"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/migration"
+	"ms/card/pkg/persistence/repository"
+	"os"
+	"strings"
+	"time"
+)
+
+// apikey issues an api key to a client. Only its hash is stored, the key is printed once.
+//
+//	go run ./cmd/apikey -client backoffice -scopes accounts:read,accounts:write
+func main() {
+	_ = godotenv.Load()
+	console := log.New("apikey")
+
+	client := flag.String("client", "", "client id the key is issued to")
+	scopes := flag.String("scopes", "", "comma separated scopes granted to the key")
+	flag.Parse()
+
+	if *client == "" || *scopes == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	db, err := gorm.Open(postgres.Open(os.Getenv("API_DB_DSN")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+
+	if err != nil {
+		console.Fatalf("gorm.Open() failed with %s\n", err)
+	}
+
+	migrations, err := migration.Load(migration.Files)
+	if err != nil {
+		console.Fatalf("migration.Load() failed with %s\n", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := migration.NewMigrator(console, db, migrations).Check(ctx); err != nil {
+		console.Fatalf("migrator.Check() failed with %s\n", err)
+	}
+
+	key, err := auth.GenerateKey()
+	if err != nil {
+		console.Fatalf("auth.GenerateKey() failed with %s\n", err)
+	}
+
+	_, err = repository.NewAPIKey(console, db).Create(ctx, entity.APIKey{
+		Client:    *client,
+		Hash:      auth.Hash(key),
+		Scopes:    strings.Split(*scopes, ","),
+		CreatedAt: time.Now(),
+	})
+
+	if err != nil {
+		console.Fatalf("APIKeyRepository.Create() failed with %s\n", err)
+	}
+
+	fmt.Println(key)
+}
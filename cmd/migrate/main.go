@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/joho/godotenv"
+	"github.com/labstack/gommon/log"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"ms/card/pkg/persistence/migration"
+	"os"
+	"strconv"
+	"time"
+)
+
+const usage = `usage: migrate <command>
+
+  up               apply every pending migration
+  down [steps]     revert the last steps migrations, one by default
+  to VERSION       apply or revert migrations until VERSION, 0 reverts them all
+  force VERSION    record VERSION as applied and clear the dirty flag, without running anything
+  status           list the migrations and whether they are applied
+`
+
+// migrate applies the versioned SQL migrations embedded in the binary.
+//
+//	go run ./cmd/migrate up
+func main() {
+	_ = godotenv.Load()
+	console := log.New("migrate")
+
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	timeout := flag.Duration("timeout", 10*time.Minute, "give up after this long, waiting for the lock included")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	db, err := gorm.Open(postgres.Open(os.Getenv("API_DB_DSN")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+
+	if err != nil {
+		console.Fatalf("gorm.Open() failed with %s\n", err)
+	}
+
+	migrations, err := migration.Load(migration.Files)
+	if err != nil {
+		console.Fatalf("migration.Load() failed with %s\n", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	migrator := migration.NewMigrator(console, db, migrations)
+	switch flag.Arg(0) {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if flag.NArg() > 1 {
+			steps, err = strconv.Atoi(flag.Arg(1))
+			if err != nil || steps < 1 {
+				console.Fatalf("steps must be a positive number, got %s\n", flag.Arg(1))
+			}
+		}
+
+		err = migrator.Down(ctx, steps)
+	case "to":
+		err = migrator.To(ctx, version(console))
+	case "force":
+		err = migrator.Force(ctx, version(console))
+	case "status":
+		err = status(ctx, migrator)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		console.Fatalf("migrate %s failed with %s\n", flag.Arg(0), err)
+	}
+}
+
+func version(console *log.Logger) uint {
+	version, err := strconv.ParseUint(flag.Arg(1), 10, 32)
+	if err != nil {
+		console.Fatalf("a migration version is required, got %q\n", flag.Arg(1))
+	}
+
+	return uint(version)
+}
+
+func status(ctx context.Context, migrator *migration.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		state := "pending"
+		switch {
+		case status.Dirty:
+			state = "dirty"
+		case status.Applied:
+			state = "applied " + status.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Printf("%04d %-40s %s\n", status.Version, status.Name, state)
+	}
+
+	return nil
+}
@@ -1,25 +1,30 @@
 package handler
 
 import (
+	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/labstack/echo/v4"
 	"ms/card/pkg/contract"
-	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
 	"ms/card/pkg/telemetry/jaeger"
 	"net/http"
-	"strconv"
 )
 
 const (
 	AccountFindAllPath  = "/accounts"
 	AccountFindByIDPath = "/accounts/:id"
 	AccountCreatePath   = "/accounts"
+	AccountUpdatePath   = "/accounts/:id"
+	AccountDeletePath   = "/accounts/:id"
+	AccountChangesPath  = "/accounts/:id/changes"
 )
 
 type (
 	AccountOpts struct {
-		AccountRepository repository.Accounts
+		AccountService          service.Accounts
+		AccountRepository       repository.Accounts
+		AccountChangeRepository repository.AccountChanges
 	}
 	Account struct {
 		AccountOpts
@@ -37,23 +42,21 @@ func (a *Account) Create(c echo.Context) error {
 	request := &contract.AccountRequest{}
 	if err := c.Bind(request); err != nil {
 		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	if err := request.Validate(); err != nil {
 		c.Logger().Errorf("request.Validate failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	account, err := a.AccountRepository.Create(ctx, entity.Account{
-		Document: request.Document,
-		Limit:    request.Limit,
-	})
+	account, err := a.AccountService.Create(ctx, request)
 	if err != nil {
-		c.Logger().Errorf("a.AccountRepository.Create failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Logger().Errorf("a.AccountService.Create failed with %s\n", err.Error())
+		return err
 	}
 
+	maskAccounts(c, account)
 	return c.JSON(http.StatusCreated, account)
 }
 
@@ -61,13 +64,19 @@ func (a *Account) FindByID(c echo.Context) error {
 	ctx, span := jaeger.Span(c.Request().Context())
 	defer span.End()
 
-	id, _ := strconv.Atoi(c.Param("id"))
-	account, err := a.AccountRepository.FindByID(ctx, uint(id))
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	account, err := a.AccountRepository.FindByID(ctx, id)
 	if err != nil {
 		c.Logger().Errorf("a.AccountRepository.FindByID failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
+	maskAccounts(c, account)
 	return c.JSON(http.StatusOK, account)
 }
 
@@ -75,17 +84,100 @@ func (a *Account) FindAll(c echo.Context) error {
 	ctx, span := jaeger.Span(c.Request().Context())
 	defer span.End()
 
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	size, _ := strconv.Atoi(c.QueryParam("size"))
-	accounts, err := a.AccountRepository.FindAll(ctx, filter.AccountCollection{
-		Page:     page,
-		Size:     size,
+	invalid := validation.Errors{}
+	filters := filter.AccountCollection{
+		Page:     number(c, "page", invalid),
+		Size:     number(c, "size", invalid),
+		Sort:     sorting(c, filter.AccountSortFields, invalid),
 		Document: c.QueryParam("document_number"),
-	})
+		Status:   c.QueryParam("status"),
+	}
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("query validation failed with %s\n", err.Error())
+		return err
+	}
+
+	collection, err := a.AccountRepository.FindAll(ctx, filters)
 	if err != nil {
 		c.Logger().Errorf("a.AccountRepository.FindAll failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	maskAccounts(c, collection.Data...)
+	collection.Link(c.Request().URL)
+	return c.JSON(http.StatusOK, collection)
+}
+
+func (a *Account) Update(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	request := &contract.AccountUpdateRequest{}
+	if err := c.Bind(request); err != nil {
+		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		c.Logger().Errorf("request.Validate failed with %s\n", err.Error())
+		return err
+	}
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	account, err := a.AccountService.Update(ctx, id, request)
+	if err != nil {
+		c.Logger().Errorf("a.AccountService.Update failed with %s\n", err.Error())
+		return err
+	}
+
+	maskAccounts(c, account)
+	return c.JSON(http.StatusOK, account)
+}
+
+func (a *Account) Delete(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	if err := a.AccountService.Delete(ctx, id); err != nil {
+		c.Logger().Errorf("a.AccountService.Delete failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (a *Account) Changes(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	invalid := validation.Errors{}
+	filters := filter.AccountChangeCollection{
+		Page:    number(c, "page", invalid),
+		Size:    number(c, "size", invalid),
+		Account: identifier(c, "id", invalid),
+		Field:   c.QueryParam("field"),
+	}
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("query validation failed with %s\n", err.Error())
+		return err
+	}
+
+	changes, err := a.AccountChangeRepository.FindAll(ctx, filters)
+	if err != nil {
+		c.Logger().Errorf("a.AccountChangeRepository.FindAll failed with %s\n", err.Error())
+		return err
 	}
 
-	return c.JSON(http.StatusOK, accounts)
+	return c.JSON(http.StatusOK, changes)
 }
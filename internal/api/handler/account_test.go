@@ -5,9 +5,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -18,23 +22,27 @@ func TestHandlerAccount_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAccountRepository := repository.NewMockAccounts(ctrl)
-	mockAccountRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Account{
-		ID:       1,
-		Document: "56077053074",
-		Limit:    2000,
+	mockAccountService := service.NewMockAccounts(ctrl)
+	mockAccountService.EXPECT().Create(gomock.Any(), &contract.AccountRequest{Document: "56077053074"}).Return(&entity.Account{
+		ID:             1,
+		Document:       "56077053074",
+		DocumentType:   "cpf",
+		CreditLimit:    2000,
+		AvailableLimit: 2000,
+		Currency:       "BRL",
+		Status:         "active",
 	}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, AccountCreatePath, strings.NewReader(`{"document_number":"56077053074"}`))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	h := NewAccount(AccountOpts{
-		AccountRepository: mockAccountRepository,
+		AccountService: mockAccountService,
 	})
 
 	if assert.NoError(t, h.Create(echo.New().NewContext(req, rec))) {
 		assert.Equal(t, http.StatusCreated, rec.Code)
-		assert.JSONEq(t, `{"id":1,"document_number":"56077053074","limit":2000}`, rec.Body.String())
+		assert.JSONEq(t, `{"id":1,"document_number":"*********74","document_type":"cpf","credit_limit":2000,"available_limit":2000,"used_amount":0,"currency":"BRL","status":"active"}`, rec.Body.String())
 	}
 }
 
@@ -42,17 +50,17 @@ func TestHandlerAccount_Create_Persist_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAccountRepository := repository.NewMockAccounts(ctrl)
-	mockAccountRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrAccountCreate)
+	mockAccountService := service.NewMockAccounts(ctrl)
+	mockAccountService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrAccountCreate)
 
 	req := httptest.NewRequest(http.MethodPost, AccountCreatePath, strings.NewReader(`{"document_number":"56077053074"}`))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	h := NewAccount(AccountOpts{
-		AccountRepository: mockAccountRepository,
+		AccountService: mockAccountService,
 	})
 
-	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=failed to create new account")
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "failed to create new account")
 }
 
 func TestHandlerAccount_Create_BindRequest_Error(t *testing.T) {
@@ -63,7 +71,7 @@ func TestHandlerAccount_Create_BindRequest_Error(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	h := NewAccount(AccountOpts{})
-	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=code=400, message=unexpected EOF, internal=unexpected EOF")
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=unexpected EOF, internal=unexpected EOF")
 }
 
 func TestHandlerAccount_Create_Validate_Error(t *testing.T) {
@@ -75,7 +83,7 @@ func TestHandlerAccount_Create_Validate_Error(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h := NewAccount(AccountOpts{})
 
-	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=document_number: cannot be blank.")
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "document_number: cannot be blank.")
 }
 
 func TestHandlerAccount_FindByID(t *testing.T) {
@@ -84,9 +92,13 @@ func TestHandlerAccount_FindByID(t *testing.T) {
 
 	mockAccountRepository := repository.NewMockAccounts(ctrl)
 	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{
-		ID:       1,
-		Document: "56077053074",
-		Limit:    2000,
+		ID:             1,
+		Document:       "56077053074",
+		DocumentType:   "cpf",
+		CreditLimit:    2000,
+		AvailableLimit: 1500,
+		Currency:       "BRL",
+		Status:         "active",
 	}, nil)
 
 	server := echo.New()
@@ -104,7 +116,38 @@ func TestHandlerAccount_FindByID(t *testing.T) {
 
 	if assert.NoError(t, h.FindByID(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
-		assert.JSONEq(t, `{"id":1,"document_number":"56077053074","limit":2000}`, rec.Body.String())
+		assert.JSONEq(t, `{"id":1,"document_number":"*********74","document_type":"cpf","credit_limit":2000,"available_limit":1500,"used_amount":500,"currency":"BRL","status":"active"}`, rec.Body.String())
+	}
+}
+
+func TestHandlerAccount_FindByID_PII(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{
+		ID:           1,
+		Document:     "11222333000181",
+		DocumentType: "cnpj",
+		Currency:     "BRL",
+		Status:       "active",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, AccountFindByIDPath, nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Client: "backoffice", Scopes: []string{auth.ScopeAccountsRead, auth.ScopePIIRead}}))
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(AccountFindByIDPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	h := NewAccount(AccountOpts{
+		AccountRepository: mockAccountRepository,
+	})
+
+	if assert.NoError(t, h.FindByID(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"id":1,"document_number":"11222333000181","document_type":"cnpj","credit_limit":0,"available_limit":0,"used_amount":0,"currency":"BRL","status":"active"}`, rec.Body.String())
 	}
 }
 
@@ -128,7 +171,7 @@ func TestHandlerAccount_FindByID_Error(t *testing.T) {
 		AccountRepository: mockAccountRepository,
 	})
 
-	assert.EqualError(t, h.FindByID(c), "code=400, message=failed fetch the account")
+	assert.EqualError(t, h.FindByID(c), "failed fetch the account")
 }
 
 func TestHandlerAccount_FindAll(t *testing.T) {
@@ -136,21 +179,32 @@ func TestHandlerAccount_FindAll(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAccountRepository := repository.NewMockAccounts(ctrl)
-	mockAccountRepository.EXPECT().FindAll(gomock.Any(), filter.AccountCollection{}).Return([]*entity.Account{
-		{
-			ID:       1,
-			Document: "56077053074",
-			Limit:    2000,
-		},
-		{
-			ID:       2,
-			Document: "87756158008",
-			Limit:    3000,
+	mockAccountRepository.EXPECT().FindAll(gomock.Any(), filter.AccountCollection{}).Return(&entity.AccountCollection{
+		Pagination: persistence.NewPagination(1, 10, 12),
+		Data: []*entity.Account{
+			{
+				ID:             1,
+				Document:       "56077053074",
+				DocumentType:   "cpf",
+				CreditLimit:    2000,
+				AvailableLimit: 2000,
+				Currency:       "BRL",
+				Status:         "active",
+			},
+			{
+				ID:             2,
+				Document:       "87756158008",
+				DocumentType:   "cpf",
+				CreditLimit:    3000,
+				AvailableLimit: 3000,
+				Currency:       "BRL",
+				Status:         "active",
+			},
 		},
 	}, nil)
 
 	server := echo.New()
-	req := httptest.NewRequest(http.MethodGet, AccountFindByIDPath, nil)
+	req := httptest.NewRequest(http.MethodGet, AccountFindAllPath, nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := server.NewContext(req, rec)
@@ -161,14 +215,55 @@ func TestHandlerAccount_FindAll(t *testing.T) {
 	if assert.NoError(t, h.FindAll(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.JSONEq(t, `
-		[
-			{"id":1,"document_number":"56077053074","limit":2000},
-			{"id":2,"document_number":"87756158008","limit":3000}
-		]
+		{
+			"page": 1,
+			"size": 10,
+			"total": 12,
+			"next": "/accounts?page=2",
+			"data": [
+				{"id":1,"document_number":"*********74","document_type":"cpf","credit_limit":2000,"available_limit":2000,"used_amount":0,"currency":"BRL","status":"active"},
+				{"id":2,"document_number":"*********08","document_type":"cpf","credit_limit":3000,"available_limit":3000,"used_amount":0,"currency":"BRL","status":"active"}
+			]
+		}
 		`, rec.Body.String())
 	}
 }
 
+func TestHandlerAccount_FindAll_Sorted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindAll(gomock.Any(), filter.AccountCollection{
+		Size: 5,
+		Sort: filter.Sort{Column: `"limit"`, Descending: true},
+	}).Return(&entity.AccountCollection{Data: []*entity.Account{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, AccountFindAllPath+"?size=5&sort=available_limit:desc", nil)
+	rec := httptest.NewRecorder()
+	h := NewAccount(AccountOpts{
+		AccountRepository: mockAccountRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandlerAccount_FindAll_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, AccountFindAllPath+"?page=first&sort=document_number", nil)
+	rec := httptest.NewRecorder()
+	h := NewAccount(AccountOpts{
+		AccountRepository: repository.NewMockAccounts(ctrl),
+	})
+
+	assert.EqualError(t, h.FindAll(echo.New().NewContext(req, rec)),
+		"page: must be a non-negative integer; sort: must be one of available_limit, credit_limit, id, status.")
+}
+
 func TestHandlerAccount_FindAll_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -185,5 +280,172 @@ func TestHandlerAccount_FindAll_Error(t *testing.T) {
 		AccountRepository: mockAccountRepository,
 	})
 
-	assert.EqualError(t, h.FindAll(c), "code=400, message=err find all")
+	assert.EqualError(t, h.FindAll(c), "err find all")
+}
+
+func TestHandlerAccount_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limit := int64(8000)
+	mockAccountService := service.NewMockAccounts(ctrl)
+	mockAccountService.EXPECT().Update(gomock.Any(), uint(1), &contract.AccountUpdateRequest{Limit: &limit, Reason: "income review"}).Return(&entity.Account{
+		ID:             1,
+		Document:       "56077053074",
+		DocumentType:   "cpf",
+		CreditLimit:    6000,
+		AvailableLimit: 6000,
+		Currency:       "BRL",
+		Status:         "active",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, AccountUpdatePath, strings.NewReader(`{"limit":8000,"reason":"income review"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(AccountUpdatePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	h := NewAccount(AccountOpts{
+		AccountService: mockAccountService,
+	})
+
+	if assert.NoError(t, h.Update(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"id":1,"document_number":"*********74","document_type":"cpf","credit_limit":6000,"available_limit":6000,"used_amount":0,"currency":"BRL","status":"active"}`, rec.Body.String())
+	}
+}
+
+func TestHandlerAccount_Update_Validate_Error(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPatch, AccountUpdatePath, strings.NewReader(`{"status":"frozen"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	h := NewAccount(AccountOpts{})
+
+	assert.EqualError(t, h.Update(echo.New().NewContext(req, rec)), "status: must be a valid value.")
+}
+
+func TestHandlerAccount_Update_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountService := service.NewMockAccounts(ctrl)
+	mockAccountService.EXPECT().Update(gomock.Any(), uint(1), gomock.Any()).Return(nil, service.ErrAccountTransition)
+
+	req := httptest.NewRequest(http.MethodPatch, AccountUpdatePath, strings.NewReader(`{"status":"active"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(AccountUpdatePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	h := NewAccount(AccountOpts{
+		AccountService: mockAccountService,
+	})
+
+	assert.Equal(t, service.ErrAccountTransition, h.Update(c))
+}
+
+func TestHandlerAccount_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountService := service.NewMockAccounts(ctrl)
+	mockAccountService.EXPECT().Delete(gomock.Any(), uint(1)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, AccountDeletePath, nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(AccountDeletePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	h := NewAccount(AccountOpts{
+		AccountService: mockAccountService,
+	})
+
+	if assert.NoError(t, h.Delete(c)) {
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+}
+
+func TestHandlerAccount_Changes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountChangeRepository := repository.NewMockAccountChanges(ctrl)
+	mockAccountChangeRepository.EXPECT().FindAll(gomock.Any(), filter.AccountChangeCollection{Account: 1, Field: "status"}).Return([]*entity.AccountChange{
+		{ID: 2, Account: 1, Field: "status", From: "active", To: "blocked", CreatedBy: "backoffice"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/changes?field=status", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(AccountChangesPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	h := NewAccount(AccountOpts{
+		AccountChangeRepository: mockAccountChangeRepository,
+	})
+
+	if assert.NoError(t, h.Changes(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{"id":2,"account_id":1,"field":"status","from":"active","to":"blocked","created_by":"backoffice","created_at":"0001-01-01T00:00:00Z"}]`, rec.Body.String())
+	}
+}
+
+func TestHandlerAccount_Changes_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/abc/changes?size=big", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(AccountChangesPath)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+
+	h := NewAccount(AccountOpts{
+		AccountChangeRepository: repository.NewMockAccountChanges(ctrl),
+	})
+
+	assert.EqualError(t, h.Changes(c), "id: must be a positive integer; size: must be a non-negative integer.")
+}
+
+func TestHandlerAccount_Invalid_Path(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewAccount(AccountOpts{
+		AccountService:    service.NewMockAccounts(ctrl),
+		AccountRepository: repository.NewMockAccounts(ctrl),
+	})
+
+	cases := []struct {
+		name    string
+		method  string
+		body    string
+		handler echo.HandlerFunc
+	}{
+		{name: "find", method: http.MethodGet, handler: h.FindByID},
+		{name: "update", method: http.MethodPatch, body: `{"status":"blocked"}`, handler: h.Update},
+		{name: "delete", method: http.MethodDelete, handler: h.Delete},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			c.SetPath(AccountFindByIDPath)
+			c.SetParamNames("id")
+			c.SetParamValues("abc")
+
+			assert.EqualError(t, tt.handler(c), "id: must be a positive integer.")
+		})
+	}
 }
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/auth"
+	"strings"
+)
+
+const (
+	HeaderAPIKey = "X-API-Key"
+
+	bearer = "Bearer "
+)
+
+type (
+	// AuthOpts holds an authenticator per credential, an unset one refuses its credential.
+	AuthOpts struct {
+		APIKeys auth.Authenticator
+		Tokens  auth.Authenticator
+	}
+)
+
+// Authenticate resolves the client of the request from the X-API-Key header or a bearer
+// token, the principal is then available from the request context.
+func Authenticate(opts AuthOpts) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			key := c.Request().Header.Get(HeaderAPIKey)
+			authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+
+			var principal *auth.Principal
+			var err error = auth.ErrUnauthenticated
+			switch {
+			case key != "" && opts.APIKeys != nil:
+				principal, err = opts.APIKeys.Authenticate(ctx, key)
+			case strings.HasPrefix(authorization, bearer) && opts.Tokens != nil:
+				principal, err = opts.Tokens.Authenticate(ctx, strings.TrimPrefix(authorization, bearer))
+			}
+
+			if err != nil {
+				c.Logger().Errorf("Authenticate failed with %s\n", err.Error())
+				return err
+			}
+
+			c.SetRequest(c.Request().WithContext(auth.WithPrincipal(ctx, principal)))
+			return next(c)
+		}
+	}
+}
+
+// Scope only lets through the clients granted scope, it must come after Authenticate.
+func Scope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := auth.FromContext(c.Request().Context())
+			if !ok {
+				return auth.ErrUnauthenticated
+			}
+
+			if !principal.Allowed(scope) {
+				return auth.ErrForbidden
+			}
+
+			return next(c)
+		}
+	}
+}
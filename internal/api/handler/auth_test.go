@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func guarded(c echo.Context) error {
+	return c.String(http.StatusOK, auth.Client(c.Request().Context()))
+}
+
+func TestAuthenticate_APIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepository := repository.NewMockAPIKeys(ctrl)
+	mockAPIKeyRepository.EXPECT().FindByHash(gomock.Any(), auth.Hash("ck_secret")).Return(&entity.APIKey{
+		Client: "checkout",
+		Scopes: entity.List{auth.ScopeTransactionsCreate},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, TransactionCreatePath, nil)
+	req.Header.Set(HeaderAPIKey, "ck_secret")
+	rec := httptest.NewRecorder()
+
+	h := Authenticate(AuthOpts{APIKeys: auth.NewAPIKey(auth.APIKeyOpts{APIKeyRepository: mockAPIKeyRepository})})(Scope(auth.ScopeTransactionsCreate)(guarded))
+	if assert.NoError(t, h(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, "checkout", rec.Body.String())
+	}
+}
+
+func TestAuthenticate_Missing_Credentials(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, TransactionCreatePath, nil)
+	req.Header.Set(echo.HeaderAuthorization, "Basic dXNlcjpwYXNz")
+	rec := httptest.NewRecorder()
+
+	h := Authenticate(AuthOpts{})(guarded)
+	err := h(echo.New().NewContext(req, rec))
+	assert.Equal(t, auth.ErrUnauthenticated, err)
+	assert.Equal(t, http.StatusUnauthorized, NewProblem(err).Status)
+}
+
+func TestScope_Forbidden(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, OperationCreatePath, nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Client: "checkout", Scopes: []string{auth.ScopeTransactionsCreate}}))
+	rec := httptest.NewRecorder()
+
+	err := Scope(auth.ScopeOperationsAdmin)(guarded)(echo.New().NewContext(req, rec))
+	assert.Equal(t, auth.ErrForbidden, err)
+	assert.Equal(t, http.StatusForbidden, NewProblem(err).Status)
+}
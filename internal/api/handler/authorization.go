@@ -0,0 +1,53 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+	"net/http"
+)
+
+const (
+	AuthorizationFindAllPath = "/authorizations"
+)
+
+type (
+	AuthorizationOpts struct {
+		AuthorizationRepository repository.Authorizations
+	}
+
+	Authorization struct {
+		AuthorizationOpts
+	}
+)
+
+func NewAuthorization(opts AuthorizationOpts) *Authorization {
+	return &Authorization{opts}
+}
+
+func (h *Authorization) FindAll(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	invalid := validation.Errors{}
+	filters := transactionFilters(c, filter.AuthorizationSortFields, invalid)
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("transactionFilters failed with %s\n", err.Error())
+		return err
+	}
+
+	collection, err := h.AuthorizationRepository.FindAll(ctx, filter.AuthorizationCollection{
+		TransactionCollection: filters,
+		Outcome:               c.QueryParam("outcome"),
+		Code:                  c.QueryParam("code"),
+	})
+	if err != nil {
+		c.Logger().Errorf("h.AuthorizationRepository.FindAll failed with %s\n", err.Error())
+		return err
+	}
+
+	collection.Link(c.Request().URL)
+	return c.JSON(http.StatusOK, collection)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandlerAuthorization_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorizationRepository := repository.NewMockAuthorizations(ctrl)
+	mockAuthorizationRepository.EXPECT().FindAll(gomock.Any(), filter.AuthorizationCollection{
+		TransactionCollection: filter.TransactionCollection{Size: 1, Accounts: []uint{1}, MCC: "5411"},
+		Outcome:               entity.AuthorizationOutcomeDeclined,
+	}).Return(&entity.AuthorizationCollection{
+		Pagination: persistence.NewPagination(1, 1, 2),
+		Data: []*entity.Authorization{
+			{ID: 3, Account: 1, Type: 1, Amount: 5000, Outcome: entity.AuthorizationOutcomeDeclined, Code: "limit_exceeded", Merchant: entity.Merchant{MCC: "5411"}},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, AuthorizationFindAllPath+"?account_id=1&mcc=5411&outcome=declined&size=1", nil)
+	rec := httptest.NewRecorder()
+	h := NewAuthorization(AuthorizationOpts{
+		AuthorizationRepository: mockAuthorizationRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var collection entity.AuthorizationCollection
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &collection))
+		assert.Equal(t, "/authorizations?account_id=1&mcc=5411&outcome=declined&page=2&size=1", collection.Next)
+		assert.Equal(t, "limit_exceeded", collection.Data[0].Code)
+	}
+}
+
+func TestHandlerAuthorization_FindAll_After(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorizationRepository := repository.NewMockAuthorizations(ctrl)
+	mockAuthorizationRepository.EXPECT().FindAll(gomock.Any(), filter.AuthorizationCollection{
+		TransactionCollection: filter.TransactionCollection{Size: 2, After: 10},
+		Outcome:               entity.AuthorizationOutcomeDeclined,
+	}).Return(&entity.AuthorizationCollection{
+		Pagination: persistence.Pagination{Size: 2, NextCursor: 12, PrevCursor: 11},
+		Data:       []*entity.Authorization{{ID: 11}, {ID: 12}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, AuthorizationFindAllPath+"?outcome=declined&after=10&size=2", nil)
+	rec := httptest.NewRecorder()
+	h := NewAuthorization(AuthorizationOpts{
+		AuthorizationRepository: mockAuthorizationRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var collection entity.AuthorizationCollection
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &collection))
+		assert.Equal(t, "/authorizations?after=12&outcome=declined&size=2", collection.Next)
+		assert.Equal(t, "/authorizations?before=11&outcome=declined&size=2", collection.Prev)
+	}
+}
+
+func TestHandlerAuthorization_FindAll_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorizationRepository := repository.NewMockAuthorizations(ctrl)
+	mockAuthorizationRepository.EXPECT().FindAll(gomock.Any(), filter.AuthorizationCollection{}).Return(nil, repository.ErrAuthorizationFind)
+
+	req := httptest.NewRequest(http.MethodGet, AuthorizationFindAllPath, nil)
+	rec := httptest.NewRecorder()
+	h := NewAuthorization(AuthorizationOpts{
+		AuthorizationRepository: mockAuthorizationRepository,
+	})
+
+	assert.EqualError(t, h.FindAll(echo.New().NewContext(req, rec)), ""+repository.ErrAuthorizationFind.Error())
+}
+
+func TestHandlerAuthorization_FindAll_Period_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, AuthorizationFindAllPath+"?from=yesterday", nil)
+	rec := httptest.NewRecorder()
+	h := NewAuthorization(AuthorizationOpts{
+		AuthorizationRepository: repository.NewMockAuthorizations(ctrl),
+	})
+
+	assert.EqualError(t, h.FindAll(echo.New().NewContext(req, rec)), "from: must be an RFC 3339 timestamp.")
+}
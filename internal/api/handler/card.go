@@ -0,0 +1,155 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"ms/card/pkg/telemetry/jaeger"
+	"net/http"
+)
+
+const (
+	CardIssuePath    = "/accounts/:id/cards"
+	CardFindAllPath  = "/accounts/:id/cards"
+	CardFindByIDPath = "/accounts/:id/cards/:card"
+	CardBlockPath    = "/accounts/:id/cards/:card/block"
+	CardUnblockPath  = "/accounts/:id/cards/:card/unblock"
+	CardCancelPath   = "/accounts/:id/cards/:card/cancel"
+	CardLostPath     = "/accounts/:id/cards/:card/lost"
+)
+
+type (
+	CardOpts struct {
+		CardService    service.Cards
+		CardRepository repository.Cards
+	}
+
+	Card struct {
+		CardOpts
+	}
+)
+
+func NewCard(opts CardOpts) *Card {
+	return &Card{opts}
+}
+
+func (h *Card) Issue(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	request := &contract.CardRequest{}
+	if err := c.Bind(request); err != nil {
+		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
+		return err
+	}
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	card, err := h.CardService.Issue(ctx, id, request)
+	if err != nil {
+		c.Logger().Errorf("h.CardService.Issue failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, card)
+}
+
+func (h *Card) FindAll(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	invalid := validation.Errors{}
+	filters := filter.CardCollection{
+		Page:    number(c, "page", invalid),
+		Size:    number(c, "size", invalid),
+		Account: identifier(c, "id", invalid),
+		Status:  c.QueryParam("status"),
+	}
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("query validation failed with %s\n", err.Error())
+		return err
+	}
+
+	cards, err := h.CardRepository.FindAll(ctx, filters)
+	if err != nil {
+		c.Logger().Errorf("h.CardRepository.FindAll failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, cards)
+}
+
+func (h *Card) FindByID(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, number, err := cardParams(c)
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	card, err := h.CardRepository.FindByID(ctx, number)
+	if err == nil && card.Account != id {
+		err = repository.ErrCardNotFound
+	}
+
+	if err != nil {
+		c.Logger().Errorf("h.CardRepository.FindByID failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, card)
+}
+
+func (h *Card) Block(c echo.Context) error {
+	return h.updateStatus(c, entity.CardStatusBlocked)
+}
+
+func (h *Card) Unblock(c echo.Context) error {
+	return h.updateStatus(c, entity.CardStatusActive)
+}
+
+func (h *Card) Cancel(c echo.Context) error {
+	return h.updateStatus(c, entity.CardStatusCancelled)
+}
+
+func (h *Card) Lost(c echo.Context) error {
+	return h.updateStatus(c, entity.CardStatusLost)
+}
+
+func (h *Card) updateStatus(c echo.Context, status string) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, number, err := cardParams(c)
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	card, err := h.CardService.UpdateStatus(ctx, id, number, status)
+	if err != nil {
+		c.Logger().Errorf("h.CardService.UpdateStatus failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, card)
+}
+
+// cardParams parses the account and the card of /accounts/:id/cards/:card, both reported
+// when malformed.
+func cardParams(c echo.Context) (uint, uint, error) {
+	invalid := validation.Errors{}
+	id := identifier(c, "id", invalid)
+	number := identifier(c, "card", invalid)
+	return id, number, invalid.Filter()
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerCard_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	mockCardService := service.NewMockCards(ctrl)
+	mockCardService.EXPECT().Issue(gomock.Any(), uint(1), &contract.CardRequest{Type: "virtual"}).Return(&entity.Card{
+		ID:        1,
+		Account:   1,
+		Token:     "token",
+		LastFour:  "1234",
+		PAN:       "5502090000001234",
+		Type:      entity.CardTypeVirtual,
+		Status:    entity.CardStatusActive,
+		ExpiresAt: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/1/cards", strings.NewReader(`{"type":"virtual"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(CardIssuePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewCard(CardOpts{
+		CardService: mockCardService,
+	})
+
+	if assert.NoError(t, h.Issue(c)) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{
+			"id":1,"account_id":1,"token":"token","last_four":"1234","pan":"5502090000001234","type":"virtual","status":"active",
+			"expires_at":"2023-04-01T00:00:00Z","created_at":"2022-03-12T01:02:03.000000004Z","updated_at":"2022-03-12T01:02:03.000000004Z"
+		}`, rec.Body.String())
+	}
+}
+
+func TestHandlerCard_Issue_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCardService := service.NewMockCards(ctrl)
+	mockCardService.EXPECT().Issue(gomock.Any(), uint(1), gomock.Any()).Return(nil, repository.ErrAccountCreateNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/1/cards", strings.NewReader(`{"type":"virtual"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(CardIssuePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewCard(CardOpts{
+		CardService: mockCardService,
+	})
+
+	assert.EqualError(t, h.Issue(c), "account not found")
+}
+
+func TestHandlerCard_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCardRepository := repository.NewMockCards(ctrl)
+	mockCardRepository.EXPECT().FindAll(gomock.Any(), filter.CardCollection{Account: 1, Status: "blocked"}).Return([]*entity.Card{
+		{ID: 2, Account: 1, LastFour: "5678", Status: entity.CardStatusBlocked},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/cards?status=blocked", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(CardFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewCard(CardOpts{
+		CardRepository: mockCardRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"last_four":"5678"`)
+	}
+}
+
+func TestHandlerCard_FindByID_OtherAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCardRepository := repository.NewMockCards(ctrl)
+	mockCardRepository.EXPECT().FindByID(gomock.Any(), uint(2)).Return(&entity.Card{ID: 2, Account: 9}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/cards/2", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(CardFindByIDPath)
+	c.SetParamNames("id", "card")
+	c.SetParamValues("1", "2")
+	h := NewCard(CardOpts{
+		CardRepository: mockCardRepository,
+	})
+
+	assert.EqualError(t, h.FindByID(c), "card not found")
+}
+
+func TestHandlerCard_Block(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCardService := service.NewMockCards(ctrl)
+	mockCardService.EXPECT().UpdateStatus(gomock.Any(), uint(1), uint(2), entity.CardStatusBlocked).Return(&entity.Card{
+		ID:      2,
+		Account: 1,
+		Status:  entity.CardStatusBlocked,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/1/cards/2/block", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(CardBlockPath)
+	c.SetParamNames("id", "card")
+	c.SetParamValues("1", "2")
+	h := NewCard(CardOpts{
+		CardService: mockCardService,
+	})
+
+	if assert.NoError(t, h.Block(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status":"blocked"`)
+	}
+}
+
+func TestHandlerCard_Unblock_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCardService := service.NewMockCards(ctrl)
+	mockCardService.EXPECT().UpdateStatus(gomock.Any(), uint(1), uint(2), entity.CardStatusActive).Return(nil, service.ErrCardTransition)
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/1/cards/2/unblock", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(CardUnblockPath)
+	c.SetParamNames("id", "card")
+	c.SetParamValues("1", "2")
+	h := NewCard(CardOpts{
+		CardService: mockCardService,
+	})
+
+	assert.EqualError(t, h.Unblock(c), "card status change not allowed")
+}
+
+func TestHandlerCard_FindAll_Invalid_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(CardFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	h := NewCard(CardOpts{
+		CardRepository: repository.NewMockCards(ctrl),
+	})
+
+	assert.EqualError(t, h.FindAll(c), "id: must be a positive integer.")
+}
+
+func TestHandlerCard_Invalid_Path(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   []string
+		expected string
+	}{
+		{name: "card", values: []string{"1", "abc"}, expected: "card: must be a positive integer."},
+		{name: "account", values: []string{"0", "1"}, expected: "id: must be a positive integer."},
+		{name: "both", values: []string{"abc", "-1"}, expected: "card: must be a positive integer; id: must be a positive integer."},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewCard(CardOpts{
+				CardService:    service.NewMockCards(ctrl),
+				CardRepository: repository.NewMockCards(ctrl),
+			})
+
+			for _, handler := range []echo.HandlerFunc{h.FindByID, h.Block} {
+				c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+				c.SetPath(CardFindByIDPath)
+				c.SetParamNames("id", "card")
+				c.SetParamValues(tt.values...)
+
+				assert.EqualError(t, handler(c), tt.expected)
+			}
+		})
+	}
+}
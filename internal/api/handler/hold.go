@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"ms/card/pkg/telemetry/jaeger"
+	"net/http"
+)
+
+const (
+	HoldAuthorizePath = "/holds"
+	HoldFindByIDPath  = "/holds/:id"
+	HoldCapturePath   = "/holds/:id/capture"
+	HoldVoidPath      = "/holds/:id/void"
+)
+
+type (
+	HoldOpts struct {
+		HoldService    service.Holds
+		HoldRepository repository.Holds
+	}
+
+	Hold struct {
+		HoldOpts
+	}
+)
+
+func NewHold(opts HoldOpts) *Hold {
+	return &Hold{opts}
+}
+
+func (h *Hold) Authorize(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	request := &contract.HoldRequest{}
+	if err := c.Bind(request); err != nil {
+		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
+		return err
+	}
+
+	hold, err := h.HoldService.Authorize(ctx, request)
+	if err != nil {
+		c.Logger().Errorf("h.HoldService.Authorize failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, hold)
+}
+
+func (h *Hold) FindByID(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	hold, err := h.HoldRepository.FindByID(ctx, id)
+	if err != nil {
+		c.Logger().Errorf("h.HoldRepository.FindByID failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
+
+func (h *Hold) Capture(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	request := &contract.CaptureRequest{}
+	if err := c.Bind(request); err != nil {
+		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
+		return err
+	}
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	hold, err := h.HoldService.Capture(ctx, id, request)
+	if err != nil {
+		c.Logger().Errorf("h.HoldService.Capture failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
+
+func (h *Hold) Void(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	hold, err := h.HoldService.Void(ctx, id)
+	if err != nil {
+		c.Logger().Errorf("h.HoldService.Void failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerHold_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	mockHoldService := service.NewMockHolds(ctrl)
+	mockHoldService.EXPECT().Authorize(gomock.Any(), &contract.HoldRequest{Account: 1, Operation: 1, Amount: 1000}).Return(&entity.Hold{
+		ID:        1,
+		Account:   1,
+		Type:      1,
+		Amount:    1000,
+		Status:    entity.HoldStatusAuthorized,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, HoldAuthorizePath, strings.NewReader(`{"account_id":1,"operation_id":1,"amount":1000}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	h := NewHold(HoldOpts{
+		HoldService: mockHoldService,
+	})
+
+	if assert.NoError(t, h.Authorize(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{
+			"id":1,"account_id":1,"operation_id":1,"amount":1000,"captured_amount":0,"status":"authorized",
+			"expires_at":"2022-03-12T02:02:03.000000004Z","created_at":"2022-03-12T01:02:03.000000004Z","updated_at":"2022-03-12T01:02:03.000000004Z"
+		}`, rec.Body.String())
+	}
+}
+
+func TestHandlerHold_Authorize_Limit_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := service.NewMockHolds(ctrl)
+	mockHoldService.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(nil, service.ErrLimitExceeded)
+
+	req := httptest.NewRequest(http.MethodPost, HoldAuthorizePath, strings.NewReader(`{"account_id":1,"operation_id":1,"amount":1000}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	h := NewHold(HoldOpts{
+		HoldService: mockHoldService,
+	})
+
+	assert.EqualError(t, h.Authorize(echo.New().NewContext(req, rec)), "account limit exceeded, operation not allowed")
+}
+
+func TestHandlerHold_Capture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := service.NewMockHolds(ctrl)
+	mockHoldService.EXPECT().Capture(gomock.Any(), uint(1), &contract.CaptureRequest{Amount: 400}).Return(&entity.Hold{
+		ID:       1,
+		Amount:   1000,
+		Captured: 400,
+		Status:   entity.HoldStatusPartiallyCaptured,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"amount":400}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(HoldCapturePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewHold(HoldOpts{
+		HoldService: mockHoldService,
+	})
+
+	if assert.NoError(t, h.Capture(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandlerHold_Void_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := service.NewMockHolds(ctrl)
+	mockHoldService.EXPECT().Void(gomock.Any(), uint(1)).Return(nil, service.ErrHoldNotOpen)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(HoldVoidPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewHold(HoldOpts{
+		HoldService: mockHoldService,
+	})
+
+	assert.EqualError(t, h.Void(c), "hold is no longer open")
+}
+
+func TestHandlerHold_FindByID_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(nil, repository.ErrHoldNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(HoldFindByIDPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewHold(HoldOpts{
+		HoldRepository: mockHoldRepository,
+	})
+
+	assert.EqualError(t, h.FindByID(c), "hold not found")
+}
+
+func TestHandlerHold_Invalid_Path(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewHold(HoldOpts{
+		HoldService:    service.NewMockHolds(ctrl),
+		HoldRepository: repository.NewMockHolds(ctrl),
+	})
+
+	for _, handler := range []echo.HandlerFunc{h.FindByID, h.Capture, h.Void} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		c.SetPath(HoldFindByIDPath)
+		c.SetParamNames("id")
+		c.SetParamValues("1abc")
+
+		assert.EqualError(t, handler(c), "id: must be a positive integer.")
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+	"net/http"
+)
+
+const (
+	InstallmentFindAllPath = "/accounts/:id/installments"
+)
+
+type (
+	InstallmentOpts struct {
+		InstallmentRepository repository.Installments
+	}
+
+	Installment struct {
+		InstallmentOpts
+	}
+)
+
+func NewInstallment(opts InstallmentOpts) *Installment {
+	return &Installment{opts}
+}
+
+func (i *Installment) FindAll(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	invalid := validation.Errors{}
+	filters := filter.InstallmentPlanCollection{
+		Page:    number(c, "page", invalid),
+		Size:    number(c, "size", invalid),
+		Account: identifier(c, "id", invalid),
+	}
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("query validation failed with %s\n", err.Error())
+		return err
+	}
+
+	plans, err := i.InstallmentRepository.FindPlans(ctx, filters)
+	if err != nil {
+		c.Logger().Errorf("i.InstallmentRepository.FindPlans failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, plans)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandlerInstallment_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	transaction := uint(10)
+	mockInstallmentRepository := repository.NewMockInstallments(ctrl)
+	mockInstallmentRepository.EXPECT().FindPlans(gomock.Any(), filter.InstallmentPlanCollection{Page: 1, Size: 10, Account: 1}).Return([]*entity.InstallmentPlan{
+		{
+			ID:        1,
+			Account:   1,
+			Type:      2,
+			Amount:    1000,
+			Count:     2,
+			CreatedAt: now,
+			Installments: []*entity.Installment{
+				{ID: 1, Plan: 1, Number: 1, Amount: 500, DueDate: now, Transaction: &transaction, PostedAt: &now},
+				{ID: 2, Plan: 1, Number: 2, Amount: 500, DueDate: now.AddDate(0, 1, 0)},
+			},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?page=1&size=10", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(InstallmentFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewInstallment(InstallmentOpts{
+		InstallmentRepository: mockInstallmentRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{
+			"id":1,"account_id":1,"operation_id":2,"amount":1000,"installments":2,"created_at":"2022-03-12T01:02:03.000000004Z",
+			"schedule":[
+				{"id":1,"installment_plan_id":1,"number":1,"amount":500,"due_date":"2022-03-12T01:02:03.000000004Z","transaction_id":10,"posted_at":"2022-03-12T01:02:03.000000004Z"},
+				{"id":2,"installment_plan_id":1,"number":2,"amount":500,"due_date":"2022-04-12T01:02:03.000000004Z","transaction_id":null,"posted_at":null}
+			]
+		}]`, rec.Body.String())
+	}
+}
+
+func TestHandlerInstallment_FindAll_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInstallmentRepository := repository.NewMockInstallments(ctrl)
+	mockInstallmentRepository.EXPECT().FindPlans(gomock.Any(), gomock.Any()).Return(nil, errors.New("err find plans"))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(InstallmentFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewInstallment(InstallmentOpts{
+		InstallmentRepository: mockInstallmentRepository,
+	})
+
+	assert.EqualError(t, h.FindAll(c), "err find plans")
+}
+
+func TestHandlerInstallment_FindAll_Invalid_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/?page=x", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(InstallmentFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	h := NewInstallment(InstallmentOpts{
+		InstallmentRepository: repository.NewMockInstallments(ctrl),
+	})
+
+	assert.EqualError(t, h.FindAll(c), "id: must be a positive integer; page: must be a non-negative integer.")
+}
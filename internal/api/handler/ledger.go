@@ -0,0 +1,68 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"ms/card/pkg/telemetry/jaeger"
+	"net/http"
+)
+
+const (
+	LedgerFindAllPath = "/accounts/:id/ledger"
+	LedgerDriftPath   = "/ledger/drifts"
+)
+
+type (
+	LedgerOpts struct {
+		LedgerService    service.Ledgers
+		LedgerRepository repository.Ledgers
+	}
+
+	Ledger struct {
+		LedgerOpts
+	}
+)
+
+func NewLedger(opts LedgerOpts) *Ledger {
+	return &Ledger{opts}
+}
+
+func (l *Ledger) FindAll(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	invalid := validation.Errors{}
+	filters := filter.JournalEntryCollection{
+		Page:    number(c, "page", invalid),
+		Size:    number(c, "size", invalid),
+		Account: identifier(c, "id", invalid),
+	}
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("query validation failed with %s\n", err.Error())
+		return err
+	}
+
+	entries, err := l.LedgerRepository.FindAll(ctx, filters)
+	if err != nil {
+		c.Logger().Errorf("l.LedgerRepository.FindAll failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
+
+func (l *Ledger) Drifts(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	drifts, err := l.LedgerService.Reconcile(ctx)
+	if err != nil {
+		c.Logger().Errorf("l.LedgerService.Reconcile failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, drifts)
+}
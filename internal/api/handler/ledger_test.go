@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandlerLedger_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	mockLedgerRepository := repository.NewMockLedgers(ctrl)
+	mockLedgerRepository.EXPECT().FindAll(gomock.Any(), filter.JournalEntryCollection{Account: 1}).Return([]*entity.JournalEntry{
+		{
+			ID:          1,
+			Account:     1,
+			Description: "limit consumed",
+			CreatedAt:   now,
+			Postings: []*entity.Posting{
+				{ID: 1, Entry: 1, Account: 1, Ledger: entity.LedgerAvailable, Amount: -1000},
+				{ID: 2, Entry: 1, Account: 1, Ledger: entity.LedgerUsed, Amount: 1000},
+			},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(LedgerFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewLedger(LedgerOpts{
+		LedgerRepository: mockLedgerRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{
+			"id":1,"account_id":1,"description":"limit consumed","created_at":"2022-03-12T01:02:03.000000004Z",
+			"postings":[
+				{"id":1,"journal_entry_id":1,"account_id":1,"ledger":"available","amount":-1000},
+				{"id":2,"journal_entry_id":1,"account_id":1,"ledger":"used","amount":1000}
+			]
+		}]`, rec.Body.String())
+	}
+}
+
+func TestHandlerLedger_Drifts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLedgerService := service.NewMockLedgers(ctrl)
+	mockLedgerService.EXPECT().Reconcile(gomock.Any()).Return([]*entity.LedgerDrift{{Account: 3, Limit: 900, Ledger: 1000}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, LedgerDriftPath, nil)
+	rec := httptest.NewRecorder()
+	h := NewLedger(LedgerOpts{
+		LedgerService: mockLedgerService,
+	})
+
+	if assert.NoError(t, h.Drifts(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{"account_id":3,"limit":900,"ledger":1000}]`, rec.Body.String())
+	}
+}
+
+func TestHandlerLedger_Drifts_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLedgerService := service.NewMockLedgers(ctrl)
+	mockLedgerService.EXPECT().Reconcile(gomock.Any()).Return(nil, repository.ErrLedgerDrift)
+
+	req := httptest.NewRequest(http.MethodGet, LedgerDriftPath, nil)
+	rec := httptest.NewRecorder()
+	h := NewLedger(LedgerOpts{
+		LedgerService: mockLedgerService,
+	})
+
+	assert.EqualError(t, h.Drifts(echo.New().NewContext(req, rec)), "failed to reconcile the ledger")
+}
+
+func TestHandlerLedger_FindAll_Invalid_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(LedgerFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("0")
+	h := NewLedger(LedgerOpts{
+		LedgerRepository: repository.NewMockLedgers(ctrl),
+	})
+
+	assert.EqualError(t, h.FindAll(c), "id: must be a positive integer.")
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/labstack/echo/v4"
+	"ms/card/pkg/auth"
 	"ms/card/pkg/contract"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
@@ -37,22 +39,25 @@ func (o *Operation) Create(c echo.Context) error {
 	request := &contract.OperationRequest{}
 	if err := c.Bind(request); err != nil {
 		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	if err := request.Validate(); err != nil {
 		c.Logger().Errorf("request.Validate failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	typeOperation, _ := strconv.ParseBool(request.Debit)
+	fee, _ := strconv.ParseBool(request.Fee)
 	operationType, err := o.OperationRepository.Create(ctx, entity.Operation{
 		Description: request.Description,
 		Debit:       typeOperation,
+		Fee:         fee,
+		CreatedBy:   auth.Client(ctx),
 	})
 	if err != nil {
 		c.Logger().Errorf("o.OperationTypeRepository.Create failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, operationType)
@@ -62,11 +67,16 @@ func (o *Operation) FindByID(c echo.Context) error {
 	ctx, span := jaeger.Span(c.Request().Context())
 	defer span.End()
 
-	id, _ := strconv.Atoi(c.Param("id"))
-	operationType, err := o.OperationRepository.FindByID(ctx, uint(id))
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	operationType, err := o.OperationRepository.FindByID(ctx, id)
 	if err != nil {
 		c.Logger().Errorf("o.OperationTypeRepository.FindByID failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, operationType)
@@ -76,19 +86,25 @@ func (o *Operation) FindAll(c echo.Context) error {
 	ctx, span := jaeger.Span(c.Request().Context())
 	defer span.End()
 
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	size, _ := strconv.Atoi(c.QueryParam("size"))
-
-	operationTypes, err := o.OperationRepository.FindAll(ctx, filter.OperationCollection{
-		Page:        page,
-		Size:        size,
+	invalid := validation.Errors{}
+	filters := filter.OperationCollection{
+		Page:        number(c, "page", invalid),
+		Size:        number(c, "size", invalid),
+		Sort:        sorting(c, filter.OperationSortFields, invalid),
 		Description: c.QueryParam("description"),
-		Debit:       c.QueryParam("debit"),
-	})
+		Debit:       boolean(c, "debit", invalid),
+	}
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("query validation failed with %s\n", err.Error())
+		return err
+	}
+
+	collection, err := o.OperationRepository.FindAll(ctx, filters)
 	if err != nil {
 		c.Logger().Errorf("o.OperationTypeRepository.FindAll failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	return c.JSON(http.StatusOK, operationTypes)
+	collection.Link(c.Request().URL)
+	return c.JSON(http.StatusOK, collection)
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/persistence"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"ms/card/pkg/persistence/repository"
@@ -34,7 +35,7 @@ func TestHandlerOperation_Create(t *testing.T) {
 
 	if assert.NoError(t, h.Create(echo.New().NewContext(req, rec))) {
 		assert.Equal(t, http.StatusCreated, rec.Code)
-		assert.JSONEq(t, `{"id":1,"description":"COMPRA A VISTA","debit":true}`, rec.Body.String())
+		assert.JSONEq(t, `{"id":1,"description":"COMPRA A VISTA","debit":true,"fee":false,"reversal":false}`, rec.Body.String())
 	}
 }
 
@@ -52,7 +53,7 @@ func TestHandlerOperation_Create_Persist_Error(t *testing.T) {
 		OperationRepository: mockOperationRepository,
 	})
 
-	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=failed to create new operation")
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "failed to create new operation")
 }
 
 func TestHandlerOperation_Create_BindRequest_Error(t *testing.T) {
@@ -64,7 +65,7 @@ func TestHandlerOperation_Create_BindRequest_Error(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h := NewOperation(OperationOpts{})
 
-	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=code=400, message=unexpected EOF, internal=unexpected EOF")
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=unexpected EOF, internal=unexpected EOF")
 }
 
 func TestHandlerOperation_Create_Validate_Error(t *testing.T) {
@@ -76,7 +77,7 @@ func TestHandlerOperation_Create_Validate_Error(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h := NewOperation(OperationOpts{})
 
-	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=debit: cannot be blank; description: cannot be blank.")
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "debit: cannot be blank; description: cannot be blank.")
 }
 
 func TestHandlerOperation_FindByID(t *testing.T) {
@@ -105,7 +106,7 @@ func TestHandlerOperation_FindByID(t *testing.T) {
 
 	if assert.NoError(t, h.FindByID(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
-		assert.JSONEq(t, `{"id":1,"description":"COMPRA A VISTA","debit":true}`, rec.Body.String())
+		assert.JSONEq(t, `{"id":1,"description":"COMPRA A VISTA","debit":true,"fee":false,"reversal":false}`, rec.Body.String())
 	}
 }
 
@@ -129,7 +130,7 @@ func TestHandlerOperation_FindByID_Error(t *testing.T) {
 		OperationRepository: mockOperationRepository,
 	})
 
-	assert.EqualError(t, h.FindByID(c), "code=400, message=failed fetch operation")
+	assert.EqualError(t, h.FindByID(c), "failed fetch operation")
 }
 
 func TestHandlerOperation_FindAll(t *testing.T) {
@@ -137,21 +138,24 @@ func TestHandlerOperation_FindAll(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockOperationRepository := repository.NewMockOperations(ctrl)
-	mockOperationRepository.EXPECT().FindAll(gomock.Any(), filter.OperationCollection{}).Return([]*entity.Operation{
-		{
-			ID:          1,
-			Description: "COMPRA A VISTA",
-			Debit:       true,
-		},
-		{
-			ID:          2,
-			Description: "PAGAMENTO",
-			Debit:       false,
+	mockOperationRepository.EXPECT().FindAll(gomock.Any(), filter.OperationCollection{Page: 2, Size: 2}).Return(&entity.OperationCollection{
+		Pagination: persistence.NewPagination(2, 2, 5),
+		Data: []*entity.Operation{
+			{
+				ID:          1,
+				Description: "COMPRA A VISTA",
+				Debit:       true,
+			},
+			{
+				ID:          2,
+				Description: "PAGAMENTO",
+				Debit:       false,
+			},
 		},
 	}, nil)
 
 	server := echo.New()
-	req := httptest.NewRequest(http.MethodPost, AccountFindByIDPath, nil)
+	req := httptest.NewRequest(http.MethodGet, OperationFindAllPath+"?page=2&size=2", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := server.NewContext(req, rec)
@@ -162,14 +166,56 @@ func TestHandlerOperation_FindAll(t *testing.T) {
 	if assert.NoError(t, h.FindAll(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.JSONEq(t, `
-		[
-			{"id":1,"description":"COMPRA A VISTA","debit":true},
-			{"id":2,"description":"PAGAMENTO","debit":false}
-		]
+		{
+			"page": 2,
+			"size": 2,
+			"total": 5,
+			"next": "/operations?page=3&size=2",
+			"prev": "/operations?page=1&size=2",
+			"data": [
+				{"id":1,"description":"COMPRA A VISTA","debit":true,"fee":false,"reversal":false},
+				{"id":2,"description":"PAGAMENTO","debit":false,"fee":false,"reversal":false}
+			]
+		}
 		`, rec.Body.String())
 	}
 }
 
+func TestHandlerOperation_FindAll_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	debit := true
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindAll(gomock.Any(), filter.OperationCollection{
+		Sort:  filter.Sort{Column: "description"},
+		Debit: &debit,
+	}).Return(&entity.OperationCollection{Data: []*entity.Operation{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, OperationFindAllPath+"?debit=true&sort=description", nil)
+	rec := httptest.NewRecorder()
+	h := NewOperation(OperationOpts{
+		OperationRepository: mockOperationRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandlerOperation_FindAll_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, OperationFindAllPath+"?debit=yes&size=-1", nil)
+	rec := httptest.NewRecorder()
+	h := NewOperation(OperationOpts{
+		OperationRepository: repository.NewMockOperations(ctrl),
+	})
+
+	assert.EqualError(t, h.FindAll(echo.New().NewContext(req, rec)), "debit: must be true or false; size: must be a non-negative integer.")
+}
+
 func TestHandlerOperation_FindAll_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -186,5 +232,20 @@ func TestHandlerOperation_FindAll_Error(t *testing.T) {
 		OperationRepository: mockOperationRepository,
 	})
 
-	assert.EqualError(t, h.FindAll(c), "code=400, message=err find all")
+	assert.EqualError(t, h.FindAll(c), "err find all")
+}
+
+func TestHandlerOperation_FindByID_Invalid_Path(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	c.SetPath(OperationFindByIDPath)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	h := NewOperation(OperationOpts{
+		OperationRepository: repository.NewMockOperations(ctrl),
+	})
+
+	assert.EqualError(t, h.FindByID(c), "id: must be a positive integer.")
 }
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"strings"
+)
+
+const (
+	documentQueryParam = "document_number"
+)
+
+// MaskQuery hides the document numbers searched for from the request log, it only rewrites
+// the RequestURI the logger prints so it must come before middleware.Logger. Routing and
+// query params read the parsed URL and are left untouched.
+func MaskQuery() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			query := req.URL.Query()
+			if document := query.Get(documentQueryParam); document != "" {
+				query.Set(documentQueryParam, common.MaskDocument(document))
+				req.RequestURI = req.URL.EscapedPath() + "?" + strings.ReplaceAll(query.Encode(), "%2A", "*")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// maskAccounts hides the document numbers of the accounts answered unless the client is
+// granted the pii:read scope.
+func maskAccounts(c echo.Context, accounts ...*entity.Account) {
+	if principal, ok := auth.FromContext(c.Request().Context()); ok && principal.Allowed(auth.ScopePIIRead) {
+		return
+	}
+
+	for _, account := range accounts {
+		account.Document = common.MaskDocument(account.Document)
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMaskQuery(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/accounts?document_number=560.770.530-74&page=2", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	next := func(c echo.Context) error {
+		assert.Equal(t, "560.770.530-74", c.QueryParam("document_number"))
+		return c.NoContent(http.StatusOK)
+	}
+
+	assert.NoError(t, MaskQuery()(next)(c))
+	assert.Equal(t, "/accounts?document_number=***.***.***-74&page=2", req.RequestURI)
+}
+
+func TestMaskQuery_WithoutDocument(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/accounts?page=2", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	assert.NoError(t, MaskQuery()(func(c echo.Context) error { return nil })(c))
+	assert.Equal(t, "/accounts?page=2", req.RequestURI)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/service"
+	"net/http"
+	"strings"
+)
+
+const (
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	ProblemCodeValidation = "validation_failed"
+	ProblemCodeBadRequest = "bad_request"
+	ProblemCodeInternal   = "internal_error"
+)
+
+var (
+	statusByKind = map[domain.Kind]int{
+		domain.KindInvalid:       http.StatusBadRequest,
+		domain.KindUnauthorized:  http.StatusUnauthorized,
+		domain.KindForbidden:     http.StatusForbidden,
+		domain.KindNotFound:      http.StatusNotFound,
+		domain.KindConflict:      http.StatusConflict,
+		domain.KindUnprocessable: http.StatusUnprocessableEntity,
+		domain.KindInternal:      http.StatusInternalServerError,
+	}
+)
+
+// Problem is the RFC 7807 body of every error answer. Code is stable and meant for clients
+// to branch on, Errors holds the message of each invalid field.
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Code     string            `json:"code"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`
+}
+
+// NewProblem maps err to its problem. Errors that are not typed are failures nobody planned
+// for, they answer 500 without their message, which may tell about the internals.
+func NewProblem(err error) *Problem {
+	var typed *domain.Error
+	var decline *service.Decline
+	var invalid validation.Errors
+	var transport *echo.HTTPError
+
+	switch {
+	case xerrors.As(err, &typed):
+		return problem(statusByKind[typed.Kind], typed.Code, typed.Message)
+	case xerrors.As(err, &decline):
+		return problem(http.StatusUnprocessableEntity, decline.Code, decline.Reason)
+	case xerrors.As(err, &invalid):
+		p := problem(http.StatusBadRequest, ProblemCodeValidation, "the request has invalid fields")
+		p.Errors = make(map[string]string)
+		fields(p.Errors, "", invalid)
+		return p
+	case xerrors.As(err, &transport):
+		detail, ok := transport.Message.(string)
+		if !ok {
+			detail = http.StatusText(transport.Code)
+		}
+
+		return problem(transport.Code, code(transport.Code), detail)
+	default:
+		return problem(http.StatusInternalServerError, ProblemCodeInternal, "the request could not be processed")
+	}
+}
+
+// ErrorHandler answers every error returned by the handlers as a problem.
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	p := NewProblem(err)
+	p.Instance = c.Request().URL.Path
+
+	var write error
+	if c.Request().Method == http.MethodHead {
+		write = c.NoContent(p.Status)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+		write = c.JSON(p.Status, p)
+	}
+
+	if write != nil {
+		c.Logger().Errorf("c.JSON failed with %s\n", write)
+	}
+}
+
+func problem(status int, code string, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// fields flattens nested validation errors, the items of a list are keyed by their index.
+func fields(errors map[string]string, prefix string, invalid validation.Errors) {
+	for field, err := range invalid {
+		key := field
+		if prefix != "" {
+			key = prefix + "." + field
+		}
+
+		var nested validation.Errors
+		if xerrors.As(err, &nested) {
+			fields(errors, key, nested)
+			continue
+		}
+
+		errors[key] = err.Error()
+	}
+}
+
+// code is the snake case of the status text, not_found for 404.
+func code(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
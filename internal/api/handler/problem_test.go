@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewProblem(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"account not found", repository.ErrAccountCreateNotFound, http.StatusNotFound, "account_not_found"},
+		{"account already exists", repository.ErrAccountCreateAlreadyExists, http.StatusConflict, "account_already_exists"},
+		{"account database failure", repository.ErrAccountFindByID, http.StatusInternalServerError, "account_find_failed"},
+		{"operation not found", repository.ErrOperationCreateNotFound, http.StatusNotFound, "operation_not_found"},
+		{"operation database failure", repository.ErrOperationCount, http.StatusInternalServerError, "operation_count_failed"},
+		{"limit exceeded", xerrors.Errorf("update: %w", service.ErrLimitExceeded), http.StatusUnprocessableEntity, "limit_exceeded"},
+		{"idempotency key mismatch", service.ErrIdempotencyKeyMismatch, http.StatusConflict, "idempotency_key_mismatch"},
+		{"decline", service.ErrDeclineMCC, http.StatusUnprocessableEntity, service.DeclineCodeMCC},
+		{"route not found", echo.ErrNotFound, http.StatusNotFound, "not_found"},
+		{"bind", echo.NewHTTPError(http.StatusBadRequest, "unexpected EOF"), http.StatusBadRequest, ProblemCodeBadRequest},
+		{"reversal exceeded", service.ErrReversalExceeded, http.StatusUnprocessableEntity, "reversal_exceeded"},
+		{"hold not open", service.ErrHoldNotOpen, http.StatusUnprocessableEntity, "hold_not_open"},
+		{"card not found", repository.ErrCardNotFound, http.StatusNotFound, "card_not_found"},
+		{"transaction database failure", repository.ErrTransactionCreate, http.StatusInternalServerError, "transaction_create_failed"},
+		{"transaction totals failure", repository.ErrTransactionTotals, http.StatusInternalServerError, "transaction_totals_failed"},
+		{"transaction export failure", repository.ErrTransactionExport, http.StatusInternalServerError, "transaction_export_failed"},
+		{"untyped", xerrors.New("pq: connection refused"), http.StatusInternalServerError, ProblemCodeInternal},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			problem := NewProblem(c.err)
+			assert.Equal(t, c.status, problem.Status)
+			assert.Equal(t, c.code, problem.Code)
+			assert.Equal(t, http.StatusText(c.status), problem.Title)
+			assert.Equal(t, "about:blank", problem.Type)
+		})
+	}
+}
+
+func TestNewProblem_Untyped_Detail(t *testing.T) {
+	problem := NewProblem(xerrors.New("pq: connection refused"))
+	assert.Equal(t, "the request could not be processed", problem.Detail)
+}
+
+func TestNewProblem_Validation(t *testing.T) {
+	err := contract.SpendingControlRequest{MaxAmount: -1, BlockedMCCs: []string{"5411", "79"}}.Validate()
+
+	problem := NewProblem(err)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, ProblemCodeValidation, problem.Code)
+	assert.Equal(t, map[string]string{
+		"max_amount":     "must be no less than 0",
+		"blocked_mccs.1": "must be a 4 digit merchant category code",
+	}, problem.Errors)
+}
+
+func TestErrorHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/9", nil)
+	rec := httptest.NewRecorder()
+
+	ErrorHandler(repository.ErrAccountCreateNotFound, echo.New().NewContext(req, rec))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.JSONEq(t, `
+	{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"code": "account_not_found",
+		"detail": "account not found",
+		"instance": "/accounts/9"
+	}
+	`, rec.Body.String())
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/persistence/filter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The query params of the list endpoints are read by the helpers below, each one records
+// what it can't parse in invalid under the param name and returns the zero value, so a
+// handler reads them all and answers every mistake at once.
+
+// number parses a non-negative integer such as page or size, zero when it is missing.
+func number(c echo.Context, name string, invalid validation.Errors) int {
+	value := c.QueryParam(name)
+	if value == "" {
+		return 0
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		invalid[name] = xerrors.New("must be a non-negative integer")
+		return 0
+	}
+
+	return parsed
+}
+
+// identifier parses an id path param such as the account of /accounts/:id/cards, a positive
+// integer. Without it the collections would lose their account scope.
+func identifier(c echo.Context, name string, invalid validation.Errors) uint {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil || id == 0 {
+		invalid[name] = xerrors.New("must be a positive integer")
+		return 0
+	}
+
+	return uint(id)
+}
+
+// param parses the id path param of a single record, a malformed one answers 400 instead of
+// being looked up as id 0.
+func param(c echo.Context, name string) (uint, error) {
+	invalid := validation.Errors{}
+	id := identifier(c, name, invalid)
+	return id, invalid.Filter()
+}
+
+// amount parses a positive amount in cents, zero when it is missing.
+func amount(c echo.Context, name string, invalid validation.Errors) int64 {
+	value := c.QueryParam(name)
+	if value == "" {
+		return 0
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		invalid[name] = xerrors.New("must be a positive integer")
+		return 0
+	}
+
+	return parsed
+}
+
+// identifiers parses the ids of a repeated or comma separated param, account_id=1&account_id=2
+// and account_id=1,2 are the same.
+func identifiers(c echo.Context, name string, invalid validation.Errors) []uint {
+	var ids []uint
+	for _, value := range c.QueryParams()[name] {
+		for _, item := range strings.Split(value, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(item), 10, 32)
+			if err != nil || id == 0 {
+				invalid[name] = xerrors.New("must be a list of ids")
+				return nil
+			}
+
+			ids = append(ids, uint(id))
+		}
+	}
+
+	return ids
+}
+
+// boolean parses a true or false param, nil when it is missing.
+func boolean(c echo.Context, name string, invalid validation.Errors) *bool {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		invalid[name] = xerrors.New("must be true or false")
+		return nil
+	}
+
+	return &parsed
+}
+
+// timestamp parses the RFC 3339 query param, it is zero when the param is missing.
+func timestamp(c echo.Context, name string, invalid validation.Errors) time.Time {
+	value := c.QueryParam(name)
+	if value == "" {
+		return time.Time{}
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		invalid[name] = xerrors.New("must be an RFC 3339 timestamp")
+		return time.Time{}
+	}
+
+	return parsed.UTC()
+}
+
+// sorting parses the sort param, a field of the whitelist optionally followed by :asc or
+// :desc, sort=amount:desc. It is the zero sort, by id, when the param is missing.
+func sorting(c echo.Context, fields map[string]string, invalid validation.Errors) filter.Sort {
+	value := c.QueryParam("sort")
+	if value == "" {
+		return filter.Sort{}
+	}
+
+	name, direction := value, filter.SortAscending
+	if i := strings.LastIndex(value, ":"); i >= 0 {
+		name, direction = value[:i], strings.ToLower(value[i+1:])
+	}
+
+	column, ok := fields[name]
+	if !ok {
+		invalid["sort"] = xerrors.Errorf("must be one of %s", strings.Join(filter.SortFields(fields), ", "))
+		return filter.Sort{}
+	}
+
+	if direction != filter.SortAscending && direction != filter.SortDescending {
+		invalid["sort"] = xerrors.Errorf("direction must be %s or %s", filter.SortAscending, filter.SortDescending)
+		return filter.Sort{}
+	}
+
+	return filter.Sort{Column: column, Descending: direction == filter.SortDescending}
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/persistence/filter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func queryContext(query string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/transactions"+query, nil)
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestNumber(t *testing.T) {
+	invalid := validation.Errors{}
+	c := queryContext("?page=2&size=abc&after=-1")
+
+	assert.Equal(t, 2, number(c, "page", invalid))
+	assert.Equal(t, 0, number(c, "size", invalid))
+	assert.Equal(t, 0, number(c, "after", invalid))
+	assert.Equal(t, 0, number(c, "before", invalid))
+	assert.EqualError(t, invalid, "after: must be a non-negative integer; size: must be a non-negative integer.")
+}
+
+func TestAmount(t *testing.T) {
+	invalid := validation.Errors{}
+	c := queryContext("?amount_min=1000&amount_max=0")
+
+	assert.Equal(t, int64(1000), amount(c, "amount_min", invalid))
+	assert.Equal(t, int64(0), amount(c, "amount_max", invalid))
+	assert.EqualError(t, invalid, "amount_max: must be a positive integer.")
+}
+
+func TestIdentifiers(t *testing.T) {
+	invalid := validation.Errors{}
+	c := queryContext("?account_id=1,2&account_id=3&operation_id=4&card_id=1,x")
+
+	assert.Equal(t, []uint{1, 2, 3}, identifiers(c, "account_id", invalid))
+	assert.Equal(t, []uint{4}, identifiers(c, "operation_id", invalid))
+	assert.Nil(t, identifiers(c, "hold_id", invalid))
+	assert.Nil(t, identifiers(c, "card_id", invalid))
+	assert.EqualError(t, invalid, "card_id: must be a list of ids.")
+}
+
+func TestBoolean(t *testing.T) {
+	invalid := validation.Errors{}
+	c := queryContext("?debit=true&fee=maybe")
+
+	if debit := boolean(c, "debit", invalid); assert.NotNil(t, debit) {
+		assert.True(t, *debit)
+	}
+
+	assert.Nil(t, boolean(c, "fee", invalid))
+	assert.Nil(t, boolean(c, "credit", invalid))
+	assert.EqualError(t, invalid, "fee: must be true or false.")
+}
+
+func TestSorting(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected filter.Sort
+		err      string
+	}{
+		{query: "", expected: filter.Sort{}},
+		{query: "?sort=amount", expected: filter.Sort{Column: "amount"}},
+		{query: "?sort=created_at:desc", expected: filter.Sort{Column: "created_at", Descending: true}},
+		{query: "?sort=id:ASC", expected: filter.Sort{Column: "id"}},
+		{query: "?sort=merchant_name", err: "sort: must be one of account_id, amount, created_at, id, operation_id."},
+		{query: "?sort=amount:down", err: "sort: direction must be asc or desc."},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.query, func(t *testing.T) {
+			invalid := validation.Errors{}
+			assert.Equal(t, tt.expected, sorting(queryContext(tt.query), filter.TransactionSortFields, invalid))
+			if tt.err == "" {
+				assert.Empty(t, invalid)
+			} else {
+				assert.EqualError(t, invalid, tt.err)
+			}
+		})
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected uint
+		err      string
+	}{
+		{value: "12", expected: 12},
+		{value: "abc", err: "id: must be a positive integer."},
+		{value: "0", err: "id: must be a positive integer."},
+		{value: "-1", err: "id: must be a positive integer."},
+		{value: "", err: "id: must be a positive integer."},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.value, func(t *testing.T) {
+			invalid := validation.Errors{}
+			c := queryContext("")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.value)
+
+			assert.Equal(t, tt.expected, identifier(c, "id", invalid))
+			if tt.err == "" {
+				assert.Empty(t, invalid)
+			} else {
+				assert.EqualError(t, invalid, tt.err)
+			}
+		})
+	}
+}
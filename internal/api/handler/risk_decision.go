@@ -0,0 +1,53 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+	"net/http"
+)
+
+const (
+	RiskDecisionFindAllPath = "/accounts/:id/risk-decisions"
+)
+
+type (
+	RiskDecisionOpts struct {
+		RiskDecisionRepository repository.RiskDecisions
+	}
+
+	RiskDecision struct {
+		RiskDecisionOpts
+	}
+)
+
+func NewRiskDecision(opts RiskDecisionOpts) *RiskDecision {
+	return &RiskDecision{opts}
+}
+
+func (h *RiskDecision) FindAll(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	invalid := validation.Errors{}
+	filters := filter.RiskDecisionCollection{
+		Page:    number(c, "page", invalid),
+		Size:    number(c, "size", invalid),
+		Account: identifier(c, "id", invalid),
+		Outcome: c.QueryParam("outcome"),
+	}
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("query validation failed with %s\n", err.Error())
+		return err
+	}
+
+	decisions, err := h.RiskDecisionRepository.FindAll(ctx, filters)
+	if err != nil {
+		c.Logger().Errorf("h.RiskDecisionRepository.FindAll failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, decisions)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandlerRiskDecision_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRiskDecisionRepository := repository.NewMockRiskDecisions(ctrl)
+	mockRiskDecisionRepository.EXPECT().FindAll(gomock.Any(), filter.RiskDecisionCollection{Account: 1, Outcome: "decline"}).Return([]*entity.RiskDecision{
+		{ID: 4, Account: 1, Amount: 9000, Outcome: "decline", Score: 90, Reasons: entity.List{"rules:velocity", "rules:amount_spike"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/risk-decisions?outcome=decline", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(RiskDecisionFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewRiskDecision(RiskDecisionOpts{
+		RiskDecisionRepository: mockRiskDecisionRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"reasons":["rules:velocity","rules:amount_spike"]`)
+	}
+}
+
+func TestHandlerRiskDecision_FindAll_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRiskDecisionRepository := repository.NewMockRiskDecisions(ctrl)
+	mockRiskDecisionRepository.EXPECT().FindAll(gomock.Any(), filter.RiskDecisionCollection{Account: 1}).Return(nil, repository.ErrRiskDecisionFind)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/risk-decisions", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(RiskDecisionFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewRiskDecision(RiskDecisionOpts{
+		RiskDecisionRepository: mockRiskDecisionRepository,
+	})
+
+	assert.EqualError(t, h.FindAll(c), ""+repository.ErrRiskDecisionFind.Error())
+}
+
+func TestHandlerRiskDecision_FindAll_Invalid_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(RiskDecisionFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("-3")
+	h := NewRiskDecision(RiskDecisionOpts{
+		RiskDecisionRepository: repository.NewMockRiskDecisions(ctrl),
+	})
+
+	assert.EqualError(t, h.FindAll(c), "id: must be a positive integer.")
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"ms/card/pkg/telemetry/jaeger"
+	"net/http"
+)
+
+const (
+	SpendingControlAccountPath = "/accounts/:id/spending-controls"
+	SpendingControlCardPath    = "/accounts/:id/cards/:card/spending-controls"
+)
+
+type (
+	SpendingControlOpts struct {
+		SpendingControlService    service.SpendingControls
+		SpendingControlRepository repository.SpendingControls
+	}
+
+	SpendingControl struct {
+		SpendingControlOpts
+	}
+)
+
+func NewSpendingControl(opts SpendingControlOpts) *SpendingControl {
+	return &SpendingControl{opts}
+}
+
+// Configure serves both paths, the card param is empty on the account one.
+func (s *SpendingControl) Configure(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, card, err := owner(c)
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	request := &contract.SpendingControlRequest{}
+	if err := c.Bind(request); err != nil {
+		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
+		return err
+	}
+
+	control, err := s.SpendingControlService.Configure(ctx, id, card, request)
+	if err != nil {
+		c.Logger().Errorf("s.SpendingControlService.Configure failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, control)
+}
+
+func (s *SpendingControl) Find(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, card, err := owner(c)
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	control, err := s.SpendingControlRepository.FindByOwner(ctx, id, card)
+	if err != nil {
+		c.Logger().Errorf("s.SpendingControlRepository.FindByOwner failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, control)
+}
+
+// owner parses the account of the control and, on the card path only, its card. A malformed
+// card is refused rather than read as the account-wide control.
+func owner(c echo.Context) (uint, uint, error) {
+	invalid := validation.Errors{}
+	id := identifier(c, "id", invalid)
+
+	var card uint
+	for _, name := range c.ParamNames() {
+		if name == "card" {
+			card = identifier(c, "card", invalid)
+		}
+	}
+
+	return id, card, invalid.Filter()
+}
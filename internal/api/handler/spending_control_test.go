@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerSpendingControl_Configure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 0, time.UTC)
+	mockSpendingControlService := service.NewMockSpendingControls(ctrl)
+	mockSpendingControlService.EXPECT().Configure(gomock.Any(), uint(1), uint(0), &contract.SpendingControlRequest{
+		DailyLimit:  10000,
+		BlockedMCCs: []string{"7995"},
+	}).Return(&entity.SpendingControl{
+		Account:     1,
+		DailyLimit:  10000,
+		BlockedMCCs: entity.List{"7995"},
+		UpdatedAt:   now,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts/1/spending-controls", strings.NewReader(`{"daily_limit":10000,"blocked_mccs":["7995"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(SpendingControlAccountPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewSpendingControl(SpendingControlOpts{
+		SpendingControlService: mockSpendingControlService,
+	})
+
+	if assert.NoError(t, h.Configure(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"account_id":1,"max_amount":0,"daily_limit":10000,"monthly_limit":0,"blocked_mccs":["7995"],
+			"block_ecommerce":false,"block_international":false,"updated_at":"2022-03-12T01:02:03Z"
+		}`, rec.Body.String())
+	}
+}
+
+func TestHandlerSpendingControl_Configure_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpendingControlService := service.NewMockSpendingControls(ctrl)
+	mockSpendingControlService.EXPECT().Configure(gomock.Any(), uint(1), uint(2), gomock.Any()).Return(nil, service.ErrCardAccountMismatch)
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts/1/cards/2/spending-controls", strings.NewReader(`{"block_ecommerce":true}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(SpendingControlCardPath)
+	c.SetParamNames("id", "card")
+	c.SetParamValues("1", "2")
+	h := NewSpendingControl(SpendingControlOpts{
+		SpendingControlService: mockSpendingControlService,
+	})
+
+	assert.EqualError(t, h.Configure(c), "card does not belong to the account")
+}
+
+func TestHandlerSpendingControl_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpendingControlRepository := repository.NewMockSpendingControls(ctrl)
+	mockSpendingControlRepository.EXPECT().FindByOwner(gomock.Any(), uint(1), uint(2)).Return(&entity.SpendingControl{
+		Account:        1,
+		Card:           2,
+		BlockedMCCs:    entity.List{},
+		BlockEcommerce: true,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/cards/2/spending-controls", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(SpendingControlCardPath)
+	c.SetParamNames("id", "card")
+	c.SetParamValues("1", "2")
+	h := NewSpendingControl(SpendingControlOpts{
+		SpendingControlRepository: mockSpendingControlRepository,
+	})
+
+	if assert.NoError(t, h.Find(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"card_id":2`)
+		assert.Contains(t, rec.Body.String(), `"block_ecommerce":true`)
+	}
+}
+
+func TestHandlerSpendingControl_Find_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpendingControlRepository := repository.NewMockSpendingControls(ctrl)
+	mockSpendingControlRepository.EXPECT().FindByOwner(gomock.Any(), uint(1), uint(0)).Return(nil, repository.ErrSpendingControlNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/spending-controls", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(SpendingControlAccountPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewSpendingControl(SpendingControlOpts{
+		SpendingControlRepository: mockSpendingControlRepository,
+	})
+
+	assert.EqualError(t, h.Find(c), "spending control not found")
+}
+
+func TestHandlerSpendingControl_Configure_Invalid_Path(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		params   []string
+		values   []string
+		expected string
+	}{
+		{name: "card", path: SpendingControlCardPath, params: []string{"id", "card"}, values: []string{"1", "abc"}, expected: "card: must be a positive integer."},
+		{name: "account", path: SpendingControlAccountPath, params: []string{"id"}, values: []string{"abc"}, expected: "id: must be a positive integer."},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"block_ecommerce":true}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetPath(tt.path)
+			c.SetParamNames(tt.params...)
+			c.SetParamValues(tt.values...)
+			h := NewSpendingControl(SpendingControlOpts{
+				SpendingControlService: service.NewMockSpendingControls(ctrl),
+			})
+
+			assert.EqualError(t, h.Configure(c), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"ms/card/pkg/telemetry/jaeger"
+	"net/http"
+)
+
+const (
+	StatementBillingCyclePath = "/accounts/:id/billing-cycle"
+	StatementFindAllPath      = "/accounts/:id/statements"
+	StatementFindByPeriodPath = "/accounts/:id/statements/:period"
+)
+
+type (
+	StatementOpts struct {
+		StatementService       service.Statements
+		StatementRepository    repository.Statements
+		BillingCycleRepository repository.BillingCycles
+	}
+
+	Statement struct {
+		StatementOpts
+	}
+)
+
+func NewStatement(opts StatementOpts) *Statement {
+	return &Statement{opts}
+}
+
+func (s *Statement) Configure(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	request := &contract.BillingCycleRequest{}
+	if err := c.Bind(request); err != nil {
+		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
+		return err
+	}
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	cycle, err := s.StatementService.Configure(ctx, id, request)
+	if err != nil {
+		c.Logger().Errorf("s.StatementService.Configure failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, cycle)
+}
+
+func (s *Statement) FindBillingCycle(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	cycle, err := s.BillingCycleRepository.FindByAccount(ctx, id)
+	if err != nil {
+		c.Logger().Errorf("s.BillingCycleRepository.FindByAccount failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, cycle)
+}
+
+func (s *Statement) FindAll(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	invalid := validation.Errors{}
+	filters := filter.StatementCollection{
+		Page:    number(c, "page", invalid),
+		Size:    number(c, "size", invalid),
+		Account: identifier(c, "id", invalid),
+	}
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("query validation failed with %s\n", err.Error())
+		return err
+	}
+
+	statements, err := s.StatementRepository.FindAll(ctx, filters)
+	if err != nil {
+		c.Logger().Errorf("s.StatementRepository.FindAll failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, statements)
+}
+
+func (s *Statement) FindByPeriod(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	statement, err := s.StatementRepository.FindByPeriod(ctx, id, c.Param("period"))
+	if err != nil {
+		c.Logger().Errorf("s.StatementRepository.FindByPeriod failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusOK, statement)
+}
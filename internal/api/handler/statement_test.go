@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerStatement_Configure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	mockStatementService := service.NewMockStatements(ctrl)
+	mockStatementService.EXPECT().Configure(gomock.Any(), uint(1), &contract.BillingCycleRequest{ClosingDay: 10, DueDays: 7}).Return(&entity.BillingCycle{
+		Account:       1,
+		ClosingDay:    10,
+		DueDays:       7,
+		NextClosingAt: now.AddDate(0, 0, 9),
+		UpdatedAt:     now,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"closing_day":10,"due_days":7}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(StatementBillingCyclePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewStatement(StatementOpts{
+		StatementService: mockStatementService,
+	})
+
+	if assert.NoError(t, h.Configure(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"account_id":1,"closing_day":10,"due_days":7,
+			"next_closing_at":"2022-03-10T00:00:00Z","updated_at":"2022-03-01T00:00:00Z"
+		}`, rec.Body.String())
+	}
+}
+
+func TestHandlerStatement_Configure_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementService := service.NewMockStatements(ctrl)
+	mockStatementService.EXPECT().Configure(gomock.Any(), uint(1), gomock.Any()).Return(nil, repository.ErrAccountCreateNotFound)
+
+	req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"closing_day":10,"due_days":7}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(StatementBillingCyclePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewStatement(StatementOpts{
+		StatementService: mockStatementService,
+	})
+
+	assert.EqualError(t, h.Configure(c), "account not found")
+}
+
+func TestHandlerStatement_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementRepository := repository.NewMockStatements(ctrl)
+	mockStatementRepository.EXPECT().FindAll(gomock.Any(), filter.StatementCollection{Account: 1}).Return([]*entity.Statement{
+		{ID: 1, Account: 1, Period: "2022-03", ClosingBalance: 1000},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(StatementFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewStatement(StatementOpts{
+		StatementRepository: mockStatementRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"period":"2022-03"`)
+		assert.NotContains(t, rec.Body.String(), `"items"`)
+	}
+}
+
+func TestHandlerStatement_FindByPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementRepository := repository.NewMockStatements(ctrl)
+	mockStatementRepository.EXPECT().FindByPeriod(gomock.Any(), uint(1), "2022-03").Return(&entity.Statement{
+		ID:      1,
+		Account: 1,
+		Period:  "2022-03",
+		Items: []*entity.StatementItem{
+			{ID: 1, Statement: 1, Transaction: 7, Type: 1, Amount: -1000},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(StatementFindByPeriodPath)
+	c.SetParamNames("id", "period")
+	c.SetParamValues("1", "2022-03")
+	h := NewStatement(StatementOpts{
+		StatementRepository: mockStatementRepository,
+	})
+
+	if assert.NoError(t, h.FindByPeriod(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"transaction_id":7`)
+	}
+}
+
+func TestHandlerStatement_FindByPeriod_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementRepository := repository.NewMockStatements(ctrl)
+	mockStatementRepository.EXPECT().FindByPeriod(gomock.Any(), uint(1), "2022-13").Return(nil, errors.New("statement not found"))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(StatementFindByPeriodPath)
+	c.SetParamNames("id", "period")
+	c.SetParamValues("1", "2022-13")
+	h := NewStatement(StatementOpts{
+		StatementRepository: mockStatementRepository,
+	})
+
+	assert.EqualError(t, h.FindByPeriod(c), "statement not found")
+}
+
+func TestHandlerStatement_FindAll_Invalid_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(StatementFindAllPath)
+	c.SetParamNames("id")
+	c.SetParamValues("x")
+	h := NewStatement(StatementOpts{
+		StatementRepository: repository.NewMockStatements(ctrl),
+	})
+
+	assert.EqualError(t, h.FindAll(c), "id: must be a positive integer.")
+}
+
+func TestHandlerStatement_Invalid_Path(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewStatement(StatementOpts{
+		StatementService:       service.NewMockStatements(ctrl),
+		StatementRepository:    repository.NewMockStatements(ctrl),
+		BillingCycleRepository: repository.NewMockBillingCycles(ctrl),
+	})
+
+	for _, handler := range []echo.HandlerFunc{h.Configure, h.FindBillingCycle, h.FindByPeriod} {
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"closing_day":10,"due_days":10}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		c.SetPath(StatementFindByPeriodPath)
+		c.SetParamNames("id", "period")
+		c.SetParamValues("abc", "2022-03")
+
+		assert.EqualError(t, handler(c), "id: must be a positive integer.")
+	}
+}
@@ -1,25 +1,38 @@
 package handler
 
 import (
+	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/labstack/echo/v4"
+	"golang.org/x/xerrors"
 	"ms/card/pkg/contract"
+	"ms/card/pkg/export"
+	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"ms/card/pkg/persistence/repository"
 	"ms/card/pkg/service"
 	"ms/card/pkg/telemetry/jaeger"
 	"net/http"
-	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	TransactionFindAllPath = "/transactions"
 	TransactionCreatePath  = "/transactions"
+	TransactionReversePath = "/transactions/:id/reversal"
+	TransactionExportPath  = "/accounts/:id/transactions/export"
+
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// exportFlushRows is how many exported rows are sent together.
+	exportFlushRows = 100
 )
 
 type (
 	TransactionOpts struct {
 		TransactionService    service.Transactions
 		TransactionRepository repository.Transactions
+		AccountRepository     repository.Accounts
 	}
 	Transaction struct {
 		TransactionOpts
@@ -37,39 +50,181 @@ func (t *Transaction) Create(c echo.Context) error {
 	request := &contract.TransactionRequest{}
 	if err := c.Bind(request); err != nil {
 		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	if key := c.Request().Header.Get(HeaderIdempotencyKey); key != "" {
+		request.IdempotencyKey = key
 	}
 
 	transaction, err := t.TransactionService.Create(ctx, request)
 	if err != nil {
 		c.Logger().Errorf("t.TransactionService.Create failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, transaction)
 }
 
+func (t *Transaction) Reverse(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	request := &contract.ReversalRequest{}
+	if err := c.Bind(request); err != nil {
+		c.Logger().Errorf("c.Bind failed with %s\n", err.Error())
+		return err
+	}
+
+	id, err := param(c, "id")
+	if err != nil {
+		c.Logger().Errorf("path validation failed with %s\n", err.Error())
+		return err
+	}
+
+	reversal, err := t.TransactionService.Reverse(ctx, id, request)
+	if err != nil {
+		c.Logger().Errorf("t.TransactionService.Reverse failed with %s\n", err.Error())
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, reversal)
+}
+
 func (t *Transaction) FindAll(c echo.Context) error {
 	ctx, span := jaeger.Span(c.Request().Context())
 	defer span.End()
 
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	size, _ := strconv.Atoi(c.QueryParam("size"))
-	createDateStart := c.QueryParam("createDateStart")
-	createDateEnd := c.QueryParam("createDateEnd")
-
-	collection, err := t.TransactionRepository.FindAll(ctx, filter.TransactionCollection{
-		Page:            page,
-		Size:            size,
-		Account:         c.QueryParam("account_id"),
-		Operation:       c.QueryParam("operation_id"),
-		CreateDateStart: createDateStart,
-		CreateDateEnd:   createDateEnd,
-	})
+	invalid := validation.Errors{}
+	filters := transactionFilters(c, filter.TransactionSortFields, invalid)
+	filters.Debit = boolean(c, "debit", invalid)
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("transactionFilters failed with %s\n", err.Error())
+		return err
+	}
+
+	collection, err := t.TransactionRepository.FindAll(ctx, filters)
 	if err != nil {
 		c.Logger().Errorf("t.TransactionRepository.FindAll failed with %s\n", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
+	collection.Link(c.Request().URL)
 	return c.JSON(http.StatusOK, collection)
 }
+
+// Export streams the transactions of the account as csv, ofx or ndjson, csv by default, with
+// the filters of FindAll but pages. Rows are written as the database reads them and flushed
+// every exportFlushRows, so the response is chunked and the status is sent before the first
+// row: a failure halfway aborts the connection instead of ending a file that looks whole.
+func (t *Transaction) Export(c echo.Context) error {
+	ctx, span := jaeger.Span(c.Request().Context())
+	defer span.End()
+
+	invalid := validation.Errors{}
+	id := identifier(c, "id", invalid)
+	filters := transactionFilters(c, filter.TransactionSortFields, invalid)
+	filters.Debit = boolean(c, "debit", invalid)
+
+	name := c.QueryParam("format")
+	if name == "" {
+		name = export.FormatCSV
+	}
+
+	format, err := export.Lookup(name)
+	if err != nil {
+		invalid["format"] = xerrors.Errorf("must be one of %s", strings.Join(export.Names(), ", "))
+	}
+
+	if format != nil && format.Period {
+		if filters.From.IsZero() {
+			invalid["from"] = xerrors.Errorf("is required by the %s format", format.Name)
+		}
+
+		if filters.To.IsZero() {
+			invalid["to"] = xerrors.Errorf("is required by the %s format", format.Name)
+		}
+	}
+
+	if err := invalid.Filter(); err != nil {
+		c.Logger().Errorf("transactionFilters failed with %s\n", err.Error())
+		return err
+	}
+
+	account, err := t.AccountRepository.FindByID(ctx, id)
+	if err != nil {
+		c.Logger().Errorf("t.AccountRepository.FindByID failed with %s\n", err.Error())
+		return err
+	}
+
+	filters.Accounts = []uint{account.ID}
+	statement := export.Statement{Account: account, From: filters.From, To: filters.To, GeneratedAt: time.Now()}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, format.ContentType)
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+format.Filename(statement)+`"`)
+	res.WriteHeader(http.StatusOK)
+
+	writer := format.New(res, statement)
+	written := 0
+	err = t.TransactionRepository.Export(ctx, filters, func(row *entity.TransactionExport) error {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+
+		if written++; written%exportFlushRows == 0 {
+			res.Flush()
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil {
+		c.Logger().Errorf("t.TransactionRepository.Export failed with %s\n", err.Error())
+		panic(http.ErrAbortHandler)
+	}
+
+	res.Flush()
+	return nil
+}
+
+// transactionFilters reads the transaction filters from the query string into invalid, they
+// are shared with the authorizations which sort by their own fields. from and to are RFC 3339
+// timestamps, either may be left out and to is exclusive. A cursor pages by id, it can't be
+// combined with another sort.
+func transactionFilters(c echo.Context, sorts map[string]string, invalid validation.Errors) filter.TransactionCollection {
+	filters := filter.TransactionCollection{
+		Page:            number(c, "page", invalid),
+		Size:            number(c, "size", invalid),
+		After:           uint(number(c, "after", invalid)),
+		Before:          uint(number(c, "before", invalid)),
+		Sort:            sorting(c, sorts, invalid),
+		Accounts:        identifiers(c, "account_id", invalid),
+		Operations:      identifiers(c, "operation_id", invalid),
+		From:            timestamp(c, "from", invalid),
+		To:              timestamp(c, "to", invalid),
+		AmountMin:       amount(c, "amount_min", invalid),
+		AmountMax:       amount(c, "amount_max", invalid),
+		MCC:             c.QueryParam("mcc"),
+		MerchantName:    c.QueryParam("merchant_name"),
+		MerchantCountry: c.QueryParam("merchant_country"),
+	}
+
+	if !filters.From.IsZero() && !filters.To.IsZero() && !filters.To.After(filters.From) {
+		invalid["to"] = xerrors.New("must be after from")
+	}
+
+	if filters.AmountMin != 0 && filters.AmountMax != 0 && filters.AmountMax < filters.AmountMin {
+		invalid["amount_max"] = xerrors.New("must not be lower than amount_min")
+	}
+
+	if (filters.After != 0 || filters.Before != 0) && !filters.Sort.IsZero() {
+		invalid["sort"] = xerrors.New("can't be combined with after or before")
+	}
+
+	return filters
+}
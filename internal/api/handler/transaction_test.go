@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/undefinedlabs/go-mpatch"
+	"golang.org/x/net/context"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"ms/card/pkg/persistence/repository"
@@ -73,7 +77,77 @@ func TestHandlerTransaction_Create_Persist_Error(t *testing.T) {
 		TransactionService: mockTransactionService,
 	})
 
-	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=failed to create new transaction")
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "failed to create new transaction")
+}
+
+func TestHandlerTransaction_Create_IdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionService := service.NewMockTransactions(ctrl)
+	mockTransactionService.EXPECT().Create(gomock.Any(), &contract.TransactionRequest{
+		Account:        1,
+		Operation:      4,
+		Amount:         10020,
+		IdempotencyKey: "a8098c1a-f86e-11da-bd1a-00112444be1e",
+	}).Return(&entity.Transaction{
+		ID:      1,
+		Account: 1,
+		Type:    4,
+		Amount:  -10020,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, TransactionCreatePath, strings.NewReader(`{"account_id":1,"operation_id":4,"amount":10020}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(HeaderIdempotencyKey, "a8098c1a-f86e-11da-bd1a-00112444be1e")
+	rec := httptest.NewRecorder()
+	h := NewTransaction(TransactionOpts{
+		TransactionService: mockTransactionService,
+	})
+
+	if assert.NoError(t, h.Create(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	}
+}
+
+func TestHandlerTransaction_Create_IdempotencyKey_Mismatch_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionService := service.NewMockTransactions(ctrl)
+	mockTransactionService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, service.ErrIdempotencyKeyMismatch)
+
+	req := httptest.NewRequest(http.MethodPost, TransactionCreatePath, strings.NewReader(`{"account_id":1,"operation_id":4,"amount":10020}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(HeaderIdempotencyKey, "a8098c1a-f86e-11da-bd1a-00112444be1e")
+	rec := httptest.NewRecorder()
+	h := NewTransaction(TransactionOpts{
+		TransactionService: mockTransactionService,
+	})
+
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "idempotency key already used with a different payload")
+}
+
+func TestHandlerTransaction_Create_Declined(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionService := service.NewMockTransactions(ctrl)
+	mockTransactionService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, service.ErrDeclineDailyLimit)
+
+	req := httptest.NewRequest(http.MethodPost, TransactionCreatePath, strings.NewReader(`{"account_id":1,"operation_id":4,"amount":10020}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	h := NewTransaction(TransactionOpts{
+		TransactionService: mockTransactionService,
+	})
+
+	err := h.Create(echo.New().NewContext(req, rec))
+	assert.ErrorIs(t, err, service.ErrDeclineDailyLimit)
+
+	problem := NewProblem(err)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+	assert.Equal(t, service.DeclineCodeDailyLimit, problem.Code)
 }
 
 func TestHandlerTransaction_Create_BindRequest_Erro(t *testing.T) {
@@ -93,7 +167,60 @@ func TestHandlerTransaction_Create_BindRequest_Erro(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h := NewTransaction(TransactionOpts{})
 
-	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=code=400, message=unexpected EOF, internal=unexpected EOF")
+	assert.EqualError(t, h.Create(echo.New().NewContext(req, rec)), "code=400, message=unexpected EOF, internal=unexpected EOF")
+}
+
+func TestHandlerTransaction_Reverse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parent := uint(1)
+	mockTransactionService := service.NewMockTransactions(ctrl)
+	mockTransactionService.EXPECT().Reverse(gomock.Any(), uint(1), &contract.ReversalRequest{Amount: 500}).Return(&entity.Transaction{
+		ID:        2,
+		Account:   1,
+		Type:      4,
+		Amount:    500,
+		Parent:    &parent,
+		CreatedAt: time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC),
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"amount":500}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(TransactionReversePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewTransaction(TransactionOpts{
+		TransactionService: mockTransactionService,
+	})
+
+	if assert.NoError(t, h.Reverse(c)) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":2,"account_id":1,"operation_id":4,"amount":500,"parent_id":1,"created_at":"2022-03-12T01:02:03.000000004Z"}`, rec.Body.String())
+	}
+}
+
+func TestHandlerTransaction_Reverse_Exceeded_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionService := service.NewMockTransactions(ctrl)
+	mockTransactionService.EXPECT().Reverse(gomock.Any(), uint(1), gomock.Any()).Return(nil, service.ErrReversalExceeded)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"amount":5000}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath(TransactionReversePath)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewTransaction(TransactionOpts{
+		TransactionService: mockTransactionService,
+	})
+
+	assert.EqualError(t, h.Reverse(c), "reversal amount exceeds the original transaction amount")
 }
 
 func TestHandlerTransaction_FindAll(t *testing.T) {
@@ -109,6 +236,14 @@ func TestHandlerTransaction_FindAll(t *testing.T) {
 	}()
 
 	collection := &entity.TransactionCollection{
+		Pagination: persistence.Pagination{Size: 2, Total: 2, PrevCursor: 1},
+		TransactionTotals: &entity.TransactionTotals{
+			Balance: -5000,
+			Debits:  10000,
+			Credits: 5000,
+			Count:   2,
+		},
+		Operations: []*entity.OperationCount{{Operation: 4, Count: 2}},
 		Data: []*entity.Transaction{
 			{
 				ID:        1,
@@ -127,13 +262,11 @@ func TestHandlerTransaction_FindAll(t *testing.T) {
 		},
 	}
 
-	collection.Sum()
-
 	mockTranscationRepository := repository.NewMockTransactions(ctrl)
-	mockTranscationRepository.EXPECT().FindAll(gomock.Any(), filter.TransactionCollection{}).Return(collection, nil)
+	mockTranscationRepository.EXPECT().FindAll(gomock.Any(), filter.TransactionCollection{Size: 2, Before: 3}).Return(collection, nil)
 
 	server := echo.New()
-	req := httptest.NewRequest(http.MethodPost, AccountFindByIDPath, nil)
+	req := httptest.NewRequest(http.MethodGet, TransactionFindAllPath+"?before=3&size=2", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := server.NewContext(req, rec)
@@ -145,7 +278,15 @@ func TestHandlerTransaction_FindAll(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.JSONEq(t, `
 		{
+			"size": 2,
+			"total": 2,
+			"prev_cursor": 1,
+			"prev": "/transactions?before=1&size=2",
 			"balance": -5000,
+			"debits": 10000,
+			"credits": 5000,
+			"count": 2,
+			"operations": [{"operation_id":4,"count":2}],
 			"data": [
 				{"id":1,"account_id":1,"operation_id":4,"amount":-10000,"created_at":"2022-03-12T01:02:03.000000004Z"},
 				{"id":2,"account_id":1,"operation_id":4,"amount":5000,"created_at":"2022-03-12T01:02:03.000000004Z"}
@@ -155,6 +296,178 @@ func TestHandlerTransaction_FindAll(t *testing.T) {
 	}
 }
 
+func TestHandlerTransaction_FindAll_Next_Link(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranscationRepository := repository.NewMockTransactions(ctrl)
+	mockTranscationRepository.EXPECT().FindAll(gomock.Any(), filter.TransactionCollection{
+		Size:     2,
+		Sort:     filter.Sort{Column: "id"},
+		Accounts: []uint{1},
+	}).Return(&entity.TransactionCollection{
+		Pagination: persistence.Pagination{Page: 1, Size: 2, Total: 3, NextCursor: 2},
+		Data:       []*entity.Transaction{{ID: 1}, {ID: 2}},
+	}, nil)
+	mockTranscationRepository.EXPECT().FindAll(gomock.Any(), filter.TransactionCollection{
+		Size:     2,
+		After:    2,
+		Accounts: []uint{1},
+	}).Return(&entity.TransactionCollection{
+		Pagination: persistence.Pagination{Size: 2, PrevCursor: 3},
+		Data:       []*entity.Transaction{{ID: 3}},
+	}, nil)
+
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: mockTranscationRepository,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, TransactionFindAllPath+"?account_id=1&size=2&sort=id", nil)
+	rec := httptest.NewRecorder()
+	if !assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		return
+	}
+
+	var page entity.TransactionCollection
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Equal(t, "/transactions?account_id=1&after=2&size=2", page.Next)
+
+	// the next link is followed as it was returned
+	req = httptest.NewRequest(http.MethodGet, page.Next, nil)
+	rec = httptest.NewRecorder()
+	if assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandlerTransaction_FindAll_Merchant_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranscationRepository := repository.NewMockTransactions(ctrl)
+	mockTranscationRepository.EXPECT().FindAll(gomock.Any(), filter.TransactionCollection{
+		MCC:             "5411",
+		MerchantName:    "market",
+		MerchantCountry: "BR",
+	}).Return(&entity.TransactionCollection{Data: []*entity.Transaction{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, TransactionFindAllPath+"?mcc=5411&merchant_name=market&merchant_country=BR", nil)
+	rec := httptest.NewRecorder()
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: mockTranscationRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandlerTransaction_FindAll_Period_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranscationRepository := repository.NewMockTransactions(ctrl)
+	mockTranscationRepository.EXPECT().FindAll(gomock.Any(), filter.TransactionCollection{
+		From: time.Date(2022, time.March, 1, 3, 0, 0, 0, time.UTC),
+		To:   time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}).Return(&entity.TransactionCollection{Data: []*entity.Transaction{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, TransactionFindAllPath+"?from=2022-03-01T00:00:00-03:00&to=2022-04-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: mockTranscationRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandlerTransaction_FindAll_Period_Invalid(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "from", query: "?from=2022-03-01", expected: "from: must be an RFC 3339 timestamp."},
+		{name: "to", query: "?to=01/04/2022", expected: "to: must be an RFC 3339 timestamp."},
+		{name: "order", query: "?from=2022-04-01T00:00:00Z&to=2022-03-01T00:00:00Z", expected: "to: must be after from."},
+		{name: "empty", query: "?from=2022-04-01T00:00:00Z&to=2022-04-01T00:00:00Z", expected: "to: must be after from."},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := httptest.NewRequest(http.MethodGet, TransactionFindAllPath+tt.query, nil)
+			rec := httptest.NewRecorder()
+			h := NewTransaction(TransactionOpts{
+				TransactionRepository: repository.NewMockTransactions(ctrl),
+			})
+
+			assert.EqualError(t, h.FindAll(echo.New().NewContext(req, rec)), tt.expected)
+		})
+	}
+}
+
+func TestHandlerTransaction_FindAll_Amount_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	debit := false
+	mockTranscationRepository := repository.NewMockTransactions(ctrl)
+	mockTranscationRepository.EXPECT().FindAll(gomock.Any(), filter.TransactionCollection{
+		Sort:       filter.Sort{Column: "amount", Descending: true},
+		Accounts:   []uint{1, 2, 3},
+		Operations: []uint{4},
+		AmountMin:  1000,
+		AmountMax:  5000,
+		Debit:      &debit,
+	}).Return(&entity.TransactionCollection{Data: []*entity.Transaction{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, TransactionFindAllPath+"?account_id=1,2&account_id=3&operation_id=4&amount_min=1000&amount_max=5000&debit=false&sort=amount:desc", nil)
+	rec := httptest.NewRecorder()
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: mockTranscationRepository,
+	})
+
+	if assert.NoError(t, h.FindAll(echo.New().NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandlerTransaction_FindAll_Invalid(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "numbers", query: "?page=one&after=x", expected: "after: must be a non-negative integer; page: must be a non-negative integer."},
+		{name: "ids", query: "?account_id=1,a&operation_id=0", expected: "account_id: must be a list of ids; operation_id: must be a list of ids."},
+		{name: "amounts", query: "?amount_min=5000&amount_max=1000", expected: "amount_max: must not be lower than amount_min."},
+		{name: "debit", query: "?debit=credit", expected: "debit: must be true or false."},
+		{name: "cursor", query: "?after=10&sort=amount", expected: "sort: can't be combined with after or before."},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := httptest.NewRequest(http.MethodGet, TransactionFindAllPath+tt.query, nil)
+			rec := httptest.NewRecorder()
+			h := NewTransaction(TransactionOpts{
+				TransactionRepository: repository.NewMockTransactions(ctrl),
+			})
+
+			assert.EqualError(t, h.FindAll(echo.New().NewContext(req, rec)), tt.expected)
+		})
+	}
+}
+
 func TestHandlerTransaction_FindAll_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -179,5 +492,166 @@ func TestHandlerTransaction_FindAll_Error(t *testing.T) {
 		TransactionRepository: mockTranscationRepository,
 	})
 
-	assert.EqualError(t, h.FindAll(c), "code=400, message=err find all")
+	assert.EqualError(t, h.FindAll(c), "err find all")
+}
+
+func TestHandlerTransaction_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, Currency: "BRL"}, nil)
+
+	mockTranscationRepository := repository.NewMockTransactions(ctrl)
+	mockTranscationRepository.EXPECT().Export(gomock.Any(), filter.TransactionCollection{
+		Accounts: []uint{1},
+		From:     time.Date(2022, time.March, 1, 3, 0, 0, 0, time.UTC),
+	}, gomock.Any()).DoAndReturn(func(ctx context.Context, filters filter.TransactionCollection, fn func(row *entity.TransactionExport) error) error {
+		for i := uint(1); i <= 150; i++ {
+			row := &entity.TransactionExport{
+				Transaction: entity.Transaction{ID: i, Account: 1, Type: 1, Amount: -1050, CreatedAt: time.Date(2022, time.March, 12, 1, 2, 3, 0, time.UTC)},
+				Operation:   "COMPRA A VISTA",
+			}
+
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions/export?format=ndjson&from=2022-03-01T00:00:00-03:00&account_id=2", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: mockTranscationRepository,
+		AccountRepository:     mockAccountRepository,
+	})
+
+	if assert.NoError(t, h.Export(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="account-1-transactions.ndjson"`, rec.Header().Get(echo.HeaderContentDisposition))
+		lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+		if assert.Len(t, lines, 150) {
+			assert.JSONEq(t, `{"id":1,"created_at":"2022-03-12T01:02:03Z","account_id":1,"operation_id":1,"operation":"COMPRA A VISTA","amount":"-10.50","currency":"BRL"}`, lines[0])
+		}
+	}
+}
+
+func TestHandlerTransaction_Export_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions/export?format=xlsx&amount_min=-1", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: repository.NewMockTransactions(ctrl),
+		AccountRepository:     repository.NewMockAccounts(ctrl),
+	})
+
+	assert.EqualError(t, h.Export(c), "amount_min: must be a positive integer; format: must be one of csv, ndjson, ofx.")
+}
+
+func TestHandlerTransaction_Export_OFX_Period_Required(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions/export?format=ofx&from=2022-03-01T00:00:00-03:00", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: repository.NewMockTransactions(ctrl),
+		AccountRepository:     repository.NewMockAccounts(ctrl),
+	})
+
+	assert.EqualError(t, h.Export(c), "to: is required by the ofx format.")
+}
+
+func TestHandlerTransaction_Export_Account_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(9)).Return(nil, repository.ErrAccountCreateNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/9/transactions/export", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("9")
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: repository.NewMockTransactions(ctrl),
+		AccountRepository:     mockAccountRepository,
+	})
+
+	assert.Equal(t, repository.ErrAccountCreateNotFound, h.Export(c))
+	assert.False(t, c.Response().Committed)
+}
+
+func TestHandlerTransaction_Export_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, Currency: "BRL"}, nil)
+
+	mockTranscationRepository := repository.NewMockTransactions(ctrl)
+	mockTranscationRepository.EXPECT().Export(gomock.Any(), filter.TransactionCollection{Accounts: []uint{1}}, gomock.Any()).
+		Return(repository.ErrTransactionExport)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions/export?format=csv", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	h := NewTransaction(TransactionOpts{
+		TransactionRepository: mockTranscationRepository,
+		AccountRepository:     mockAccountRepository,
+	})
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { _ = h.Export(c) })
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandlerTransaction_Reverse_Invalid_Path(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	c.SetPath(TransactionReversePath)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	h := NewTransaction(TransactionOpts{
+		TransactionService: service.NewMockTransactions(ctrl),
+	})
+
+	assert.EqualError(t, h.Reverse(c), "id: must be a positive integer.")
+}
+
+func TestHandlerTransaction_Export_Invalid_Path(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/?format=xml", nil), httptest.NewRecorder())
+	c.SetPath(TransactionExportPath)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	h := NewTransaction(TransactionOpts{
+		AccountRepository:     repository.NewMockAccounts(ctrl),
+		TransactionRepository: repository.NewMockTransactions(ctrl),
+	})
+
+	assert.EqualError(t, h.Export(c), "format: must be one of csv, ndjson, ofx; id: must be a positive integer.")
 }
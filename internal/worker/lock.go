@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"golang.org/x/net/context"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+)
+
+const (
+	// lockClass namespaces the advisory locks of the workers, each worker locks the hash of its
+	// name within it.
+	lockClass = 7412054
+)
+
+type (
+	// Locker runs the job on a single instance at a time, the others skip the run.
+	Locker interface {
+		Locked(ctx context.Context, name string, job Job) error
+	}
+
+	AdvisoryLocker struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewAdvisoryLocker(logger common.Logger, adapter *gorm.DB) *AdvisoryLocker {
+	return &AdvisoryLocker{logger, adapter}
+}
+
+// Locked runs the job holding a postgres advisory lock of the name, on a connection of its
+// own. The run is skipped when another instance holds it.
+func (a *AdvisoryLocker) Locked(ctx context.Context, name string, job Job) error {
+	return a.adapter.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		var locked bool
+		if err := conn.Raw(`SELECT pg_try_advisory_lock(?, hashtext(?))`, lockClass, name).Scan(&locked).Error; err != nil {
+			a.logger.Errorf("pg_try_advisory_lock() failed with %s\n", err)
+			return err
+		}
+
+		if !locked {
+			return nil
+		}
+
+		defer func() {
+			// the job may have run out of time, the lock is released regardless
+			err := conn.WithContext(context.Background()).Exec(`SELECT pg_advisory_unlock(?, hashtext(?))`, lockClass, name).Error
+			if err != nil {
+				a.logger.Errorf("pg_advisory_unlock() failed with %s\n", err)
+			}
+		}()
+
+		return job(ctx)
+	})
+}
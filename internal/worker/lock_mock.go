@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/worker/lock.go
+
+// Package worker is a generated GoMock package.
+package worker
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockLocker is a mock of Locker interface.
+type MockLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockerMockRecorder
+}
+
+// MockLockerMockRecorder is the mock recorder for MockLocker.
+type MockLockerMockRecorder struct {
+	mock *MockLocker
+}
+
+// NewMockLocker creates a new mock instance.
+func NewMockLocker(ctrl *gomock.Controller) *MockLocker {
+	mock := &MockLocker{ctrl: ctrl}
+	mock.recorder = &MockLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocker) EXPECT() *MockLockerMockRecorder {
+	return m.recorder
+}
+
+// Locked mocks base method.
+func (m *MockLocker) Locked(ctx context.Context, name string, job Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Locked", ctx, name, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Locked indicates an expected call of Locked.
+func (mr *MockLockerMockRecorder) Locked(ctx, name, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Locked", reflect.TypeOf((*MockLocker)(nil).Locked), ctx, name, job)
+}
@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"regexp"
+	"testing"
+)
+
+func newAdvisoryLocker(t *testing.T, logger common.Logger) (*AdvisoryLocker, sqlmock.Sqlmock) {
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = mockdb.Close() })
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return NewAdvisoryLocker(logger, gormdb), dbmock
+}
+
+func expectTryLock(dbmock sqlmock.Sqlmock, locked bool) {
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_lock($1, hashtext($2))`)).WithArgs(lockClass, "test").
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(locked))
+}
+
+func TestAdvisoryLocker_Locked(t *testing.T) {
+	locker, dbmock := newAdvisoryLocker(t, nil)
+	expectTryLock(dbmock, true)
+	dbmock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1, hashtext($2))`)).WithArgs(lockClass, "test").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	calls := 0
+	err := locker.Locked(context.Background(), "test", func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestAdvisoryLocker_Locked_Held(t *testing.T) {
+	locker, dbmock := newAdvisoryLocker(t, nil)
+	expectTryLock(dbmock, false)
+
+	err := locker.Locked(context.Background(), "test", func(ctx context.Context) error {
+		t.Fatal("the job ran without the lock")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestAdvisoryLocker_Locked_Job_Error(t *testing.T) {
+	locker, dbmock := newAdvisoryLocker(t, nil)
+	expectTryLock(dbmock, true)
+	dbmock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1, hashtext($2))`)).WithArgs(lockClass, "test").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := locker.Locked(context.Background(), "test", func(ctx context.Context) error {
+		return errors.New("job failed")
+	})
+	assert.EqualError(t, err, "job failed")
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestAdvisoryLocker_Locked_Lock_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	locker, dbmock := newAdvisoryLocker(t, mockLogger)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_lock($1, hashtext($2))`)).WillReturnError(errors.New("connection refused"))
+
+	err := locker.Locked(context.Background(), "test", func(ctx context.Context) error {
+		t.Fatal("the job ran without the lock")
+		return nil
+	})
+	assert.EqualError(t, err, "connection refused")
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"time"
+)
+
+type (
+	Job func(ctx context.Context) error
+
+	Opts struct {
+		Logger   common.Logger
+		Name     string
+		Interval time.Duration
+		Timeout  time.Duration
+		Job      Job
+		Locker   Locker
+	}
+
+	Worker struct {
+		Opts
+	}
+)
+
+func New(opts Opts) *Worker {
+	return &Worker{opts}
+}
+
+// Start runs the job every interval until ctx is cancelled. A failed run is logged and
+// retried on the next tick. With a Locker, a run is skipped while another instance runs it.
+func (w *Worker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.run(ctx)
+		}
+	}
+}
+
+func (w *Worker) run(ctx context.Context) {
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+
+	var err error
+	if w.Locker != nil {
+		err = w.Locker.Locked(ctx, w.Name, w.Job)
+	} else {
+		err = w.Job(ctx)
+	}
+
+	if err != nil {
+		w.Logger.Errorf("worker %s failed with %s\n", w.Name, err)
+	}
+}
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"testing"
+	"time"
+)
+
+func TestWorker_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	w := New(Opts{
+		Name:     "test",
+		Interval: time.Millisecond,
+		Job: func(ctx context.Context) error {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+
+			return nil
+		},
+	})
+
+	w.Start(ctx)
+	assert.Equal(t, 2, calls)
+}
+
+func TestWorker_Start_Job_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := New(Opts{
+		Logger:   mockLogger,
+		Name:     "test",
+		Interval: time.Millisecond,
+		Timeout:  time.Second,
+		Job: func(ctx context.Context) error {
+			cancel()
+			return errors.New("job failed")
+		},
+	})
+
+	w.Start(ctx)
+}
+
+func TestWorker_Start_Locker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockLocker := NewMockLocker(ctrl)
+	mockLocker.EXPECT().Locked(gomock.Any(), "test", gomock.Any()).DoAndReturn(func(ctx context.Context, name string, job Job) error {
+		cancel()
+		return job(ctx)
+	})
+
+	calls := 0
+	w := New(Opts{
+		Name:     "test",
+		Interval: time.Millisecond,
+		Locker:   mockLocker,
+		Job: func(ctx context.Context) error {
+			calls++
+			return nil
+		},
+	})
+
+	w.Start(ctx)
+	assert.Equal(t, 1, calls)
+}
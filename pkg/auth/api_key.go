@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+const (
+	APIKeyPrefix = "ck_"
+	apiKeySize   = 32
+)
+
+type (
+	APIKeyOpts struct {
+		Logger           common.Logger
+		APIKeyRepository repository.APIKeys
+	}
+
+	APIKey struct {
+		APIKeyOpts
+	}
+)
+
+func NewAPIKey(opts APIKeyOpts) *APIKey {
+	return &APIKey{opts}
+}
+
+func (a *APIKey) Authenticate(ctx context.Context, key string) (*Principal, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	stored, err := a.APIKeyRepository.FindByHash(ctx, Hash(key))
+	if xerrors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, ErrUnauthenticated
+	}
+
+	if err != nil {
+		a.Logger.Errorf("a.APIKeyRepository.FindByHash failed with %s\n", err)
+		return nil, err
+	}
+
+	if stored.Revoked() {
+		return nil, ErrUnauthenticated
+	}
+
+	return &Principal{Client: stored.Client, Scopes: stored.Scopes}, nil
+}
+
+// GenerateKey returns a new random api key, it must be stored by its Hash only.
+func GenerateKey() (string, error) {
+	raw := make([]byte, apiKeySize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	return APIKeyPrefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// Hash is the stored form of an api key. Keys are random and long, a plain SHA-256 is enough
+// and lets them be looked up directly.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
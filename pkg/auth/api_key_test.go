@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKey_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepository := repository.NewMockAPIKeys(ctrl)
+	mockAPIKeyRepository.EXPECT().FindByHash(gomock.Any(), Hash("ck_secret")).Return(&entity.APIKey{
+		Client: "checkout",
+		Scopes: entity.List{ScopeTransactionsCreate},
+	}, nil)
+
+	authenticator := NewAPIKey(APIKeyOpts{APIKeyRepository: mockAPIKeyRepository})
+
+	principal, err := authenticator.Authenticate(context.Background(), "ck_secret")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "checkout", principal.Client)
+		assert.True(t, principal.Allowed(ScopeTransactionsCreate))
+		assert.False(t, principal.Allowed(ScopeOperationsAdmin))
+	}
+}
+
+func TestAPIKey_Authenticate_Unknown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepository := repository.NewMockAPIKeys(ctrl)
+	mockAPIKeyRepository.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(nil, repository.ErrAPIKeyNotFound)
+
+	authenticator := NewAPIKey(APIKeyOpts{APIKeyRepository: mockAPIKeyRepository})
+
+	principal, err := authenticator.Authenticate(context.Background(), "ck_unknown")
+	assert.Nil(t, principal)
+	assert.Equal(t, ErrUnauthenticated, err)
+}
+
+func TestAPIKey_Authenticate_Revoked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	revoked := time.Now()
+	mockAPIKeyRepository := repository.NewMockAPIKeys(ctrl)
+	mockAPIKeyRepository.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(&entity.APIKey{Client: "checkout", RevokedAt: &revoked}, nil)
+
+	authenticator := NewAPIKey(APIKeyOpts{APIKeyRepository: mockAPIKeyRepository})
+
+	principal, err := authenticator.Authenticate(context.Background(), "ck_secret")
+	assert.Nil(t, principal)
+	assert.Equal(t, ErrUnauthenticated, err)
+}
+
+func TestGenerateKey(t *testing.T) {
+	first, err := GenerateKey()
+	assert.NoError(t, err)
+	second, err := GenerateKey()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, APIKeyPrefix))
+	assert.NotEqual(t, first, second)
+	assert.Len(t, Hash(first), 64)
+}
+
+func TestClient(t *testing.T) {
+	assert.Empty(t, Client(context.Background()))
+
+	ctx := WithPrincipal(context.Background(), &Principal{Client: "checkout"})
+	assert.Equal(t, "checkout", Client(ctx))
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/telemetry/jaeger"
+	"strings"
+)
+
+var (
+	ErrTokenAlgorithm = xerrors.New("token signing algorithm not accepted")
+)
+
+type (
+	// JWTOpts holds the local keys, Secret verifies HS256 tokens and PublicKey RS256 ones. A
+	// token signed with an algorithm without key is refused.
+	JWTOpts struct {
+		Secret    []byte
+		PublicKey *rsa.PublicKey
+		// Issuer, when set, must match the iss claim.
+		Issuer string
+	}
+
+	JWT struct {
+		JWTOpts
+	}
+
+	// Claims is the token payload, the client is the subject and scope lists the granted scopes
+	// separated by spaces.
+	Claims struct {
+		jwt.StandardClaims
+		Scope string `json:"scope"`
+	}
+)
+
+func NewJWT(opts JWTOpts) *JWT {
+	return &JWT{opts}
+}
+
+// Authenticate accepts a signed token with a subject and an expiration.
+func (j *JWT) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	_, span := jaeger.Span(ctx)
+	defer span.End()
+
+	claims := &Claims{}
+	if _, err := jwt.ParseWithClaims(token, claims, j.key); err != nil {
+		return nil, ErrUnauthenticated
+	}
+
+	if claims.Subject == "" || claims.ExpiresAt == 0 {
+		return nil, ErrUnauthenticated
+	}
+
+	if j.Issuer != "" && !claims.VerifyIssuer(j.Issuer, true) {
+		return nil, ErrUnauthenticated
+	}
+
+	return &Principal{Client: claims.Subject, Scopes: strings.Fields(claims.Scope)}, nil
+}
+
+// key picks the key by the exact algorithm of the token, so an RS256 public key is never
+// used as an HS256 secret.
+func (j *JWT) key(token *jwt.Token) (interface{}, error) {
+	switch {
+	case token.Method == jwt.SigningMethodHS256 && len(j.Secret) > 0:
+		return j.Secret, nil
+	case token.Method == jwt.SigningMethodRS256 && j.PublicKey != nil:
+		return j.PublicKey, nil
+	default:
+		return nil, ErrTokenAlgorithm
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"testing"
+	"time"
+)
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims Claims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	assert.NoError(t, err)
+	return token
+}
+
+func TestJWT_Authenticate(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	secret := []byte("secret")
+	valid := Claims{
+		StandardClaims: jwt.StandardClaims{Subject: "backoffice", Issuer: "card", ExpiresAt: time.Now().Add(time.Hour).Unix()},
+		Scope:          "accounts:read accounts:write",
+	}
+
+	authenticator := NewJWT(JWTOpts{Secret: secret, PublicKey: &private.PublicKey, Issuer: "card"})
+
+	cases := []struct {
+		name   string
+		token  string
+		client string
+	}{
+		{"hs256", sign(t, jwt.SigningMethodHS256, secret, valid), "backoffice"},
+		{"rs256", sign(t, jwt.SigningMethodRS256, private, valid), "backoffice"},
+		{"wrong secret", sign(t, jwt.SigningMethodHS256, []byte("other"), valid), ""},
+		{"algorithm without key", sign(t, jwt.SigningMethodHS512, secret, valid), ""},
+		{"expired", sign(t, jwt.SigningMethodHS256, secret, Claims{StandardClaims: jwt.StandardClaims{Subject: "backoffice", Issuer: "card", ExpiresAt: time.Now().Add(-time.Minute).Unix()}}), ""},
+		{"without expiration", sign(t, jwt.SigningMethodHS256, secret, Claims{StandardClaims: jwt.StandardClaims{Subject: "backoffice", Issuer: "card"}}), ""},
+		{"without subject", sign(t, jwt.SigningMethodHS256, secret, Claims{StandardClaims: jwt.StandardClaims{Issuer: "card", ExpiresAt: valid.ExpiresAt}}), ""},
+		{"other issuer", sign(t, jwt.SigningMethodHS256, secret, Claims{StandardClaims: jwt.StandardClaims{Subject: "backoffice", Issuer: "other", ExpiresAt: valid.ExpiresAt}}), ""},
+		{"garbage", "not-a-token", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			principal, err := authenticator.Authenticate(context.Background(), c.token)
+			if c.client == "" {
+				assert.Nil(t, principal)
+				assert.Equal(t, ErrUnauthenticated, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, c.client, principal.Client)
+				assert.Equal(t, []string{ScopeAccountsRead, ScopeAccountsWrite}, principal.Scopes)
+			}
+		})
+	}
+}
+
+func TestJWT_Authenticate_PublicKey_As_Secret(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	// only RS256 is configured, an HS256 token must not be checked against anything.
+	authenticator := NewJWT(JWTOpts{PublicKey: &private.PublicKey})
+	token := sign(t, jwt.SigningMethodHS256, []byte("guess"), Claims{StandardClaims: jwt.StandardClaims{Subject: "x", ExpiresAt: time.Now().Add(time.Hour).Unix()}})
+
+	principal, err := authenticator.Authenticate(context.Background(), token)
+	assert.Nil(t, principal)
+	assert.Equal(t, ErrUnauthenticated, err)
+}
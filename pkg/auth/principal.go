@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/domain"
+)
+
+const (
+	ScopeAccountsRead       = "accounts:read"
+	ScopeAccountsWrite      = "accounts:write"
+	ScopeOperationsRead     = "operations:read"
+	ScopeOperationsAdmin    = "operations:admin"
+	ScopeTransactionsRead   = "transactions:read"
+	ScopeTransactionsCreate = "transactions:create"
+	ScopeLedgerRead         = "ledger:read"
+	// ScopePIIRead unmasks personal data, as the document numbers of accounts.
+	ScopePIIRead = "pii:read"
+)
+
+var (
+	ErrUnauthenticated = domain.Unauthorized("unauthenticated", "missing or invalid credentials")
+	ErrForbidden       = domain.Forbidden("insufficient_scope", "the client is not allowed to do this")
+)
+
+type (
+	// Authenticator resolves a credential, an api key or a token, to the client presenting it.
+	Authenticator interface {
+		Authenticate(ctx context.Context, credential string) (*Principal, error)
+	}
+
+	// Principal is the authenticated client of a request.
+	Principal struct {
+		Client string
+		Scopes []string
+	}
+
+	principalKey struct{}
+)
+
+func (p *Principal) Allowed(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// Client is the id of the authenticated client, recorded as created_by. It is empty outside
+// of a request, for the records created by the workers.
+func Client(ctx context.Context) string {
+	if principal, ok := FromContext(ctx); ok {
+		return principal.Client
+	}
+
+	return ""
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"strings"
+)
+
+const (
+	DocumentTypeCPF  = "cpf"
+	DocumentTypeCNPJ = "cnpj"
+
+	CPFLength  = 11
+	CNPJLength = 14
+
+	// documentVisible is how many trailing digits a masked document keeps.
+	documentVisible = 2
+)
+
+var (
+	cnpjWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// NormalizeDocument strips the punctuation of a document number, "560.770.530-74" becomes
+// "56077053074".
+func NormalizeDocument(document string) string {
+	var digits strings.Builder
+	for _, r := range document {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	return digits.String()
+}
+
+// DocumentType tells a CPF from a CNPJ by its check digits, it is empty for anything else.
+// The document must be normalized.
+func DocumentType(document string) string {
+	switch {
+	case len(document) == CPFLength && validCPF(document):
+		return DocumentTypeCPF
+	case len(document) == CNPJLength && validCNPJ(document):
+		return DocumentTypeCNPJ
+	}
+
+	return ""
+}
+
+// MaskDocument hides every digit of the document but the last ones, keeping its length and
+// punctuation so the type can still be told apart.
+func MaskDocument(document string) string {
+	masked := []byte(document)
+	visible := 0
+	for i := len(masked) - 1; i >= 0; i-- {
+		if masked[i] < '0' || masked[i] > '9' {
+			continue
+		}
+
+		if visible < documentVisible {
+			visible++
+			continue
+		}
+
+		masked[i] = '*'
+	}
+
+	return string(masked)
+}
+
+func validCPF(document string) bool {
+	if repeated(document) {
+		return false
+	}
+
+	for n := 9; n <= 10; n++ {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(document[i]-'0') * (n + 1 - i)
+		}
+
+		digit := sum * 10 % 11
+		if digit == 10 {
+			digit = 0
+		}
+
+		if digit != int(document[n]-'0') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validCNPJ(document string) bool {
+	if repeated(document) {
+		return false
+	}
+
+	for n := 12; n <= 13; n++ {
+		weights := cnpjWeights[13-n:]
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(document[i]-'0') * weights[i]
+		}
+
+		digit := 0
+		if sum%11 >= 2 {
+			digit = 11 - sum%11
+		}
+
+		if digit != int(document[n]-'0') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// repeated catches the numbers made of a single digit, their check digits add up but none
+// is ever issued.
+func repeated(document string) bool {
+	return strings.Count(document, document[:1]) == len(document)
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeDocument(t *testing.T) {
+	assert.Equal(t, "56077053074", NormalizeDocument("560.770.530-74"))
+	assert.Equal(t, "11222333000181", NormalizeDocument("11.222.333/0001-81"))
+	assert.Equal(t, "", NormalizeDocument("abc"))
+}
+
+func TestDocumentType(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "56077053074", expected: DocumentTypeCPF},
+		{input: "64715245019", expected: DocumentTypeCPF},
+		{input: "56077053075", expected: ""},
+		{input: "11111111111", expected: ""},
+		{input: "11222333000181", expected: DocumentTypeCNPJ},
+		{input: "11444777000161", expected: DocumentTypeCNPJ},
+		{input: "11222333000182", expected: ""},
+		{input: "00000000000000", expected: ""},
+		{input: "5607705307", expected: ""},
+		{input: "", expected: ""},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DocumentType(tt.input))
+		})
+	}
+}
+
+func TestMaskDocument(t *testing.T) {
+	assert.Equal(t, "*********74", MaskDocument("56077053074"))
+	assert.Equal(t, "***.***.***-74", MaskDocument("560.770.530-74"))
+	assert.Equal(t, "************81", MaskDocument("11222333000181"))
+	assert.Equal(t, "", MaskDocument(""))
+}
@@ -1,5 +1,9 @@
 package common
 
+import (
+	"fmt"
+)
+
 func Abs(x int64) int64 {
 	if x < 0 {
 		return -x
@@ -7,3 +11,31 @@ func Abs(x int64) int64 {
 
 	return x
 }
+
+// Split divides amount into parts installments. Cents that do not divide evenly are added
+// to the first installment, so every other installment carries the same value.
+func Split(amount int64, parts int) []int64 {
+	if parts <= 0 {
+		return nil
+	}
+
+	values := make([]int64, parts)
+	share := amount / int64(parts)
+	for i := range values {
+		values[i] = share
+	}
+
+	values[0] += amount - share*int64(parts)
+	return values
+}
+
+// FormatAmount writes an amount in cents with two decimals, -1234 is "-12.34".
+func FormatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+
+	abs := uint64(Abs(amount))
+	return fmt.Sprintf("%s%d.%02d", sign, abs/100, abs%100)
+}
@@ -28,3 +28,59 @@ func TestIntToNegative(t *testing.T) {
 		})
 	}
 }
+
+func TestSplit(t *testing.T) {
+	cases := []struct {
+		amount   int64
+		parts    int
+		expected []int64
+	}{
+		{
+			amount:   1000,
+			parts:    4,
+			expected: []int64{250, 250, 250, 250},
+		},
+		{
+			amount:   1000,
+			parts:    3,
+			expected: []int64{334, 333, 333},
+		},
+		{
+			amount:   2,
+			parts:    3,
+			expected: []int64{2, 0, 0},
+		},
+		{
+			amount:   1000,
+			parts:    0,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, tt.expected, Split(tt.amount, tt.parts))
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	cases := []struct {
+		input    int64
+		expected string
+	}{
+		{input: 0, expected: "0.00"},
+		{input: 5, expected: "0.05"},
+		{input: -1234, expected: "-12.34"},
+		{input: 100000, expected: "1000.00"},
+		{input: -99, expected: "-0.99"},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatAmount(tt.input))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"golang.org/x/xerrors"
+	"math/big"
+	"regexp"
+)
+
+const (
+	PANLength = 16
+)
+
+var (
+	ErrBINInvalid      = xerrors.New("bin must be 6 to 8 digits")
+	ErrTokenKeyMissing = xerrors.New("token key must not be empty")
+
+	binFormat = regexp.MustCompile(`^[0-9]{6,8}$`)
+)
+
+// ParseBIN checks the issuer bin the card numbers start with.
+func ParseBIN(value string) (string, error) {
+	if !binFormat.MatchString(value) {
+		return "", xerrors.Errorf("%q: %w", value, ErrBINInvalid)
+	}
+
+	return value, nil
+}
+
+// ParseTokenKey checks the key of TokenizePAN, an empty one would make the tokens plain
+// hashes of the card numbers.
+func ParseTokenKey(value string) ([]byte, error) {
+	if value == "" {
+		return nil, ErrTokenKeyMissing
+	}
+
+	return []byte(value), nil
+}
+
+// GeneratePAN returns a random card number starting with the bin and ending with its Luhn
+// check digit.
+func GeneratePAN(bin string) (string, error) {
+	digits := []byte(bin)
+	for len(digits) < PANLength-1 {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+
+		digits = append(digits, byte('0'+n.Int64()))
+	}
+
+	return string(digits) + string('0'+LuhnDigit(string(digits))), nil
+}
+
+// LuhnDigit computes the check digit to append to the number.
+func LuhnDigit(number string) byte {
+	sum := 0
+	double := true
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+
+	return byte((10 - sum%10) % 10)
+}
+
+// TokenizePAN derives a stable token from the card number, without the key it can't be
+// reversed nor brute forced from the last four digits.
+func TokenizePAN(key []byte, pan string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(pan))
+	return hex.EncodeToString(mac.Sum(nil))
+}
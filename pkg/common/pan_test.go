@@ -0,0 +1,67 @@
+package common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLuhnDigit(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected byte
+	}{
+		{
+			input:    "7992739871",
+			expected: 3,
+		},
+		{
+			input:    "411111111111111",
+			expected: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, LuhnDigit(tt.input))
+		})
+	}
+}
+
+func TestGeneratePAN(t *testing.T) {
+	pan, err := GeneratePAN("550209")
+	assert.NoError(t, err)
+	assert.Len(t, pan, PANLength)
+	assert.True(t, strings.HasPrefix(pan, "550209"))
+	assert.Equal(t, pan[PANLength-1]-'0', LuhnDigit(pan[:PANLength-1]))
+}
+
+func TestTokenizePAN(t *testing.T) {
+	token := TokenizePAN([]byte("key"), "4111111111111111")
+	assert.Len(t, token, 64)
+	assert.Equal(t, token, TokenizePAN([]byte("key"), "4111111111111111"))
+	assert.NotEqual(t, token, TokenizePAN([]byte("other"), "4111111111111111"))
+}
+
+func TestParseBIN(t *testing.T) {
+	for _, value := range []string{"550209", "5502091", "55020912"} {
+		bin, err := ParseBIN(value)
+		assert.NoError(t, err)
+		assert.Equal(t, value, bin)
+	}
+
+	for _, value := range []string{"", "55020", "550209123", "5502a9"} {
+		_, err := ParseBIN(value)
+		assert.ErrorIs(t, err, ErrBINInvalid)
+	}
+}
+
+func TestParseTokenKey(t *testing.T) {
+	key, err := ParseTokenKey("change-me")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("change-me"), key)
+
+	_, err = ParseTokenKey("")
+	assert.Equal(t, ErrTokenKeyMissing, err)
+}
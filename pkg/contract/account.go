@@ -5,15 +5,41 @@ import (
 )
 
 type (
+	// AccountRequest opens an account for a CPF or, for business cards, a CNPJ. The document
+	// may come punctuated, it is stored with digits only.
 	AccountRequest struct {
 		Document string `json:"document_number"`
 		Limit    int64  `json:"limit"`
+		Currency string `json:"currency,omitempty"`
+	}
+
+	// AccountUpdateRequest changes the credit limit, the status or both, Reason is kept in
+	// the audit trail.
+	AccountUpdateRequest struct {
+		Limit  *int64 `json:"limit,omitempty"`
+		Status string `json:"status,omitempty"`
+		Reason string `json:"reason,omitempty"`
 	}
 )
 
 func (a AccountRequest) Validate() error {
 	return validation.ValidateStruct(
 		&a,
-		validation.Field(&a.Document, validation.Required),
+		validation.Field(&a.Document, validation.Required, DocumentRule),
+		validation.Field(&a.Currency, CurrencyRule),
+	)
+}
+
+func (a AccountUpdateRequest) Validate() error {
+	status := []validation.Rule{validation.In("active", "blocked", "closed")}
+	if a.Limit == nil {
+		status = append([]validation.Rule{validation.Required.Error("status or limit is required")}, status...)
+	}
+
+	return validation.ValidateStruct(
+		&a,
+		validation.Field(&a.Limit, validation.Min(int64(0))),
+		validation.Field(&a.Status, status...),
+		validation.Field(&a.Reason, validation.Length(0, 255)),
 	)
 }
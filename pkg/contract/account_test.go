@@ -14,6 +14,51 @@ func TestAccount_Validate(t *testing.T) {
 			input:    AccountRequest{},
 			expected: "document_number: cannot be blank.",
 		},
+		{
+			input:    AccountRequest{Document: "56077053075"},
+			expected: "document_number: must be a valid CPF or CNPJ.",
+		},
+		{
+			input:    AccountRequest{Document: "1122233300018"},
+			expected: "document_number: must be a valid CPF or CNPJ.",
+		},
+		{
+			input:    AccountRequest{Document: "56077053074", Currency: "brl"},
+			expected: "currency: must be an ISO 4217 currency code.",
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.EqualError(t, tt.input.Validate(), tt.expected)
+		})
+	}
+}
+
+func TestAccount_Validate_Document(t *testing.T) {
+	assert.NoError(t, AccountRequest{Document: "560.770.530-74"}.Validate())
+	assert.NoError(t, AccountRequest{Document: "11.222.333/0001-81"}.Validate())
+}
+
+func TestAccountUpdate_Validate(t *testing.T) {
+	negative := int64(-1)
+	cases := []struct {
+		input    AccountUpdateRequest
+		expected string
+	}{
+		{
+			input:    AccountUpdateRequest{},
+			expected: "status: status or limit is required.",
+		},
+		{
+			input:    AccountUpdateRequest{Status: "frozen"},
+			expected: "status: must be a valid value.",
+		},
+		{
+			input:    AccountUpdateRequest{Limit: &negative},
+			expected: "limit: must be no less than 0.",
+		},
 	}
 
 	for _, tt := range cases {
@@ -22,4 +67,8 @@ func TestAccount_Validate(t *testing.T) {
 			assert.EqualError(t, tt.input.Validate(), tt.expected)
 		})
 	}
+
+	zero := int64(0)
+	assert.NoError(t, AccountUpdateRequest{Limit: &zero}.Validate())
+	assert.NoError(t, AccountUpdateRequest{Status: "blocked", Reason: "chargeback investigation"}.Validate())
 }
@@ -0,0 +1,21 @@
+package contract
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	BillingCycleRequest struct {
+		ClosingDay int `json:"closing_day"`
+		DueDays    int `json:"due_days"`
+	}
+)
+
+// Validate limits the closing day to 28 so every month has a closing date.
+func (b BillingCycleRequest) Validate() error {
+	return validation.ValidateStruct(
+		&b,
+		validation.Field(&b.ClosingDay, validation.Required, validation.Min(1), validation.Max(28)),
+		validation.Field(&b.DueDays, validation.Required, validation.Min(1), validation.Max(30)),
+	)
+}
@@ -0,0 +1,36 @@
+package contract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContractBillingCycle_Validate_Error(t *testing.T) {
+	cases := []struct {
+		description string
+		input       BillingCycleRequest
+		expected    string
+	}{
+		{
+			description: "fields required",
+			input:       BillingCycleRequest{},
+			expected:    "closing_day: cannot be blank; due_days: cannot be blank.",
+		},
+		{
+			description: "out of range",
+			input:       BillingCycleRequest{ClosingDay: 31, DueDays: 45},
+			expected:    "closing_day: must be no greater than 28; due_days: must be no greater than 30.",
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			assert.EqualError(t, tt.input.Validate(), tt.expected)
+		})
+	}
+}
+
+func TestContractBillingCycle_Validate(t *testing.T) {
+	assert.NoError(t, BillingCycleRequest{ClosingDay: 10, DueDays: 7}.Validate())
+}
@@ -0,0 +1,18 @@
+package contract
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	CardRequest struct {
+		Type string `json:"type"`
+	}
+)
+
+func (c CardRequest) Validate() error {
+	return validation.ValidateStruct(
+		&c,
+		validation.Field(&c.Type, validation.Required, validation.In("physical", "virtual")),
+	)
+}
@@ -0,0 +1,31 @@
+package contract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContractCard_Validate(t *testing.T) {
+	cases := []struct {
+		input    CardRequest
+		expected string
+	}{
+		{
+			input:    CardRequest{},
+			expected: "type: cannot be blank.",
+		},
+		{
+			input:    CardRequest{Type: "plastic"},
+			expected: "type: must be a valid value.",
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.EqualError(t, tt.input.Validate(), tt.expected)
+		})
+	}
+
+	assert.NoError(t, CardRequest{Type: "virtual"}.Validate())
+}
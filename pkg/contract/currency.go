@@ -0,0 +1,9 @@
+package contract
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"regexp"
+)
+
+// CurrencyRule accepts ISO 4217 alphabetic codes as in "BRL" or "USD".
+var CurrencyRule = validation.Match(regexp.MustCompile("^[A-Z]{3}$")).Error("must be an ISO 4217 currency code")
@@ -0,0 +1,17 @@
+package contract
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/common"
+)
+
+// DocumentRule accepts a CPF or a CNPJ with valid check digits, punctuated or not.
+var DocumentRule = validation.By(func(value interface{}) error {
+	document, _ := value.(string)
+	if document == "" || common.DocumentType(common.NormalizeDocument(document)) != "" {
+		return nil
+	}
+
+	return xerrors.New("must be a valid CPF or CNPJ")
+})
@@ -0,0 +1,68 @@
+package contract
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	// HoldRequest reserves a purchase, checked as one: the card, the spending controls and
+	// the risk assessment see the same fields a TransactionRequest carries.
+	HoldRequest struct {
+		Account         uint   `json:"account_id"`
+		Card            uint   `json:"card_id,omitempty"`
+		Operation       uint   `json:"operation_id"`
+		Amount          int64  `json:"amount"`
+		MerchantID      string `json:"merchant_id,omitempty"`
+		MerchantName    string `json:"merchant_name,omitempty"`
+		MCC             string `json:"mcc,omitempty"`
+		MerchantCity    string `json:"merchant_city,omitempty"`
+		MerchantCountry string `json:"merchant_country,omitempty"`
+		TerminalID      string `json:"terminal_id,omitempty"`
+		EntryMode       string `json:"entry_mode,omitempty"`
+	}
+
+	CaptureRequest struct {
+		Amount int64 `json:"amount"`
+	}
+)
+
+func (h HoldRequest) Validate() error {
+	return validation.ValidateStruct(
+		&h,
+		validation.Field(&h.Account, validation.Required),
+		validation.Field(&h.Operation, validation.Required),
+		validation.Field(&h.Amount, validation.Required, validation.Min(int64(1))),
+		validation.Field(&h.MerchantID, validation.Length(0, 32)),
+		validation.Field(&h.MerchantName, validation.Length(0, 100)),
+		validation.Field(&h.MCC, MCCRule),
+		validation.Field(&h.MerchantCity, validation.Length(0, 64)),
+		validation.Field(&h.MerchantCountry, CountryRule),
+		validation.Field(&h.TerminalID, validation.Length(0, 16)),
+		validation.Field(&h.EntryMode, EntryModeRule),
+	)
+}
+
+// Purchase is the hold as the purchase it reserves, for the checks written for transactions.
+func (h HoldRequest) Purchase() *TransactionRequest {
+	return &TransactionRequest{
+		Account:         h.Account,
+		Card:            h.Card,
+		Operation:       h.Operation,
+		Amount:          h.Amount,
+		MerchantID:      h.MerchantID,
+		MerchantName:    h.MerchantName,
+		MCC:             h.MCC,
+		MerchantCity:    h.MerchantCity,
+		MerchantCountry: h.MerchantCountry,
+		TerminalID:      h.TerminalID,
+		EntryMode:       h.EntryMode,
+	}
+}
+
+// Validate accepts a zero amount, which captures everything still reserved by the hold.
+func (c CaptureRequest) Validate() error {
+	return validation.ValidateStruct(
+		&c,
+		validation.Field(&c.Amount, validation.Min(int64(0))),
+	)
+}
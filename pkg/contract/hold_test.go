@@ -0,0 +1,42 @@
+package contract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContractHold_Validate_Error(t *testing.T) {
+	cases := []struct {
+		description string
+		input       HoldRequest
+		expected    string
+	}{
+		{
+			description: "fields required",
+			input:       HoldRequest{},
+			expected:    "account_id: cannot be blank; amount: cannot be blank; operation_id: cannot be blank.",
+		},
+		{
+			description: "negative amount",
+			input:       HoldRequest{Account: 1, Operation: 1, Amount: -10},
+			expected:    "amount: must be no less than 1.",
+		},
+		{
+			description: "invalid merchant",
+			input:       HoldRequest{Account: 1, Operation: 1, Amount: 10, MCC: "54a1", MerchantCountry: "BRA", EntryMode: "swipe"},
+			expected:    "entry_mode: must be a valid value; mcc: must be a 4 digit merchant category code; merchant_country: must be an ISO 3166-1 alpha-2 country code.",
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			assert.EqualError(t, tt.input.Validate(), tt.expected)
+		})
+	}
+}
+
+func TestContractCapture_Validate_Error(t *testing.T) {
+	assert.NoError(t, CaptureRequest{}.Validate())
+	assert.EqualError(t, CaptureRequest{Amount: -1}.Validate(), "amount: must be no less than 0.")
+}
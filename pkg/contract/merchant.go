@@ -0,0 +1,24 @@
+package contract
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"regexp"
+)
+
+const (
+	EntryModeChip        = "chip"
+	EntryModeContactless = "contactless"
+	EntryModeMagstripe   = "magstripe"
+	EntryModeManual      = "manual"
+	EntryModeEcommerce   = "ecommerce"
+)
+
+var (
+	// MCCRule accepts ISO 18245 merchant category codes as in "5411".
+	MCCRule = validation.Match(regexp.MustCompile("^[0-9]{4}$")).Error("must be a 4 digit merchant category code")
+
+	// CountryRule accepts ISO 3166-1 alpha-2 codes as in "BR" or "US".
+	CountryRule = validation.Match(regexp.MustCompile("^[A-Z]{2}$")).Error("must be an ISO 3166-1 alpha-2 country code")
+
+	EntryModeRule = validation.In(EntryModeChip, EntryModeContactless, EntryModeMagstripe, EntryModeManual, EntryModeEcommerce)
+)
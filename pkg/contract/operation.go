@@ -8,6 +8,7 @@ type (
 	OperationRequest struct {
 		Description string `json:"description"`
 		Debit       string `json:"debit"`
+		Fee         string `json:"fee,omitempty"`
 	}
 )
 
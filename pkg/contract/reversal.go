@@ -0,0 +1,19 @@
+package contract
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	ReversalRequest struct {
+		Amount int64 `json:"amount"`
+	}
+)
+
+// Validate accepts a zero amount, which refunds everything not yet reversed.
+func (r ReversalRequest) Validate() error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(&r.Amount, validation.Min(int64(0))),
+	)
+}
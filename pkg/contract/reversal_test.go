@@ -0,0 +1,11 @@
+package contract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContractReversal_Validate_Error(t *testing.T) {
+	assert.NoError(t, ReversalRequest{}.Validate())
+	assert.EqualError(t, ReversalRequest{Amount: -1}.Validate(), "amount: must be no less than 0.")
+}
@@ -0,0 +1,27 @@
+package contract
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	SpendingControlRequest struct {
+		MaxAmount          int64    `json:"max_amount"`
+		DailyLimit         int64    `json:"daily_limit"`
+		MonthlyLimit       int64    `json:"monthly_limit"`
+		BlockedMCCs        []string `json:"blocked_mccs"`
+		BlockEcommerce     bool     `json:"block_ecommerce"`
+		BlockInternational bool     `json:"block_international"`
+	}
+)
+
+// Validate accepts zero amounts, they mean no cap.
+func (s SpendingControlRequest) Validate() error {
+	return validation.ValidateStruct(
+		&s,
+		validation.Field(&s.MaxAmount, validation.Min(int64(0))),
+		validation.Field(&s.DailyLimit, validation.Min(int64(0))),
+		validation.Field(&s.MonthlyLimit, validation.Min(int64(0))),
+		validation.Field(&s.BlockedMCCs, validation.Length(0, 200), validation.Each(MCCRule)),
+	)
+}
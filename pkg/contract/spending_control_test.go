@@ -0,0 +1,34 @@
+package contract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContractSpendingControl_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		input       SpendingControlRequest
+		expected    string
+	}{
+		{
+			description: "negative caps",
+			input:       SpendingControlRequest{MaxAmount: -1, DailyLimit: -1, MonthlyLimit: -1},
+			expected:    "daily_limit: must be no less than 0; max_amount: must be no less than 0; monthly_limit: must be no less than 0.",
+		},
+		{
+			description: "invalid mcc",
+			input:       SpendingControlRequest{BlockedMCCs: []string{"7995", "79"}},
+			expected:    "blocked_mccs: (1: must be a 4 digit merchant category code.).",
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			assert.EqualError(t, tt.input.Validate(), tt.expected)
+		})
+	}
+
+	assert.NoError(t, SpendingControlRequest{DailyLimit: 10000, BlockedMCCs: []string{"7995"}}.Validate())
+}
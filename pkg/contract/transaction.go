@@ -1,14 +1,32 @@
 package contract
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
+const (
+	InstallmentsMax = 24
+)
+
 type (
 	TransactionRequest struct {
-		Account   uint  `json:"account_id"`
-		Operation uint  `json:"operation_id"`
-		Amount    int64 `json:"amount"`
+		Account         uint   `json:"account_id"`
+		Card            uint   `json:"card_id,omitempty"`
+		Operation       uint   `json:"operation_id"`
+		Amount          int64  `json:"amount"`
+		Installments    uint   `json:"installments,omitempty"`
+		Currency        string `json:"currency,omitempty"`
+		MerchantID      string `json:"merchant_id,omitempty"`
+		MerchantName    string `json:"merchant_name,omitempty"`
+		MCC             string `json:"mcc,omitempty"`
+		MerchantCity    string `json:"merchant_city,omitempty"`
+		MerchantCountry string `json:"merchant_country,omitempty"`
+		TerminalID      string `json:"terminal_id,omitempty"`
+		EntryMode       string `json:"entry_mode,omitempty"`
+		IdempotencyKey  string `json:"idempotency_key,omitempty"`
 	}
 )
 
@@ -18,5 +36,23 @@ func (t TransactionRequest) Validate() error {
 		validation.Field(&t.Account, validation.Required),
 		validation.Field(&t.Operation, validation.Required),
 		validation.Field(&t.Amount, validation.Required),
+		validation.Field(&t.Installments, validation.Max(uint(InstallmentsMax))),
+		validation.Field(&t.Currency, CurrencyRule),
+		validation.Field(&t.MerchantID, validation.Length(0, 32)),
+		validation.Field(&t.MerchantName, validation.Length(0, 100)),
+		validation.Field(&t.MCC, MCCRule),
+		validation.Field(&t.MerchantCity, validation.Length(0, 64)),
+		validation.Field(&t.MerchantCountry, CountryRule),
+		validation.Field(&t.TerminalID, validation.Length(0, 16)),
+		validation.Field(&t.EntryMode, EntryModeRule),
+		validation.Field(&t.IdempotencyKey, validation.Length(0, 255)),
 	)
 }
+
+// Hash fingerprints the payload, a retry reusing an idempotency key must produce the same hash.
+func (t TransactionRequest) Hash() string {
+	t.IdempotencyKey = ""
+	payload, _ := json.Marshal(t)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
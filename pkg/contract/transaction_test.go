@@ -2,6 +2,7 @@ package contract
 
 import (
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -16,6 +17,31 @@ func TestContractTransaction_Validate_Error(t *testing.T) {
 			input:       TransactionRequest{},
 			expected:    "account_id: cannot be blank; amount: cannot be blank; operation_id: cannot be blank.",
 		},
+		{
+			description: "idempotency key too long",
+			input:       TransactionRequest{Account: 1, Operation: 1, Amount: 100, IdempotencyKey: strings.Repeat("k", 256)},
+			expected:    "idempotency_key: the length must be no more than 255.",
+		},
+		{
+			description: "too many installments",
+			input:       TransactionRequest{Account: 1, Operation: 1, Amount: 100, Installments: 25},
+			expected:    "installments: must be no greater than 24.",
+		},
+		{
+			description: "invalid currency",
+			input:       TransactionRequest{Account: 1, Operation: 1, Amount: 100, Currency: "US"},
+			expected:    "currency: must be an ISO 4217 currency code.",
+		},
+		{
+			description: "invalid merchant context",
+			input:       TransactionRequest{Account: 1, Operation: 1, Amount: 100, MCC: "54", MerchantCountry: "BRA", EntryMode: "swipe"},
+			expected:    "entry_mode: must be a valid value; mcc: must be a 4 digit merchant category code; merchant_country: must be an ISO 3166-1 alpha-2 country code.",
+		},
+		{
+			description: "merchant fields too long",
+			input:       TransactionRequest{Account: 1, Operation: 1, Amount: 100, MerchantID: strings.Repeat("m", 33), MerchantName: strings.Repeat("n", 101), MerchantCity: strings.Repeat("c", 65), TerminalID: strings.Repeat("t", 17)},
+			expected:    "merchant_city: the length must be no more than 64; merchant_id: the length must be no more than 32; merchant_name: the length must be no more than 100; terminal_id: the length must be no more than 16.",
+		},
 	}
 
 	for _, tt := range cases {
@@ -25,3 +51,12 @@ func TestContractTransaction_Validate_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestContractTransaction_Hash(t *testing.T) {
+	request := TransactionRequest{Account: 1, Operation: 1, Amount: 100, IdempotencyKey: "a"}
+	retry := TransactionRequest{Account: 1, Operation: 1, Amount: 100, IdempotencyKey: "b"}
+	changed := TransactionRequest{Account: 1, Operation: 1, Amount: 200, IdempotencyKey: "a"}
+
+	assert.Equal(t, request.Hash(), retry.Hash())
+	assert.NotEqual(t, request.Hash(), changed.Hash())
+}
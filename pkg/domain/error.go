@@ -0,0 +1,61 @@
+package domain
+
+// Kind classifies a domain error, the transport decides how each kind is answered.
+type Kind int
+
+const (
+	KindInvalid Kind = iota
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindUnprocessable
+	KindInternal
+)
+
+// Error is a failure with a stable machine readable Code. Sentinels are declared once with
+// the constructors below and compared with xerrors.Is, so they can be wrapped freely.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Invalid is a request that can't be served as it is.
+func Invalid(code string, message string) *Error {
+	return &Error{Kind: KindInvalid, Code: code, Message: message}
+}
+
+// Unauthorized is a request without valid credentials.
+func Unauthorized(code string, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+// Forbidden is an authenticated request the client is not allowed to make.
+func Forbidden(code string, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+// NotFound is a missing resource the request refers to.
+func NotFound(code string, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+// Conflict is a request clashing with the current state, a duplicate for instance.
+func Conflict(code string, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+// Unprocessable is a well formed request refused by a business rule.
+func Unprocessable(code string, message string) *Error {
+	return &Error{Kind: KindUnprocessable, Code: code, Message: message}
+}
+
+// Internal is a failure of the service itself, usually the database.
+func Internal(code string, message string) *Error {
+	return &Error{Kind: KindInternal, Code: code, Message: message}
+}
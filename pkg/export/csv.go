@@ -0,0 +1,85 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"ms/card/pkg/persistence/entity"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{
+	"id",
+	"created_at",
+	"account_id",
+	"operation_id",
+	"operation",
+	"amount",
+	"currency",
+	"original_amount",
+	"original_currency",
+	"card_id",
+	"merchant_name",
+	"mcc",
+	"merchant_city",
+	"merchant_country",
+}
+
+// csvWriter writes a header line and one line per transaction, flushed as it goes.
+type csvWriter struct {
+	w         *csv.Writer
+	statement Statement
+	started   bool
+}
+
+func newCSV(w io.Writer, statement Statement) Writer {
+	return &csvWriter{w: csv.NewWriter(w), statement: statement}
+}
+
+func (c *csvWriter) Write(row *entity.TransactionExport) error {
+	c.header()
+
+	r := newRecord(row, c.statement)
+	_ = c.w.Write([]string{
+		strconv.FormatUint(uint64(r.ID), 10),
+		r.CreatedAt,
+		strconv.FormatUint(uint64(r.Account), 10),
+		strconv.FormatUint(uint64(r.Operation), 10),
+		text(r.Description),
+		r.Amount,
+		r.Currency,
+		r.OriginalAmount,
+		r.OriginalCurrency,
+		r.Card,
+		text(r.MerchantName),
+		r.MCC,
+		text(r.MerchantCity),
+		r.MerchantCountry,
+	})
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.header()
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) header() {
+	if !c.started {
+		c.started = true
+		_ = c.w.Write(csvHeader)
+	}
+}
+
+// text keeps spreadsheets from reading a free text cell, such as a merchant name sent by the
+// network, as a formula.
+func text(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	var b bytes.Buffer
+	w := newCSV(&b, statement())
+	for _, row := range rows() {
+		assert.NoError(t, w.Write(row))
+	}
+
+	assert.NoError(t, w.Close())
+	assert.Equal(t, `id,created_at,account_id,operation_id,operation,amount,currency,original_amount,original_currency,card_id,merchant_name,mcc,merchant_city,merchant_country
+1,2022-03-12T04:02:03Z,7,1,COMPRA A VISTA,-123.45,BRL,,,3,'=SUPER MARKET,5411,SAO PAULO,BR
+2,2022-03-20T15:00:00Z,7,1,COMPRA A VISTA,-55.10,BRL,-10.00,USD,,BOOKS & CO,,,
+3,2022-03-25T00:00:00Z,7,4,PAGAMENTO,50.00,BRL,,,,,,,
+`, b.String())
+}
+
+func TestCSV_Empty(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, newCSV(&b, statement()).Close())
+	assert.Equal(t, "id,created_at,account_id,operation_id,operation,amount,currency,original_amount,original_currency,card_id,merchant_name,mcc,merchant_city,merchant_country\n", b.String())
+}
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"golang.org/x/xerrors"
+	"io"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatOFX    = "ofx"
+	FormatNDJSON = "ndjson"
+)
+
+var (
+	ErrFormatUnknown = xerrors.New("unknown export format")
+
+	formats = map[string]*Format{
+		FormatCSV:    {Name: FormatCSV, ContentType: "text/csv; charset=utf-8", writer: newCSV},
+		FormatOFX:    {Name: FormatOFX, ContentType: "application/x-ofx", Period: true, writer: newOFX},
+		FormatNDJSON: {Name: FormatNDJSON, ContentType: "application/x-ndjson", writer: newNDJSON},
+	}
+)
+
+type (
+	// Writer writes the exported transactions as they are read, nothing is kept between rows.
+	// Close finishes the document, it must be called even when no row was written.
+	Writer interface {
+		Write(row *entity.TransactionExport) error
+		Close() error
+	}
+
+	// Statement is what an export is about: the account, the period asked for, either bound
+	// may be zero, and when the export was generated.
+	Statement struct {
+		Account     *entity.Account
+		From        time.Time
+		To          time.Time
+		GeneratedAt time.Time
+	}
+
+	// Format is an export file type. Period formats state the period of the statement before
+	// its first row, the rows come in the order asked for so they need both from and to.
+	Format struct {
+		Name        string
+		ContentType string
+		Period      bool
+		writer      func(w io.Writer, statement Statement) Writer
+	}
+
+	// record is a transaction as the CSV and NDJSON exports list it, amounts are formatted in
+	// the account currency and the original ones in the currency of the purchase.
+	record struct {
+		ID               uint   `json:"id"`
+		CreatedAt        string `json:"created_at"`
+		Account          uint   `json:"account_id"`
+		Operation        uint   `json:"operation_id"`
+		Description      string `json:"operation"`
+		Amount           string `json:"amount"`
+		Currency         string `json:"currency"`
+		OriginalAmount   string `json:"original_amount,omitempty"`
+		OriginalCurrency string `json:"original_currency,omitempty"`
+		Card             string `json:"card_id,omitempty"`
+		MerchantName     string `json:"merchant_name,omitempty"`
+		MCC              string `json:"mcc,omitempty"`
+		MerchantCity     string `json:"merchant_city,omitempty"`
+		MerchantCountry  string `json:"merchant_country,omitempty"`
+	}
+)
+
+// Lookup finds a format by name, ErrFormatUnknown when there is none.
+func Lookup(name string) (*Format, error) {
+	format, ok := formats[name]
+	if !ok {
+		return nil, xerrors.Errorf("%s: %w", name, ErrFormatUnknown)
+	}
+
+	return format, nil
+}
+
+// Names lists the formats, sorted.
+func Names() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (f *Format) New(w io.Writer, statement Statement) Writer {
+	return f.writer(w, statement)
+}
+
+// Filename is the name the export is downloaded as.
+func (f *Format) Filename(statement Statement) string {
+	return "account-" + strconv.FormatUint(uint64(statement.Account.ID), 10) + "-transactions." + f.Name
+}
+
+func newRecord(row *entity.TransactionExport, statement Statement) record {
+	r := record{
+		ID:              row.ID,
+		CreatedAt:       row.CreatedAt.UTC().Format(time.RFC3339),
+		Account:         row.Account,
+		Operation:       row.Type,
+		Description:     row.Operation,
+		Amount:          common.FormatAmount(row.Amount),
+		Currency:        statement.Account.Currency,
+		MerchantName:    row.MerchantName,
+		MCC:             row.MCC,
+		MerchantCity:    row.MerchantCity,
+		MerchantCountry: row.MerchantCountry,
+	}
+
+	if row.Currency != "" {
+		r.OriginalAmount = common.FormatAmount(row.OriginalAmount)
+		r.OriginalCurrency = row.Currency
+	}
+
+	if row.Card != nil {
+		r.Card = strconv.FormatUint(uint64(*row.Card), 10)
+	}
+
+	return r
+}
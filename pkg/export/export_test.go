@@ -0,0 +1,66 @@
+package export
+
+import (
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/persistence/entity"
+	"testing"
+	"time"
+)
+
+func statement() Statement {
+	return Statement{
+		Account:     &entity.Account{ID: 7, CreditLimit: 100000, AvailableLimit: 87655, Currency: "BRL"},
+		GeneratedAt: time.Date(2022, time.March, 31, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func rows() []*entity.TransactionExport {
+	card := uint(3)
+	return []*entity.TransactionExport{
+		{
+			Transaction: entity.Transaction{
+				ID:        1,
+				Account:   7,
+				Type:      1,
+				Amount:    -12345,
+				Card:      &card,
+				CreatedAt: time.Date(2022, time.March, 12, 1, 2, 3, 0, time.FixedZone("BRT", -3*60*60)),
+				Merchant:  entity.Merchant{MerchantName: "=SUPER MARKET", MCC: "5411", MerchantCity: "SAO PAULO", MerchantCountry: "BR"},
+			},
+			Operation: "COMPRA A VISTA",
+		},
+		{
+			Transaction: entity.Transaction{
+				ID:             2,
+				Account:        7,
+				Type:           1,
+				Amount:         -5510,
+				Currency:       "USD",
+				OriginalAmount: -1000,
+				CreatedAt:      time.Date(2022, time.March, 20, 15, 0, 0, 0, time.UTC),
+				Merchant:       entity.Merchant{MerchantName: "BOOKS & CO"},
+			},
+			Operation: "COMPRA A VISTA",
+		},
+		{
+			Transaction: entity.Transaction{ID: 3, Account: 7, Type: 4, Amount: 5000, CreatedAt: time.Date(2022, time.March, 25, 0, 0, 0, 0, time.UTC)},
+			Operation:   "PAGAMENTO",
+		},
+	}
+}
+
+func TestLookup(t *testing.T) {
+	format, err := Lookup(FormatCSV)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "text/csv; charset=utf-8", format.ContentType)
+		assert.Equal(t, "account-7-transactions.csv", format.Filename(statement()))
+	}
+
+	_, err = Lookup("xlsx")
+	assert.True(t, xerrors.Is(err, ErrFormatUnknown))
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, []string{FormatCSV, FormatNDJSON, FormatOFX}, Names())
+}
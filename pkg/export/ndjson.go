@@ -0,0 +1,25 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"ms/card/pkg/persistence/entity"
+)
+
+// ndjsonWriter writes one JSON object per line, a line per transaction.
+type ndjsonWriter struct {
+	encoder   *json.Encoder
+	statement Statement
+}
+
+func newNDJSON(w io.Writer, statement Statement) Writer {
+	return &ndjsonWriter{encoder: json.NewEncoder(w), statement: statement}
+}
+
+func (n *ndjsonWriter) Write(row *entity.TransactionExport) error {
+	return n.encoder.Encode(newRecord(row, n.statement))
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,24 @@
+package export
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNDJSON(t *testing.T) {
+	var b bytes.Buffer
+	w := newNDJSON(&b, statement())
+	for _, row := range rows() {
+		assert.NoError(t, w.Write(row))
+	}
+
+	assert.NoError(t, w.Close())
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.JSONEq(t, `{"id":1,"created_at":"2022-03-12T04:02:03Z","account_id":7,"operation_id":1,"operation":"COMPRA A VISTA","amount":"-123.45","currency":"BRL","card_id":"3","merchant_name":"=SUPER MARKET","mcc":"5411","merchant_city":"SAO PAULO","merchant_country":"BR"}`, lines[0])
+		assert.JSONEq(t, `{"id":2,"created_at":"2022-03-20T15:00:00Z","account_id":7,"operation_id":1,"operation":"COMPRA A VISTA","amount":"-55.10","currency":"BRL","original_amount":"-10.00","original_currency":"USD","merchant_name":"BOOKS & CO"}`, lines[1])
+		assert.JSONEq(t, `{"id":3,"created_at":"2022-03-25T00:00:00Z","account_id":7,"operation_id":4,"operation":"PAGAMENTO","amount":"50.00","currency":"BRL"}`, lines[2])
+	}
+}
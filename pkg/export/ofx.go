@@ -0,0 +1,132 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+	// ofxNameLength is the longest NAME the specification accepts.
+	ofxNameLength = 32
+)
+
+// ofxWriter writes an OFX 2.2 credit card statement. The transaction list opens with its
+// period, from and to, which the rows can't tell in any sort but by date: the format is a
+// Period one.
+type ofxWriter struct {
+	w         io.Writer
+	statement Statement
+	started   bool
+}
+
+func newOFX(w io.Writer, statement Statement) Writer {
+	return &ofxWriter{w: w, statement: statement}
+}
+
+func (o *ofxWriter) Write(row *entity.TransactionExport) error {
+	if err := o.start(); err != nil {
+		return err
+	}
+
+	kind := "CREDIT"
+	if row.Amount < 0 {
+		kind = "DEBIT"
+	}
+
+	name := row.MerchantName
+	if name == "" {
+		name = row.Operation
+	}
+
+	var b strings.Builder
+	b.WriteString("<STMTTRN>\n")
+	element(&b, "TRNTYPE", kind)
+	element(&b, "DTPOSTED", ofxTime(row.CreatedAt))
+	element(&b, "TRNAMT", common.FormatAmount(row.Amount))
+	element(&b, "FITID", strconv.FormatUint(uint64(row.ID), 10))
+	element(&b, "NAME", truncate(name, ofxNameLength))
+	element(&b, "MEMO", row.Operation)
+	b.WriteString("</STMTTRN>\n")
+
+	_, err := io.WriteString(o.w, b.String())
+	return err
+}
+
+func (o *ofxWriter) Close() error {
+	if err := o.start(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("</BANKTRANLIST>\n<LEDGERBAL>\n")
+	element(&b, "BALAMT", common.FormatAmount(-o.statement.Account.Used()))
+	element(&b, "DTASOF", ofxTime(o.statement.GeneratedAt))
+	b.WriteString("</LEDGERBAL>\n</CCSTMTRS>\n</CCSTMTTRNRS>\n</CREDITCARDMSGSRSV1>\n</OFX>\n")
+
+	_, err := io.WriteString(o.w, b.String())
+	return err
+}
+
+// start writes everything up to the transaction list, once.
+func (o *ofxWriter) start() error {
+	if o.started {
+		return nil
+	}
+
+	o.started = true
+	var b strings.Builder
+	b.WriteString(ofxHeader)
+	b.WriteString("<OFX>\n<SIGNONMSGSRSV1>\n<SONRS>\n")
+	status(&b)
+	element(&b, "DTSERVER", ofxTime(o.statement.GeneratedAt))
+	element(&b, "LANGUAGE", "POR")
+	b.WriteString("</SONRS>\n</SIGNONMSGSRSV1>\n<CREDITCARDMSGSRSV1>\n<CCSTMTTRNRS>\n")
+	element(&b, "TRNUID", "0")
+	status(&b)
+	b.WriteString("<CCSTMTRS>\n")
+	element(&b, "CURDEF", o.statement.Account.Currency)
+	b.WriteString("<CCACCTFROM>\n")
+	element(&b, "ACCTID", strconv.FormatUint(uint64(o.statement.Account.ID), 10))
+	b.WriteString("</CCACCTFROM>\n<BANKTRANLIST>\n")
+	element(&b, "DTSTART", ofxTime(o.statement.From))
+	element(&b, "DTEND", ofxTime(o.statement.To))
+
+	_, err := io.WriteString(o.w, b.String())
+	return err
+}
+
+func status(b *strings.Builder) {
+	b.WriteString("<STATUS>\n")
+	element(b, "CODE", "0")
+	element(b, "SEVERITY", "INFO")
+	b.WriteString("</STATUS>\n")
+}
+
+func element(b *strings.Builder, name string, value string) {
+	b.WriteString("<" + name + ">")
+	_ = xml.EscapeText(b, []byte(value))
+	b.WriteString("</" + name + ">\n")
+}
+
+// ofxTime writes the time in UTC the way OFX dates are, 20220312040203.000[0:GMT].
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+
+	return string(runes[:length])
+}
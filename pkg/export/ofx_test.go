@@ -0,0 +1,71 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func period() Statement {
+	s := statement()
+	s.From = time.Date(2022, time.March, 1, 3, 0, 0, 0, time.UTC)
+	s.To = time.Date(2022, time.April, 1, 3, 0, 0, 0, time.UTC)
+	return s
+}
+
+func TestOFX(t *testing.T) {
+	var b bytes.Buffer
+	w := newOFX(&b, period())
+	for _, row := range rows() {
+		assert.NoError(t, w.Write(row))
+	}
+
+	assert.NoError(t, w.Close())
+	document := b.String()
+	assert.True(t, strings.HasPrefix(document, ofxHeader+"<OFX>\n"))
+	assert.Contains(t, document, "<CURDEF>BRL</CURDEF>\n<CCACCTFROM>\n<ACCTID>7</ACCTID>\n</CCACCTFROM>")
+	assert.Contains(t, document, "<DTSTART>20220301030000.000[0:GMT]</DTSTART>\n<DTEND>20220401030000.000[0:GMT]</DTEND>")
+	assert.Contains(t, document, `<STMTTRN>
+<TRNTYPE>DEBIT</TRNTYPE>
+<DTPOSTED>20220320150000.000[0:GMT]</DTPOSTED>
+<TRNAMT>-55.10</TRNAMT>
+<FITID>2</FITID>
+<NAME>BOOKS &amp; CO</NAME>
+<MEMO>COMPRA A VISTA</MEMO>
+</STMTTRN>`)
+	assert.Contains(t, document, "<TRNTYPE>CREDIT</TRNTYPE>")
+	assert.Contains(t, document, "<NAME>PAGAMENTO</NAME>")
+	assert.Contains(t, document, "<LEDGERBAL>\n<BALAMT>-123.45</BALAMT>")
+	assert.Equal(t, 3, strings.Count(document, "<STMTTRN>"))
+	assertWellFormed(t, document)
+}
+
+func TestOFX_Empty(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, newOFX(&b, period()).Close())
+	assert.Contains(t, b.String(), "<DTSTART>20220301030000.000[0:GMT]</DTSTART>\n<DTEND>20220401030000.000[0:GMT]</DTEND>\n</BANKTRANLIST>")
+	assertWellFormed(t, b.String())
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "PADARIA", truncate("PADARIA", 32))
+	assert.Equal(t, "AÇÚ", truncate("AÇÚCAR", 3))
+}
+
+func assertWellFormed(t *testing.T, document string) {
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+}
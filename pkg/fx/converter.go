@@ -0,0 +1,115 @@
+package fx
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"math/big"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+const (
+	RatePrecision = 8
+)
+
+var (
+	ErrPercentInvalid = xerrors.New("invalid percentage")
+)
+
+type (
+	Converters interface {
+		Convert(ctx context.Context, amount int64, from string, to string, debit bool) (*Conversion, error)
+	}
+
+	// ConverterOpts holds the markup charged over the provider rate and the tax charged over
+	// the converted amount, both as fractions, 0.04 is 4%. Both are only charged on debits.
+	ConverterOpts struct {
+		Provider Provider
+		Markup   *big.Rat
+		Tax      *big.Rat
+	}
+
+	Converter struct {
+		ConverterOpts
+	}
+
+	// Conversion is the outcome of converting an amount in cents. Amount is what is billed,
+	// markup and tax included, Rate is the effective rate with the markup applied.
+	Conversion struct {
+		Amount   int64
+		Original int64
+		Rate     string
+		Tax      int64
+	}
+)
+
+func NewConverter(opts ConverterOpts) *Converter {
+	if opts.Markup == nil {
+		opts.Markup = new(big.Rat)
+	}
+
+	if opts.Tax == nil {
+		opts.Tax = new(big.Rat)
+	}
+
+	return &Converter{opts}
+}
+
+// Percent parses a percentage as in "6.38" into a fraction, an empty string is zero.
+func Percent(value string) (*big.Rat, error) {
+	if value == "" {
+		return new(big.Rat), nil
+	}
+
+	percent, ok := new(big.Rat).SetString(value)
+	if !ok || percent.Sign() < 0 {
+		return nil, xerrors.Errorf("%s: %w", value, ErrPercentInvalid)
+	}
+
+	return percent.Quo(percent, big.NewRat(100, 1)), nil
+}
+
+// Convert converts the amount from one currency to the other. Each step is computed exactly
+// and only the billed values are rounded, half away from zero, to cents. Credits, such as
+// refunds from a merchant abroad, are converted at the provider rate, without markup or tax.
+func (c *Converter) Convert(ctx context.Context, amount int64, from string, to string, debit bool) (*Conversion, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if from == to {
+		return &Conversion{Amount: amount, Original: amount}, nil
+	}
+
+	rate, err := c.Provider.Rate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	markup, percent := c.Markup, c.Tax
+	if !debit {
+		markup, percent = new(big.Rat), new(big.Rat)
+	}
+
+	rate.Mul(rate, new(big.Rat).Add(big.NewRat(1, 1), markup))
+	converted := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), rate)
+	value := round(converted)
+	tax := round(converted.Mul(converted, percent))
+
+	return &Conversion{
+		Amount:   value + tax,
+		Original: amount,
+		Rate:     rate.FloatString(RatePrecision),
+		Tax:      tax,
+	}, nil
+}
+
+func round(value *big.Rat) int64 {
+	numerator := new(big.Int).Mul(value.Num(), big.NewInt(2))
+	if value.Sign() < 0 {
+		numerator.Sub(numerator, value.Denom())
+	} else {
+		numerator.Add(numerator, value.Denom())
+	}
+
+	denominator := new(big.Int).Mul(value.Denom(), big.NewInt(2))
+	return numerator.Quo(numerator, denominator).Int64()
+}
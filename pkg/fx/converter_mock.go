@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/fx/converter.go
+
+// Package fx is a generated GoMock package.
+package fx
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockConverters is a mock of Converters interface.
+type MockConverters struct {
+	ctrl     *gomock.Controller
+	recorder *MockConvertersMockRecorder
+}
+
+// MockConvertersMockRecorder is the mock recorder for MockConverters.
+type MockConvertersMockRecorder struct {
+	mock *MockConverters
+}
+
+// NewMockConverters creates a new mock instance.
+func NewMockConverters(ctrl *gomock.Controller) *MockConverters {
+	mock := &MockConverters{ctrl: ctrl}
+	mock.recorder = &MockConvertersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConverters) EXPECT() *MockConvertersMockRecorder {
+	return m.recorder
+}
+
+// Convert mocks base method.
+func (m *MockConverters) Convert(ctx context.Context, amount int64, from, to string, debit bool) (*Conversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", ctx, amount, from, to, debit)
+	ret0, _ := ret[0].(*Conversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockConvertersMockRecorder) Convert(ctx, amount, from, to, debit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockConverters)(nil).Convert), ctx, amount, from, to, debit)
+}
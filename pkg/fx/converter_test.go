@@ -0,0 +1,78 @@
+package fx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"math/big"
+	"testing"
+)
+
+func TestConverter_Convert(t *testing.T) {
+	memory, err := NewMemory(map[string]string{"USD/BRL": "5.1234"})
+	assert.NoError(t, err)
+
+	markup, err := Percent("4")
+	assert.NoError(t, err)
+
+	tax, err := Percent("6.38")
+	assert.NoError(t, err)
+
+	converter := NewConverter(ConverterOpts{Provider: memory, Markup: markup, Tax: tax})
+
+	// 10.00 USD * 5.1234 * 1.04 = 53.28336 BRL, tax 6.38% = 3.39948 BRL
+	conversion, err := converter.Convert(context.Background(), 1000, "USD", "BRL", true)
+	assert.NoError(t, err)
+	assert.Equal(t, &Conversion{Amount: 5668, Original: 1000, Rate: "5.32833600", Tax: 340}, conversion)
+}
+
+func TestConverter_Convert_Credit(t *testing.T) {
+	memory, err := NewMemory(map[string]string{"USD/BRL": "5.1234"})
+	assert.NoError(t, err)
+
+	markup, err := Percent("4")
+	assert.NoError(t, err)
+
+	tax, err := Percent("6.38")
+	assert.NoError(t, err)
+
+	converter := NewConverter(ConverterOpts{Provider: memory, Markup: markup, Tax: tax})
+
+	// 10.00 USD * 5.1234 = 51.234 BRL, neither markup nor tax on a credit
+	conversion, err := converter.Convert(context.Background(), 1000, "USD", "BRL", false)
+	assert.NoError(t, err)
+	assert.Equal(t, &Conversion{Amount: 5123, Original: 1000, Rate: "5.12340000"}, conversion)
+}
+
+func TestConverter_Convert_SameCurrency(t *testing.T) {
+	converter := NewConverter(ConverterOpts{Provider: &Memory{}})
+
+	conversion, err := converter.Convert(context.Background(), 1000, "BRL", "BRL", true)
+	assert.NoError(t, err)
+	assert.Equal(t, &Conversion{Amount: 1000, Original: 1000}, conversion)
+}
+
+func TestConverter_Convert_RateNotFound(t *testing.T) {
+	converter := NewConverter(ConverterOpts{Provider: &Memory{}})
+
+	_, err := converter.Convert(context.Background(), 1000, "USD", "BRL", true)
+	assert.ErrorIs(t, err, ErrRateNotFound)
+}
+
+func TestPercent(t *testing.T) {
+	percent, err := Percent("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, percent.Sign())
+
+	percent, err = Percent("6.38")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(638, 10000), percent)
+
+	_, err = Percent("abc")
+	assert.ErrorIs(t, err, ErrPercentInvalid)
+}
+
+func TestRound(t *testing.T) {
+	assert.Equal(t, int64(3), round(big.NewRat(5, 2)))
+	assert.Equal(t, int64(-3), round(big.NewRat(-5, 2)))
+	assert.Equal(t, int64(2), round(big.NewRat(24, 10)))
+}
@@ -0,0 +1,74 @@
+package fx
+
+import (
+	"encoding/json"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"math/big"
+	"ms/card/pkg/domain"
+	"os"
+	"strings"
+)
+
+var (
+	ErrRateNotFound = domain.Unprocessable("exchange_rate_not_found", "exchange rate not found")
+	ErrRateInvalid  = xerrors.New("invalid exchange rate")
+)
+
+type (
+	// Provider quotes the rate that converts one unit of the currency from into the currency to.
+	Provider interface {
+		Rate(ctx context.Context, from string, to string) (*big.Rat, error)
+	}
+
+	// Memory is a static Provider, the rates are keyed by pair as in "USD/BRL".
+	Memory struct {
+		rates map[string]*big.Rat
+	}
+)
+
+func NewMemory(rates map[string]string) (*Memory, error) {
+	memory := &Memory{rates: make(map[string]*big.Rat, len(rates))}
+	for pair, value := range rates {
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 || !strings.Contains(pair, "/") {
+			return nil, xerrors.Errorf("%s %s: %w", pair, value, ErrRateInvalid)
+		}
+
+		memory.rates[strings.ToUpper(pair)] = rate
+	}
+
+	return memory, nil
+}
+
+// NewFile loads a Memory provider from a json object of pair and rate, as in {"USD/BRL": "5.0123"}.
+func NewFile(path string) (*Memory, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]string)
+	if err := json.Unmarshal(content, &rates); err != nil {
+		return nil, err
+	}
+
+	return NewMemory(rates)
+}
+
+// Rate looks the pair up, falling back to the inverse of the opposite pair.
+func (m *Memory) Rate(_ context.Context, from string, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	if rate, ok := m.rates[from+"/"+to]; ok {
+		return new(big.Rat).Set(rate), nil
+	}
+
+	if rate, ok := m.rates[to+"/"+from]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+
+	return nil, xerrors.Errorf("%s/%s: %w", from, to, ErrRateNotFound)
+}
@@ -0,0 +1,53 @@
+package fx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMemory_Rate(t *testing.T) {
+	memory, err := NewMemory(map[string]string{"usd/brl": "5.25"})
+	assert.NoError(t, err)
+
+	rate, err := memory.Rate(context.Background(), "USD", "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(21, 4), rate)
+
+	rate, err = memory.Rate(context.Background(), "BRL", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(4, 21), rate)
+
+	rate, err = memory.Rate(context.Background(), "BRL", "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 1), rate)
+
+	_, err = memory.Rate(context.Background(), "EUR", "BRL")
+	assert.ErrorIs(t, err, ErrRateNotFound)
+}
+
+func TestNewMemory_Invalid(t *testing.T) {
+	_, err := NewMemory(map[string]string{"USD/BRL": "-1"})
+	assert.ErrorIs(t, err, ErrRateInvalid)
+
+	_, err = NewMemory(map[string]string{"USDBRL": "5"})
+	assert.ErrorIs(t, err, ErrRateInvalid)
+}
+
+func TestNewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"EUR/BRL": "5.5"}`), 0600))
+
+	memory, err := NewFile(path)
+	assert.NoError(t, err)
+
+	rate, err := memory.Rate(context.Background(), "EUR", "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(11, 2), rate)
+
+	_, err = NewFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
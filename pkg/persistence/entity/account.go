@@ -1,15 +1,75 @@
 package entity
 
+import (
+	"encoding/json"
+	"gorm.io/gorm"
+	"ms/card/pkg/persistence"
+)
+
 const (
 	AccountTableName = "account"
+	// AccountCurrencyDefault is the billing currency of accounts created without one.
+	AccountCurrencyDefault = "BRL"
+
+	AccountStatusActive  = "active"
+	AccountStatusBlocked = "blocked"
+	AccountStatusClosed  = "closed"
 )
 
-type Account struct {
-	ID       uint   `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
-	Document string `json:"document_number" gorm:"type:varchar(11);unique;column:document_number"`
-	Limit    int64  `json:"limit" gorm:"type:integer;column:limit"`
+// accountTransitions lists the statuses each status can move to, closed is final.
+var accountTransitions = map[string][]string{
+	AccountStatusActive:  {AccountStatusBlocked, AccountStatusClosed},
+	AccountStatusBlocked: {AccountStatusActive, AccountStatusClosed},
 }
 
+type (
+	// Account holds the credit line granted in CreditLimit and what is left of it in
+	// AvailableLimit, the available limit keeps the original limit column. Document is
+	// stored with digits only, DocumentType tells a CPF from a CNPJ.
+	Account struct {
+		ID             uint           `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Document       string         `json:"document_number" gorm:"type:varchar(14);unique;column:document_number"`
+		DocumentType   string         `json:"document_type" gorm:"type:varchar(4);column:document_type"`
+		CreditLimit    int64          `json:"credit_limit" gorm:"type:integer;column:credit_limit;not null;default:0"`
+		AvailableLimit int64          `json:"available_limit" gorm:"type:integer;column:limit"`
+		Currency       string         `json:"currency" gorm:"type:varchar(3);column:currency;default:BRL"`
+		Status         string         `json:"status" gorm:"type:varchar(10);column:status;default:active"`
+		CreatedBy      string         `json:"created_by,omitempty" gorm:"type:varchar(64);column:created_by"`
+		DeletedAt      gorm.DeletedAt `json:"-" gorm:"type:timestamp without time zone;column:deleted_at;index"`
+	}
+
+	AccountCollection struct {
+		persistence.Pagination
+		Data []*Account `json:"data"`
+	}
+)
+
 func (a *Account) TableName() string {
 	return AccountTableName
 }
+
+// Used is the part of the credit limit consumed and not paid back yet, it is negative when
+// the account holds a credit balance.
+func (a *Account) Used() int64 {
+	return a.CreditLimit - a.AvailableLimit
+}
+
+// MarshalJSON adds the derived used amount next to both limits.
+func (a Account) MarshalJSON() ([]byte, error) {
+	type account Account
+	return json.Marshal(struct {
+		account
+		UsedAmount int64 `json:"used_amount"`
+	}{account(a), a.Used()})
+}
+
+// CanMoveTo reports whether the account may go from its current status to the given one.
+func (a *Account) CanMoveTo(status string) bool {
+	for _, allowed := range accountTransitions[a.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+
+	return false
+}
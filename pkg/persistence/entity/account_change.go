@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	AccountChangeTableName = "account_change"
+
+	AccountChangeLimit   = "limit"
+	AccountChangeStatus  = "status"
+	AccountChangeDeleted = "deleted"
+)
+
+// AccountChange is the audit trail of an account, one row per changed field with its value
+// before and after the change.
+type AccountChange struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	Account   uint      `json:"account_id" gorm:"type:integer;column:account_id;index"`
+	Field     string    `json:"field" gorm:"type:varchar(20);column:field"`
+	From      string    `json:"from" gorm:"type:varchar(64);column:from_value"`
+	To        string    `json:"to" gorm:"type:varchar(64);column:to_value"`
+	Reason    string    `json:"reason,omitempty" gorm:"type:varchar(255);column:reason"`
+	CreatedBy string    `json:"created_by,omitempty" gorm:"type:varchar(64);column:created_by"`
+	CreatedAt time.Time `json:"created_at" gorm:"type:timestamp without time zone;column:created_at"`
+}
+
+func (a *AccountChange) TableName() string {
+	return AccountChangeTableName
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccount_TableName(t *testing.T) {
+	account := Account{}
+	assert.Equal(t, AccountTableName, account.TableName())
+}
+
+func TestAccount_CanMoveTo(t *testing.T) {
+	account := Account{Status: AccountStatusActive}
+	assert.True(t, account.CanMoveTo(AccountStatusBlocked))
+	assert.True(t, account.CanMoveTo(AccountStatusClosed))
+	assert.False(t, account.CanMoveTo(AccountStatusActive))
+
+	account.Status = AccountStatusBlocked
+	assert.True(t, account.CanMoveTo(AccountStatusActive))
+	assert.True(t, account.CanMoveTo(AccountStatusClosed))
+
+	account.Status = AccountStatusClosed
+	assert.False(t, account.CanMoveTo(AccountStatusActive))
+	assert.False(t, account.CanMoveTo(AccountStatusBlocked))
+}
+
+func TestAccount_Used(t *testing.T) {
+	account := Account{CreditLimit: 5000, AvailableLimit: 3200}
+	assert.Equal(t, int64(1800), account.Used())
+
+	account.AvailableLimit = 5100
+	assert.Equal(t, int64(-100), account.Used())
+}
+
+func TestAccount_MarshalJSON(t *testing.T) {
+	body, err := json.Marshal(&Account{ID: 1, Document: "56077053074", DocumentType: "cpf", CreditLimit: 5000, AvailableLimit: 3200, Currency: "BRL", Status: AccountStatusActive})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"document_number":"56077053074","document_type":"cpf","credit_limit":5000,"available_limit":3200,"used_amount":1800,"currency":"BRL","status":"active"}`, string(body))
+}
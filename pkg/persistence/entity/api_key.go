@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	APIKeyTableName = "api_key"
+)
+
+// APIKey authenticates a client. Only the SHA-256 of the key is stored, the key itself is
+// shown once when it is generated.
+type APIKey struct {
+	ID        uint       `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	Client    string     `json:"client_id" gorm:"type:varchar(64);column:client_id;index"`
+	Hash      string     `json:"-" gorm:"type:varchar(64);column:hash;unique"`
+	Scopes    List       `json:"scopes" gorm:"type:varchar(1024);column:scopes"`
+	CreatedAt time.Time  `json:"created_at" gorm:"type:timestamp without time zone;column:created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" gorm:"type:timestamp without time zone;column:revoked_at"`
+}
+
+func (a *APIKey) TableName() string {
+	return APIKeyTableName
+}
+
+func (a *APIKey) Revoked() bool {
+	return a.RevokedAt != nil
+}
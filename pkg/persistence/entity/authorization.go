@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"ms/card/pkg/persistence"
+	"time"
+)
+
+const (
+	AuthorizationTableName = "authorization_attempt"
+
+	AuthorizationOutcomeApproved = "approved"
+	AuthorizationOutcomeDeclined = "declined"
+)
+
+type (
+	// Authorization is an attempt to move the account limit, a transaction or a hold, kept
+	// whatever its outcome. Amount and Currency are the ones requested, declined attempts
+	// carry the reason Code and never a Transaction or Hold.
+	Authorization struct {
+		ID          uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Account     uint      `json:"account_id" gorm:"type:integer;column:account_id;index"`
+		Type        uint      `json:"operation_id" gorm:"type:integer;column:operation_id"`
+		Card        *uint     `json:"card_id,omitempty" gorm:"type:integer;column:card_id"`
+		Transaction *uint     `json:"transaction_id,omitempty" gorm:"type:integer;column:transaction_id"`
+		Hold        *uint     `json:"hold_id,omitempty" gorm:"type:integer;column:hold_id"`
+		Amount      int64     `json:"amount" gorm:"type:integer;column:amount"`
+		Currency    string    `json:"currency,omitempty" gorm:"type:varchar(3);column:currency"`
+		Outcome     string    `json:"outcome" gorm:"type:varchar(10);column:outcome"`
+		Code        string    `json:"code,omitempty" gorm:"type:varchar(32);column:code"`
+		Reason      string    `json:"reason,omitempty" gorm:"type:text;column:reason"`
+		CreatedBy   string    `json:"created_by,omitempty" gorm:"type:varchar(64);column:created_by"`
+		CreatedAt   time.Time `json:"created_at" gorm:"type:timestamptz;column:created_at"`
+
+		Merchant
+	}
+
+	AuthorizationCollection struct {
+		persistence.Pagination
+		Data []*Authorization `json:"data"`
+	}
+)
+
+func (a *Authorization) TableName() string {
+	return AuthorizationTableName
+}
+
+// BeforeSave and AfterFind keep CreatedAt in UTC, the driver reads timestamptz back in the
+// local time zone.
+func (a *Authorization) BeforeSave(*gorm.DB) error {
+	a.CreatedAt = a.CreatedAt.UTC()
+	return nil
+}
+
+func (a *Authorization) AfterFind(*gorm.DB) error {
+	a.CreatedAt = a.CreatedAt.UTC()
+	return nil
+}
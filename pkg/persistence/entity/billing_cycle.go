@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	BillingCycleTableName = "billing_cycle"
+)
+
+type BillingCycle struct {
+	Account       uint      `json:"account_id" gorm:"primaryKey;autoIncrement:false;column:account_id"`
+	ClosingDay    int       `json:"closing_day" gorm:"type:integer;column:closing_day"`
+	DueDays       int       `json:"due_days" gorm:"type:integer;column:due_days"`
+	NextClosingAt time.Time `json:"next_closing_at" gorm:"type:timestamptz;column:next_closing_at"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"type:timestamptz;column:updated_at"`
+}
+
+func (b *BillingCycle) TableName() string {
+	return BillingCycleTableName
+}
+
+// BeforeSave and AfterFind keep the times in UTC, see utc.
+func (b *BillingCycle) BeforeSave(*gorm.DB) error {
+	utc(&b.NextClosingAt, &b.UpdatedAt)
+	return nil
+}
+
+func (b *BillingCycle) AfterFind(*gorm.DB) error {
+	utc(&b.NextClosingAt, &b.UpdatedAt)
+	return nil
+}
+
+// Next returns the first closing date strictly after the given time, in its location. Closing
+// happens at midnight of the configured day in the API time zone, whatever the location the
+// given time is read in.
+func (b *BillingCycle) Next(after time.Time) time.Time {
+	local := after.In(time.Local)
+	closing := time.Date(local.Year(), local.Month(), b.ClosingDay, 0, 0, 0, 0, time.Local)
+	if !closing.After(after) {
+		closing = closing.AddDate(0, 1, 0)
+	}
+
+	return closing.In(after.Location())
+}
+
+// Due reports whether the cycle has reached its closing date.
+func (b *BillingCycle) Due(now time.Time) bool {
+	return !b.NextClosingAt.After(now)
+}
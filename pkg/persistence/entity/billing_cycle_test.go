@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBillingCycle_TableName(t *testing.T) {
+	cycle := BillingCycle{}
+	assert.Equal(t, BillingCycleTableName, cycle.TableName())
+}
+
+func TestBillingCycle_Next(t *testing.T) {
+	cycle := BillingCycle{ClosingDay: 10}
+	cases := []struct {
+		description string
+		after       time.Time
+		expected    time.Time
+	}{
+		{
+			description: "before closing day",
+			after:       time.Date(2022, time.March, 5, 13, 0, 0, 0, time.UTC),
+			expected:    time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "on closing date",
+			after:       time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC),
+			expected:    time.Date(2022, time.April, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "year rollover",
+			after:       time.Date(2022, time.December, 20, 0, 0, 0, 0, time.UTC),
+			expected:    time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cycle.Next(tt.after))
+		})
+	}
+}
+
+func TestBillingCycle_Next_Local(t *testing.T) {
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	local := time.Local
+	time.Local = location
+	defer func() { time.Local = local }()
+
+	// the closing date is read back in UTC, the next one is still at local midnight
+	cycle := BillingCycle{ClosingDay: 10}
+	closing := time.Date(2022, time.March, 10, 3, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2022, time.April, 10, 3, 0, 0, 0, time.UTC), cycle.Next(closing))
+
+	// a purchase at 21:30 the day before closing is 00:30 UTC of the closing day, it belongs
+	// to the closing statement
+	purchase := time.Date(2022, time.March, 9, 21, 30, 0, 0, location)
+	assert.True(t, purchase.Before(closing))
+	assert.Equal(t, closing, cycle.Next(purchase.UTC()))
+}
+
+func TestBillingCycle_UTC(t *testing.T) {
+	closing := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	cycle := BillingCycle{NextClosingAt: closing}
+	assert.NoError(t, cycle.AfterFind(nil))
+	assert.Equal(t, time.Date(2022, time.March, 10, 3, 0, 0, 0, time.UTC), cycle.NextClosingAt)
+}
+
+func TestBillingCycle_Due(t *testing.T) {
+	now := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	assert.True(t, (&BillingCycle{NextClosingAt: now}).Due(now))
+	assert.False(t, (&BillingCycle{NextClosingAt: now.Add(time.Second)}).Due(now))
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	CardTableName = "card"
+
+	CardStatusActive    = "active"
+	CardStatusBlocked   = "blocked"
+	CardStatusCancelled = "cancelled"
+	CardStatusLost      = "lost"
+
+	CardTypePhysical = "physical"
+	CardTypeVirtual  = "virtual"
+)
+
+// cardTransitions lists the statuses each status can move to, cancelled and lost are final.
+var cardTransitions = map[string][]string{
+	CardStatusActive:  {CardStatusBlocked, CardStatusCancelled, CardStatusLost},
+	CardStatusBlocked: {CardStatusActive, CardStatusCancelled, CardStatusLost},
+}
+
+// Card never stores the PAN, only its keyed token and last four digits. PAN is filled once,
+// in the issuing response.
+type Card struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	Account   uint      `json:"account_id" gorm:"type:integer;index;column:account_id"`
+	Token     string    `json:"token" gorm:"type:varchar(64);unique;column:token"`
+	LastFour  string    `json:"last_four" gorm:"type:varchar(4);column:last_four"`
+	PAN       string    `json:"pan,omitempty" gorm:"-"`
+	Type      string    `json:"type" gorm:"type:varchar(20);column:type"`
+	Status    string    `json:"status" gorm:"type:varchar(20);column:status"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"type:timestamptz;column:expires_at"`
+	CreatedBy string    `json:"created_by,omitempty" gorm:"type:varchar(64);column:created_by"`
+	CreatedAt time.Time `json:"created_at" gorm:"type:timestamptz;column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamptz;column:updated_at"`
+}
+
+func (c *Card) TableName() string {
+	return CardTableName
+}
+
+// BeforeSave and AfterFind keep the times in UTC, see utc.
+func (c *Card) BeforeSave(*gorm.DB) error {
+	utc(&c.ExpiresAt, &c.CreatedAt, &c.UpdatedAt)
+	return nil
+}
+
+func (c *Card) AfterFind(*gorm.DB) error {
+	utc(&c.ExpiresAt, &c.CreatedAt, &c.UpdatedAt)
+	return nil
+}
+
+// Expired reports whether the expiry month is over, ExpiresAt is the first instant after it.
+func (c *Card) Expired(now time.Time) bool {
+	return !c.ExpiresAt.After(now)
+}
+
+// CanMoveTo reports whether the card may go from its current status to the given one.
+func (c *Card) CanMoveTo(status string) bool {
+	for _, allowed := range cardTransitions[c.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+
+	return false
+}
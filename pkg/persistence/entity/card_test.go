@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCard_TableName(t *testing.T) {
+	card := Card{}
+	assert.Equal(t, CardTableName, card.TableName())
+}
+
+func TestCard_Expired(t *testing.T) {
+	card := Card{ExpiresAt: time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)}
+	assert.False(t, card.Expired(time.Date(2027, time.March, 31, 23, 59, 59, 0, time.UTC)))
+	assert.True(t, card.Expired(time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCard_CanMoveTo(t *testing.T) {
+	card := Card{Status: CardStatusActive}
+	assert.True(t, card.CanMoveTo(CardStatusBlocked))
+	assert.False(t, card.CanMoveTo(CardStatusActive))
+
+	card.Status = CardStatusBlocked
+	assert.True(t, card.CanMoveTo(CardStatusActive))
+	assert.True(t, card.CanMoveTo(CardStatusLost))
+
+	card.Status = CardStatusCancelled
+	assert.False(t, card.CanMoveTo(CardStatusActive))
+	assert.False(t, card.CanMoveTo(CardStatusBlocked))
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	HoldTableName = "hold"
+
+	HoldStatusAuthorized        = "authorized"
+	HoldStatusPartiallyCaptured = "partially_captured"
+	HoldStatusCaptured          = "captured"
+	HoldStatusVoided            = "voided"
+	HoldStatusExpired           = "expired"
+)
+
+// Hold reserves limit for a purchase captured later. Card and Merchant are only set on holds
+// that carried them, the capturing transactions keep them.
+type Hold struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	Account   uint      `json:"account_id" gorm:"type:integer;column:account_id"`
+	Type      uint      `json:"operation_id" gorm:"type:integer;column:operation_id"`
+	Card      *uint     `json:"card_id,omitempty" gorm:"type:integer;column:card_id"`
+	Amount    int64     `json:"amount" gorm:"type:integer;column:amount"`
+	Captured  int64     `json:"captured_amount" gorm:"type:integer;column:captured_amount;default:0"`
+	Status    string    `json:"status" gorm:"type:varchar(20);column:status"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"type:timestamptz;column:expires_at"`
+	CreatedBy string    `json:"created_by,omitempty" gorm:"type:varchar(64);column:created_by"`
+	CreatedAt time.Time `json:"created_at" gorm:"type:timestamptz;column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamptz;column:updated_at"`
+
+	Merchant
+}
+
+func (h *Hold) TableName() string {
+	return HoldTableName
+}
+
+// BeforeSave and AfterFind keep the times in UTC, see utc.
+func (h *Hold) BeforeSave(*gorm.DB) error {
+	utc(&h.ExpiresAt, &h.CreatedAt, &h.UpdatedAt)
+	return nil
+}
+
+func (h *Hold) AfterFind(*gorm.DB) error {
+	utc(&h.ExpiresAt, &h.CreatedAt, &h.UpdatedAt)
+	return nil
+}
+
+// Remaining is the part of the authorized amount still reserved against the account limit.
+func (h *Hold) Remaining() int64 {
+	return h.Amount - h.Captured
+}
+
+// Open reports whether the hold can still be captured or voided.
+func (h *Hold) Open() bool {
+	return h.Status == HoldStatusAuthorized || h.Status == HoldStatusPartiallyCaptured
+}
+
+func (h *Hold) Expired(now time.Time) bool {
+	return !h.ExpiresAt.After(now)
+}
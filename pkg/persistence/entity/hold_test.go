@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestHold_TableName(t *testing.T) {
+	hold := Hold{}
+	assert.Equal(t, HoldTableName, hold.TableName())
+}
+
+func TestHold_Remaining(t *testing.T) {
+	hold := Hold{Amount: 1000, Captured: 400}
+	assert.Equal(t, int64(600), hold.Remaining())
+}
+
+func TestHold_Open(t *testing.T) {
+	cases := []struct {
+		status   string
+		expected bool
+	}{
+		{status: HoldStatusAuthorized, expected: true},
+		{status: HoldStatusPartiallyCaptured, expected: true},
+		{status: HoldStatusCaptured, expected: false},
+		{status: HoldStatusVoided, expected: false},
+		{status: HoldStatusExpired, expected: false},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.status, func(t *testing.T) {
+			hold := Hold{Status: tt.status}
+			assert.Equal(t, tt.expected, hold.Open())
+		})
+	}
+}
+
+func TestHold_Expired(t *testing.T) {
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	assert.True(t, (&Hold{ExpiresAt: now}).Expired(now))
+	assert.False(t, (&Hold{ExpiresAt: now.Add(time.Second)}).Expired(now))
+}
+
+func TestHold_UTC(t *testing.T) {
+	expiresAt := time.Date(2022, time.March, 12, 1, 2, 3, 0, time.FixedZone("BRT", -3*60*60))
+
+	hold := Hold{ExpiresAt: expiresAt}
+	assert.NoError(t, hold.BeforeSave(nil))
+	assert.Equal(t, time.UTC, hold.ExpiresAt.Location())
+	assert.True(t, expiresAt.Equal(hold.ExpiresAt))
+	assert.True(t, hold.CreatedAt.IsZero())
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	IdempotencyKeyTableName = "idempotency_key"
+)
+
+// IdempotencyKey is unique per client, each one picks its keys without knowing the others.
+type IdempotencyKey struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	Client      string    `json:"client_id" gorm:"type:varchar(64);uniqueIndex:idx_idempotency_key_client_key,priority:1;column:client_id"`
+	Key         string    `json:"key" gorm:"type:varchar(255);uniqueIndex:idx_idempotency_key_client_key,priority:2;column:key"`
+	Hash        string    `json:"hash" gorm:"type:varchar(64);column:hash"`
+	Transaction uint      `json:"transaction_id" gorm:"type:integer;column:transaction_id"`
+	CreatedAt   time.Time `json:"created_at" gorm:"type:timestamp without time zone;column:created_at"`
+	ExpiresAt   time.Time `json:"expires_at" gorm:"type:timestamp without time zone;column:expires_at"`
+}
+
+func (i *IdempotencyKey) TableName() string {
+	return IdempotencyKeyTableName
+}
+
+func (i *IdempotencyKey) Expired(now time.Time) bool {
+	return !i.ExpiresAt.After(now)
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	InstallmentPlanTableName = "installment_plan"
+	InstallmentTableName     = "installment"
+)
+
+type (
+	InstallmentPlan struct {
+		ID           uint           `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Account      uint           `json:"account_id" gorm:"type:integer;column:account_id"`
+		Type         uint           `json:"operation_id" gorm:"type:integer;column:operation_id"`
+		Card         *uint          `json:"card_id,omitempty" gorm:"type:integer;column:card_id"`
+		Amount       int64          `json:"amount" gorm:"type:integer;column:amount"`
+		Count        int            `json:"installments" gorm:"type:integer;column:installments"`
+		CreatedBy    string         `json:"created_by,omitempty" gorm:"type:varchar(64);column:created_by"`
+		CreatedAt    time.Time      `json:"created_at" gorm:"type:timestamptz;column:created_at"`
+		Installments []*Installment `json:"schedule" gorm:"foreignKey:Plan"`
+
+		Merchant
+	}
+
+	Installment struct {
+		ID          uint       `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Plan        uint       `json:"installment_plan_id" gorm:"type:integer;column:installment_plan_id"`
+		Number      int        `json:"number" gorm:"type:integer;column:number"`
+		Amount      int64      `json:"amount" gorm:"type:integer;column:amount"`
+		DueDate     time.Time  `json:"due_date" gorm:"type:timestamptz;column:due_date"`
+		Transaction *uint      `json:"transaction_id" gorm:"type:integer;column:transaction_id"`
+		PostedAt    *time.Time `json:"posted_at" gorm:"type:timestamptz;column:posted_at"`
+		CancelledAt *time.Time `json:"cancelled_at,omitempty" gorm:"type:timestamptz;column:cancelled_at"`
+	}
+)
+
+func (p *InstallmentPlan) TableName() string {
+	return InstallmentPlanTableName
+}
+
+func (i *Installment) TableName() string {
+	return InstallmentTableName
+}
+
+// BeforeSave and AfterFind keep the times in UTC, see utc.
+func (p *InstallmentPlan) BeforeSave(*gorm.DB) error {
+	utc(&p.CreatedAt)
+	return nil
+}
+
+func (p *InstallmentPlan) AfterFind(*gorm.DB) error {
+	utc(&p.CreatedAt)
+	return nil
+}
+
+func (i *Installment) BeforeSave(*gorm.DB) error {
+	utc(&i.DueDate, i.PostedAt, i.CancelledAt)
+	return nil
+}
+
+func (i *Installment) AfterFind(*gorm.DB) error {
+	utc(&i.DueDate, i.PostedAt, i.CancelledAt)
+	return nil
+}
+
+func (i *Installment) Posted() bool {
+	return i.Transaction != nil
+}
+
+// Cancelled installments belong to a reversed plan, they are never posted.
+func (i *Installment) Cancelled() bool {
+	return i.CancelledAt != nil
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	JournalEntryTableName = "journal_entry"
+	PostingTableName      = "posting"
+
+	// LedgerAvailable holds the available limit of the card account, its balance must
+	// always match Account.AvailableLimit.
+	LedgerAvailable = "available"
+	// LedgerUsed holds the part of the limit consumed by purchases and not yet paid back.
+	LedgerUsed = "used"
+	// LedgerCreditLine is the counterpart of the limit granted to the account, its balance is
+	// always minus Account.CreditLimit.
+	LedgerCreditLine = "credit_line"
+)
+
+type (
+	// JournalEntry is an append-only record of one limit movement, its postings sum to zero.
+	JournalEntry struct {
+		ID          uint       `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Account     uint       `json:"account_id" gorm:"type:integer;column:account_id;index"`
+		Description string     `json:"description" gorm:"type:varchar(80);column:description"`
+		CreatedAt   time.Time  `json:"created_at" gorm:"type:timestamp without time zone;column:created_at"`
+		Postings    []*Posting `json:"postings" gorm:"foreignKey:Entry"`
+	}
+
+	Posting struct {
+		ID      uint   `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Entry   uint   `json:"journal_entry_id" gorm:"type:integer;column:journal_entry_id"`
+		Account uint   `json:"account_id" gorm:"type:integer;column:account_id;index:idx_posting_account_ledger"`
+		Ledger  string `json:"ledger" gorm:"type:varchar(20);column:ledger;index:idx_posting_account_ledger"`
+		Amount  int64  `json:"amount" gorm:"type:integer;column:amount"`
+	}
+
+	// LedgerDrift reports an account whose stored limit differs from its available ledger balance.
+	LedgerDrift struct {
+		Account uint  `json:"account_id" gorm:"column:account_id"`
+		Limit   int64 `json:"limit" gorm:"column:limit"`
+		Ledger  int64 `json:"ledger" gorm:"column:ledger"`
+	}
+)
+
+func (j *JournalEntry) TableName() string {
+	return JournalEntryTableName
+}
+
+func (p *Posting) TableName() string {
+	return PostingTableName
+}
+
+// Balanced reports whether the entry has postings and they sum to zero.
+func (j *JournalEntry) Balanced() bool {
+	if len(j.Postings) == 0 {
+		return false
+	}
+
+	sum := int64(0)
+	for _, posting := range j.Postings {
+		sum += posting.Amount
+	}
+
+	return sum == 0
+}
+
+// Transfer appends the pair of postings moving amount from one ledger to another.
+func (j *JournalEntry) Transfer(from, to string, amount int64) {
+	j.Postings = append(j.Postings,
+		&Posting{Account: j.Account, Ledger: from, Amount: -amount},
+		&Posting{Account: j.Account, Ledger: to, Amount: amount},
+	)
+}
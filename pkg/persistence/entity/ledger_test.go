@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJournalEntry_TableName(t *testing.T) {
+	entry := JournalEntry{}
+	posting := Posting{}
+	assert.Equal(t, JournalEntryTableName, entry.TableName())
+	assert.Equal(t, PostingTableName, posting.TableName())
+}
+
+func TestJournalEntry_Transfer(t *testing.T) {
+	entry := JournalEntry{Account: 1}
+	entry.Transfer(LedgerAvailable, LedgerUsed, 1000)
+
+	assert.True(t, entry.Balanced())
+	assert.Equal(t, &Posting{Account: 1, Ledger: LedgerAvailable, Amount: -1000}, entry.Postings[0])
+	assert.Equal(t, &Posting{Account: 1, Ledger: LedgerUsed, Amount: 1000}, entry.Postings[1])
+}
+
+func TestJournalEntry_Balanced(t *testing.T) {
+	assert.False(t, (&JournalEntry{}).Balanced())
+	assert.False(t, (&JournalEntry{Postings: []*Posting{{Amount: 100}, {Amount: -90}}}).Balanced())
+	assert.True(t, (&JournalEntry{Postings: []*Posting{{Amount: 100}, {Amount: -60}, {Amount: -40}}}).Balanced())
+}
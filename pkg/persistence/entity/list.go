@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"golang.org/x/xerrors"
+	"strings"
+)
+
+// List is a list of codes stored as a comma separated column, the codes can't hold commas.
+type List []string
+
+func (l List) Value() (driver.Value, error) {
+	return strings.Join(l, ","), nil
+}
+
+func (l *List) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return xerrors.Errorf("unsupported list %T", value)
+	}
+
+	*l = List{}
+	if raw != "" {
+		*l = strings.Split(raw, ",")
+	}
+
+	return nil
+}
+
+func (l List) Contains(code string) bool {
+	for _, item := range l {
+		if item == code {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestList_Value(t *testing.T) {
+	value, err := List{"7995", "5933"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "7995,5933", value)
+
+	value, err = List{}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "", value)
+}
+
+func TestList_Scan(t *testing.T) {
+	cases := []struct {
+		input    interface{}
+		expected List
+	}{
+		{input: "7995,5933", expected: List{"7995", "5933"}},
+		{input: []byte("7995"), expected: List{"7995"}},
+		{input: "", expected: List{}},
+		{input: nil, expected: List{}},
+	}
+
+	for _, tt := range cases {
+		var list List
+		assert.NoError(t, list.Scan(tt.input))
+		assert.Equal(t, tt.expected, list)
+	}
+
+	var list List
+	assert.Error(t, list.Scan(1))
+}
+
+func TestList_Contains(t *testing.T) {
+	list := List{"7995", "5933"}
+	assert.True(t, list.Contains("5933"))
+	assert.False(t, list.Contains("5411"))
+}
@@ -0,0 +1,13 @@
+package entity
+
+// Merchant is where a purchase was made. It is embedded in transactions, holds and installment
+// plans, so every installment and capture keeps the merchant of the original purchase.
+type Merchant struct {
+	MerchantID      string `json:"merchant_id,omitempty" gorm:"type:varchar(32);column:merchant_id"`
+	MerchantName    string `json:"merchant_name,omitempty" gorm:"type:varchar(100);column:merchant_name"`
+	MCC             string `json:"mcc,omitempty" gorm:"type:varchar(4);column:mcc;index"`
+	MerchantCity    string `json:"merchant_city,omitempty" gorm:"type:varchar(64);column:merchant_city"`
+	MerchantCountry string `json:"merchant_country,omitempty" gorm:"type:varchar(2);column:merchant_country"`
+	TerminalID      string `json:"terminal_id,omitempty" gorm:"type:varchar(16);column:terminal_id"`
+	EntryMode       string `json:"entry_mode,omitempty" gorm:"type:varchar(16);column:entry_mode"`
+}
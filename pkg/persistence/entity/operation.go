@@ -1,14 +1,28 @@
 package entity
 
+import (
+	"ms/card/pkg/persistence"
+)
+
 const (
 	OperationTableName = "operation"
 )
 
-type Operation struct {
-	ID          uint   `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
-	Description string `json:"description" gorm:"type:varchar(80);column:description"`
-	Debit       bool   `json:"debit" gorm:"type:boolean;column:debit;default:false"`
-}
+type (
+	Operation struct {
+		ID          uint   `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Description string `json:"description" gorm:"type:varchar(80);column:description"`
+		Debit       bool   `json:"debit" gorm:"type:boolean;column:debit;default:false"`
+		Fee         bool   `json:"fee" gorm:"type:boolean;column:fee;default:false"`
+		Reversal    bool   `json:"reversal" gorm:"type:boolean;column:reversal;default:false"`
+		CreatedBy   string `json:"created_by,omitempty" gorm:"type:varchar(64);column:created_by"`
+	}
+
+	OperationCollection struct {
+		persistence.Pagination
+		Data []*Operation `json:"data"`
+	}
+)
 
 func (a *Operation) TableName() string {
 	return OperationTableName
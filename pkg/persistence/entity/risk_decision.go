@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	RiskDecisionTableName = "risk_decision"
+)
+
+// RiskDecision records the risk assessment of a purchase for audit. Transaction is only set
+// when the purchase was booked, declined purchases keep their decision anyway.
+type RiskDecision struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	Account     uint      `json:"account_id" gorm:"type:integer;column:account_id;index"`
+	Card        *uint     `json:"card_id,omitempty" gorm:"type:integer;column:card_id"`
+	Transaction *uint     `json:"transaction_id,omitempty" gorm:"type:integer;column:transaction_id"`
+	Amount      int64     `json:"amount" gorm:"type:integer;column:amount"`
+	Outcome     string    `json:"outcome" gorm:"type:varchar(10);column:outcome"`
+	Score       int       `json:"score" gorm:"type:integer;column:score"`
+	Reasons     List      `json:"reasons" gorm:"type:varchar(1024);column:reasons"`
+	CreatedAt   time.Time `json:"created_at" gorm:"type:timestamp without time zone;column:created_at"`
+}
+
+func (r *RiskDecision) TableName() string {
+	return RiskDecisionTableName
+}
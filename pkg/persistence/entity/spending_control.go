@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	SpendingControlTableName = "spending_control"
+)
+
+// SpendingControl holds the rules a purchase must pass before the limit is touched. Card 0
+// holds the rules of the whole account, a card may add stricter rules of its own. Zero
+// amounts mean no cap.
+type SpendingControl struct {
+	Account            uint      `json:"account_id" gorm:"primaryKey;autoIncrement:false;column:account_id"`
+	Card               uint      `json:"card_id,omitempty" gorm:"primaryKey;autoIncrement:false;column:card_id"`
+	MaxAmount          int64     `json:"max_amount" gorm:"type:integer;column:max_amount"`
+	DailyLimit         int64     `json:"daily_limit" gorm:"type:integer;column:daily_limit"`
+	MonthlyLimit       int64     `json:"monthly_limit" gorm:"type:integer;column:monthly_limit"`
+	BlockedMCCs        List      `json:"blocked_mccs" gorm:"type:varchar(1024);column:blocked_mccs"`
+	BlockEcommerce     bool      `json:"block_ecommerce" gorm:"type:boolean;column:block_ecommerce"`
+	BlockInternational bool      `json:"block_international" gorm:"type:boolean;column:block_international"`
+	UpdatedAt          time.Time `json:"updated_at" gorm:"type:timestamp without time zone;column:updated_at"`
+}
+
+func (s *SpendingControl) TableName() string {
+	return SpendingControlTableName
+}
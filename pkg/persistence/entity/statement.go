@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"time"
+)
+
+const (
+	StatementTableName     = "statement"
+	StatementItemTableName = "statement_item"
+
+	StatementPeriodLayout = "2006-01"
+)
+
+type (
+	// Statement is the immutable snapshot of a closed billing cycle. Balances are the amount
+	// owed by the account holder, purchases and fees increase it, payments decrease it.
+	Statement struct {
+		ID             uint             `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Account        uint             `json:"account_id" gorm:"type:integer;column:account_id;uniqueIndex:idx_statement_account_period"`
+		Period         string           `json:"period" gorm:"type:varchar(7);column:period;uniqueIndex:idx_statement_account_period"`
+		PeriodStart    time.Time        `json:"period_start" gorm:"type:timestamptz;column:period_start"`
+		ClosingDate    time.Time        `json:"closing_date" gorm:"type:timestamptz;column:closing_date"`
+		DueDate        time.Time        `json:"due_date" gorm:"type:timestamptz;column:due_date"`
+		OpeningBalance int64            `json:"opening_balance" gorm:"type:integer;column:opening_balance"`
+		Purchases      int64            `json:"purchases" gorm:"type:integer;column:purchases"`
+		Payments       int64            `json:"payments" gorm:"type:integer;column:payments"`
+		Fees           int64            `json:"fees" gorm:"type:integer;column:fees"`
+		ClosingBalance int64            `json:"closing_balance" gorm:"type:integer;column:closing_balance"`
+		MinimumPayment int64            `json:"minimum_payment" gorm:"type:integer;column:minimum_payment"`
+		CreatedAt      time.Time        `json:"created_at" gorm:"type:timestamptz;column:created_at"`
+		Items          []*StatementItem `json:"items,omitempty" gorm:"foreignKey:Statement"`
+	}
+
+	StatementItem struct {
+		ID          uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Statement   uint      `json:"statement_id" gorm:"type:integer;column:statement_id"`
+		Transaction uint      `json:"transaction_id" gorm:"type:integer;column:transaction_id"`
+		Type        uint      `json:"operation_id" gorm:"type:integer;column:operation_id"`
+		Amount      int64     `json:"amount" gorm:"type:integer;column:amount"`
+		CreatedAt   time.Time `json:"created_at" gorm:"type:timestamptz;column:created_at"`
+	}
+)
+
+func (s *Statement) TableName() string {
+	return StatementTableName
+}
+
+func (i *StatementItem) TableName() string {
+	return StatementItemTableName
+}
+
+// BeforeSave and AfterFind keep the times in UTC, see utc.
+func (s *Statement) BeforeSave(*gorm.DB) error {
+	utc(&s.PeriodStart, &s.ClosingDate, &s.DueDate, &s.CreatedAt)
+	return nil
+}
+
+func (s *Statement) AfterFind(*gorm.DB) error {
+	utc(&s.PeriodStart, &s.ClosingDate, &s.DueDate, &s.CreatedAt)
+	return nil
+}
+
+func (i *StatementItem) BeforeSave(*gorm.DB) error {
+	utc(&i.CreatedAt)
+	return nil
+}
+
+func (i *StatementItem) AfterFind(*gorm.DB) error {
+	utc(&i.CreatedAt)
+	return nil
+}
+
+// Add snapshots the transaction into the statement. Debits count as purchases, or fees when
+// booked under a fee operation, reversals net against purchases and other credits are payments.
+func (s *Statement) Add(transaction *Transaction, fee bool) {
+	s.Items = append(s.Items, &StatementItem{
+		Transaction: transaction.ID,
+		Type:        transaction.Type,
+		Amount:      transaction.Amount,
+		CreatedAt:   transaction.CreatedAt,
+	})
+
+	switch {
+	case transaction.Amount < 0 && fee:
+		s.Fees += common.Abs(transaction.Amount)
+	case transaction.Amount < 0:
+		s.Purchases += common.Abs(transaction.Amount)
+	case transaction.Parent != nil:
+		s.Purchases -= transaction.Amount
+	default:
+		s.Payments += transaction.Amount
+	}
+}
+
+// Close computes the closing balance and the minimum payment, a percentage of the closing
+// balance rounded up to the cent.
+func (s *Statement) Close(minimumRate int64) {
+	s.ClosingBalance = s.OpeningBalance + s.Purchases + s.Fees - s.Payments
+	s.MinimumPayment = 0
+	if s.ClosingBalance > 0 {
+		s.MinimumPayment = (s.ClosingBalance*minimumRate + 99) / 100
+	}
+}
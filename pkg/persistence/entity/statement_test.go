@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStatement_TableName(t *testing.T) {
+	statement := Statement{}
+	item := StatementItem{}
+	assert.Equal(t, StatementTableName, statement.TableName())
+	assert.Equal(t, StatementItemTableName, item.TableName())
+}
+
+func TestStatement_Add_Close(t *testing.T) {
+	parent := uint(1)
+	statement := Statement{OpeningBalance: 500}
+	statement.Add(&Transaction{ID: 1, Type: 1, Amount: -1000}, false)
+	statement.Add(&Transaction{ID: 2, Type: 5, Amount: -50}, true)
+	statement.Add(&Transaction{ID: 3, Type: 1, Amount: 200, Parent: &parent}, false)
+	statement.Add(&Transaction{ID: 4, Type: 4, Amount: 300}, false)
+	statement.Close(15)
+
+	assert.Len(t, statement.Items, 4)
+	assert.Equal(t, int64(800), statement.Purchases)
+	assert.Equal(t, int64(50), statement.Fees)
+	assert.Equal(t, int64(300), statement.Payments)
+	assert.Equal(t, int64(1050), statement.ClosingBalance)
+	assert.Equal(t, int64(158), statement.MinimumPayment)
+}
+
+func TestStatement_Close_Credit_Balance(t *testing.T) {
+	statement := Statement{}
+	statement.Add(&Transaction{ID: 1, Type: 4, Amount: 300}, false)
+	statement.Close(15)
+
+	assert.Equal(t, int64(-300), statement.ClosingBalance)
+	assert.Equal(t, int64(0), statement.MinimumPayment)
+}
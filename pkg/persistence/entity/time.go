@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+)
+
+// utc moves the times to UTC, the driver reads timestamptz back in the local time zone. Nil
+// ones are left alone.
+func utc(times ...*time.Time) {
+	for _, t := range times {
+		if t != nil {
+			*t = t.UTC()
+		}
+	}
+}
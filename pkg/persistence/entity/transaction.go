@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"gorm.io/gorm"
+	"ms/card/pkg/persistence"
 	"time"
 )
 
@@ -9,17 +11,57 @@ const (
 )
 
 type (
+	// Transaction amounts are in the account billing currency. Currency, OriginalAmount, Rate
+	// and Tax are only set on purchases made in another currency, Amount is then the converted
+	// value, tax included. Merchant is only set on purchases that carried it. Account and Type
+	// reference the account and operation tables.
 	Transaction struct {
-		ID        uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
-		Account   uint      `json:"account_id" gorm:"type:integer;column:account_id"`
-		Type      uint      `json:"operation_id" gorm:"type:integer;column:operation_id"`
-		Amount    int64     `json:"amount" gorm:"type:integer;column:amount"`
-		CreatedAt time.Time `json:"created_at" gorm:"type:timestamp without time zone;column:created_at"`
+		ID             uint      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+		Account        uint      `json:"account_id" gorm:"type:integer;column:account_id;index:idx_transaction_account_created_at,priority:1"`
+		Type           uint      `json:"operation_id" gorm:"type:integer;column:operation_id;index:idx_transaction_operation"`
+		Amount         int64     `json:"amount" gorm:"type:integer;column:amount"`
+		Hold           *uint     `json:"hold_id,omitempty" gorm:"type:integer;column:hold_id"`
+		Parent         *uint     `json:"parent_id,omitempty" gorm:"type:integer;column:parent_id;index:idx_transaction_parent"`
+		Plan           *uint     `json:"installment_plan_id,omitempty" gorm:"type:integer;column:installment_plan_id"`
+		Card           *uint     `json:"card_id,omitempty" gorm:"type:integer;column:card_id"`
+		Currency       string    `json:"currency,omitempty" gorm:"type:varchar(3);column:currency"`
+		OriginalAmount int64     `json:"original_amount,omitempty" gorm:"type:integer;column:original_amount"`
+		Rate           string    `json:"rate,omitempty" gorm:"type:varchar(32);column:rate"`
+		Tax            int64     `json:"tax,omitempty" gorm:"type:integer;column:tax"`
+		CreatedBy      string    `json:"created_by,omitempty" gorm:"type:varchar(64);column:created_by"`
+		CreatedAt      time.Time `json:"created_at" gorm:"type:timestamptz;column:created_at;index:idx_transaction_account_created_at,priority:2"`
+
+		Merchant
+	}
+
+	// TransactionTotals aggregates the whole filtered set, not only the returned page. Debits
+	// and credits are absolute values, the balance is credits minus debits.
+	TransactionTotals struct {
+		Balance int64 `json:"balance" gorm:"column:balance"`
+		Debits  int64 `json:"debits" gorm:"column:debits"`
+		Credits int64 `json:"credits" gorm:"column:credits"`
+		Count   int64 `json:"count" gorm:"column:count"`
+	}
+
+	// TransactionExport is a transaction as the exports list it, next to the description of
+	// its operation.
+	TransactionExport struct {
+		Transaction
+		Operation string `json:"operation" gorm:"column:operation_description"`
 	}
 
+	OperationCount struct {
+		Operation uint  `json:"operation_id" gorm:"column:operation_id"`
+		Count     int64 `json:"count" gorm:"column:count"`
+	}
+
+	// TransactionCollection carries the totals and the operations on offset pages only,
+	// cursor pages follow the first page of the set and leave them out.
 	TransactionCollection struct {
-		Balance int64          `json:"balance"`
-		Data    []*Transaction `json:"data"`
+		persistence.Pagination
+		*TransactionTotals
+		Operations []*OperationCount `json:"operations,omitempty"`
+		Data       []*Transaction    `json:"data"`
 	}
 )
 
@@ -27,11 +69,14 @@ func (t *Transaction) TableName() string {
 	return TransactionTableName
 }
 
-func (t *TransactionCollection) Sum() {
-	values := int64(0)
-	for _, transaction := range t.Data {
-		values += transaction.Amount
-	}
+// BeforeSave and AfterFind keep CreatedAt in UTC, the driver reads timestamptz back in the
+// local time zone.
+func (t *Transaction) BeforeSave(*gorm.DB) error {
+	t.CreatedAt = t.CreatedAt.UTC()
+	return nil
+}
 
-	t.Balance = values
+func (t *Transaction) AfterFind(*gorm.DB) error {
+	t.CreatedAt = t.CreatedAt.UTC()
+	return nil
 }
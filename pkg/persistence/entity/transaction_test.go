@@ -3,6 +3,7 @@ package entity
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestTransaction_TableName(t *testing.T) {
@@ -10,20 +11,15 @@ func TestTransaction_TableName(t *testing.T) {
 	assert.Equal(t, TransactionTableName, transaction.TableName())
 }
 
-func TestTransactionCollection_TableName(t *testing.T) {
-	collection := TransactionCollection{
-		Data: []*Transaction{
-			{
-				Amount: 1000,
-			},
-			{
-				Amount: 2000,
-			},
-			{
-				Amount: 3000,
-			},
-		},
-	}
-	collection.Sum()
-	assert.Equal(t, int64(6000), collection.Balance)
+func TestTransaction_CreatedAt_UTC(t *testing.T) {
+	createdAt := time.Date(2022, time.March, 12, 1, 2, 3, 0, time.FixedZone("BRT", -3*60*60))
+
+	transaction := Transaction{CreatedAt: createdAt}
+	assert.NoError(t, transaction.BeforeSave(nil))
+	assert.Equal(t, time.UTC, transaction.CreatedAt.Location())
+	assert.True(t, createdAt.Equal(transaction.CreatedAt))
+
+	transaction = Transaction{CreatedAt: createdAt}
+	assert.NoError(t, transaction.AfterFind(nil))
+	assert.Equal(t, time.Date(2022, time.March, 12, 4, 2, 3, 0, time.UTC), transaction.CreatedAt)
 }
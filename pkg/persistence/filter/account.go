@@ -2,20 +2,28 @@ package filter
 
 import (
 	"gorm.io/gorm"
+	"ms/card/pkg/common"
 )
 
 type (
 	AccountCollection struct {
 		Page     int
 		Size     int
+		Sort     Sort
 		Document string
+		Status   string
 	}
 )
 
 func (t *AccountCollection) Filter() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		if t.Document != "" {
-			db.Where("document_number ILIKE ?", "%"+t.Document+"%")
+		// documents are stored with digits only, a punctuated search matches them as well
+		if document := common.NormalizeDocument(t.Document); document != "" {
+			db.Where("document_number LIKE ?", "%"+document+"%")
+		}
+
+		if t.Status != "" {
+			db.Where("status = ?", t.Status)
 		}
 
 		return db
@@ -0,0 +1,27 @@
+package filter
+
+import (
+	"gorm.io/gorm"
+)
+
+type (
+	AccountChangeCollection struct {
+		Page    int
+		Size    int
+		Account uint
+		Field   string
+	}
+)
+
+func (a *AccountChangeCollection) Filter() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// always scoped, a zero account matches nothing instead of every account
+		db.Where("account_id = ?", a.Account)
+
+		if a.Field != "" {
+			db.Where("field = ?", a.Field)
+		}
+
+		return db
+	}
+}
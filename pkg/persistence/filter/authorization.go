@@ -0,0 +1,31 @@
+package filter
+
+import (
+	"gorm.io/gorm"
+)
+
+type (
+	// AuthorizationCollection takes the transaction filters, plus the outcome and the decline
+	// code of the attempt. The cursor of the transactions is not supported.
+	AuthorizationCollection struct {
+		TransactionCollection
+		Outcome string
+		Code    string
+	}
+)
+
+func (a *AuthorizationCollection) Filter() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = a.TransactionCollection.Filter()(db)
+
+		if a.Outcome != "" {
+			db.Where("outcome = ?", a.Outcome)
+		}
+
+		if a.Code != "" {
+			db.Where("code = ?", a.Code)
+		}
+
+		return db
+	}
+}
@@ -0,0 +1,27 @@
+package filter
+
+import (
+	"gorm.io/gorm"
+)
+
+type (
+	CardCollection struct {
+		Page    int
+		Size    int
+		Account uint
+		Status  string
+	}
+)
+
+func (t *CardCollection) Filter() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// always scoped, a zero account matches nothing instead of every account
+		db.Where("account_id = ?", t.Account)
+
+		if t.Status != "" {
+			db.Where("status = ?", t.Status)
+		}
+
+		return db
+	}
+}
@@ -0,0 +1,22 @@
+package filter
+
+import (
+	"gorm.io/gorm"
+)
+
+type (
+	InstallmentPlanCollection struct {
+		Page    int
+		Size    int
+		Account uint
+	}
+)
+
+func (t *InstallmentPlanCollection) Filter() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// always scoped, a zero account matches nothing instead of every account
+		db.Where("account_id = ?", t.Account)
+
+		return db
+	}
+}
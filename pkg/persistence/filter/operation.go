@@ -2,23 +2,22 @@ package filter
 
 import (
 	"gorm.io/gorm"
-	"strconv"
 )
 
 type (
 	OperationCollection struct {
 		Page        int
 		Size        int
+		Sort        Sort
 		Description string
-		Debit       string
+		Debit       *bool
 	}
 )
 
 func (t *OperationCollection) Filter() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		if t.Debit != "" {
-			negative, _ := strconv.ParseBool(t.Debit)
-			db.Where("debit = ?", negative)
+		if t.Debit != nil {
+			db.Where("debit = ?", *t.Debit)
 		}
 
 		if t.Description != "" {
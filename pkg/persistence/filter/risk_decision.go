@@ -0,0 +1,27 @@
+package filter
+
+import (
+	"gorm.io/gorm"
+)
+
+type (
+	RiskDecisionCollection struct {
+		Page    int
+		Size    int
+		Account uint
+		Outcome string
+	}
+)
+
+func (r *RiskDecisionCollection) Filter() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// always scoped, a zero account matches nothing instead of every account
+		db.Where("account_id = ?", r.Account)
+
+		if r.Outcome != "" {
+			db.Where("outcome = ?", r.Outcome)
+		}
+
+		return db
+	}
+}
@@ -0,0 +1,73 @@
+package filter
+
+import (
+	"sort"
+)
+
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+var (
+	AccountSortFields = map[string]string{
+		"id":              "id",
+		"credit_limit":    "credit_limit",
+		"available_limit": `"limit"`,
+		"status":          "status",
+	}
+
+	OperationSortFields = map[string]string{
+		"id":          "id",
+		"description": "description",
+	}
+
+	TransactionSortFields = map[string]string{
+		"id":           "id",
+		"amount":       "amount",
+		"created_at":   "created_at",
+		"account_id":   "account_id",
+		"operation_id": "operation_id",
+	}
+
+	AuthorizationSortFields = map[string]string{
+		"id":         "id",
+		"amount":     "amount",
+		"created_at": "created_at",
+	}
+)
+
+// Sort orders a collection by one column, ties are broken by id in the same direction so
+// pages never overlap. The zero value orders by id.
+type Sort struct {
+	Column     string
+	Descending bool
+}
+
+func (s Sort) IsZero() bool {
+	return s.Column == "" && !s.Descending
+}
+
+func (s Sort) Order() string {
+	direction := ""
+	if s.Descending {
+		direction = " DESC"
+	}
+
+	if s.Column == "" || s.Column == "id" {
+		return "id" + direction
+	}
+
+	return s.Column + direction + ", id" + direction
+}
+
+// SortFields lists the names of a whitelist, for error messages and the docs.
+func SortFields(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,17 @@
+package filter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSort_Order(t *testing.T) {
+	assert.Equal(t, "id", Sort{}.Order())
+	assert.Equal(t, "id DESC", Sort{Column: "id", Descending: true}.Order())
+	assert.Equal(t, "amount, id", Sort{Column: "amount"}.Order())
+	assert.Equal(t, `"limit" DESC, id DESC`, Sort{Column: `"limit"`, Descending: true}.Order())
+}
+
+func TestSortFields(t *testing.T) {
+	assert.Equal(t, []string{"amount", "created_at", "id"}, SortFields(AuthorizationSortFields))
+}
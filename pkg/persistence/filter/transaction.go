@@ -2,40 +2,85 @@ package filter
 
 import (
 	"gorm.io/gorm"
-	"strconv"
+	"time"
 )
 
 type (
+	// TransactionCollection filters by the half-open created_at range [From, To), a zero bound
+	// leaves that side open. AmountMin and AmountMax bound the absolute amount, so they read
+	// the same on debits and credits, and Debit keeps only one of them when set.
 	TransactionCollection struct {
 		Page            int
 		Size            int
-		Account         string
-		Operation       string
-		CreateDateStart string
-		CreateDateEnd   string
+		After           uint
+		Before          uint
+		Sort            Sort
+		Accounts        []uint
+		Operations      []uint
+		From            time.Time
+		To              time.Time
+		AmountMin       int64
+		AmountMax       int64
+		Debit           *bool
+		MCC             string
+		MerchantName    string
+		MerchantCountry string
 	}
 )
 
 func (t *TransactionCollection) Filter() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		if t.Account != "" {
-			account, _ := strconv.Atoi(t.Account)
-			db.Where("account_id = ?", account)
+		in(db, "account_id", t.Accounts)
+		in(db, "operation_id", t.Operations)
+
+		if t.MCC != "" {
+			db.Where("mcc = ?", t.MCC)
+		}
+
+		if t.MerchantName != "" {
+			db.Where("merchant_name ILIKE ?", "%"+t.MerchantName+"%")
+		}
+
+		if t.MerchantCountry != "" {
+			db.Where("merchant_country = ?", t.MerchantCountry)
+		}
+
+		// plain comparisons on created_at, they use the (account_id, created_at) index
+		if !t.From.IsZero() {
+			db.Where("created_at >= ?", t.From)
+		}
+
+		if !t.To.IsZero() {
+			db.Where("created_at < ?", t.To)
+		}
+
+		if t.AmountMin != 0 {
+			db.Where("ABS(amount) >= ?", t.AmountMin)
+		}
+
+		if t.AmountMax != 0 {
+			db.Where("ABS(amount) <= ?", t.AmountMax)
 		}
 
-		if t.Operation != "" {
-			operation, _ := strconv.Atoi(t.Operation)
-			db.Where("operation_id = ?", operation)
+		if t.Debit != nil && *t.Debit {
+			db.Where("amount < 0")
 		}
 
-		if t.CreateDateStart != "" && t.CreateDateEnd != "" {
-			db.Where(
-				"TO_CHAR(created_at, 'YYYY-MM-DD HH24:MI') BETWEEN ? AND ?",
-				t.CreateDateStart,
-				t.CreateDateEnd,
-			)
+		if t.Debit != nil && !*t.Debit {
+			db.Where("amount > 0")
 		}
 
 		return db
 	}
 }
+
+// in matches any of the ids, a single one is kept as an equality.
+func in(db *gorm.DB, column string, ids []uint) {
+	switch len(ids) {
+	case 0:
+	case 1:
+		db.Where(column+" = ?", ids[0])
+	default:
+		db.Where(column+" IN ?", ids)
+	}
+}
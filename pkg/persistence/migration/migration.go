@@ -0,0 +1,93 @@
+package migration
+
+import (
+	"embed"
+	"golang.org/x/xerrors"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const (
+	directionUp = "up"
+)
+
+var (
+	// Files holds the migrations of the service, named VERSION_NAME.up.sql and
+	// VERSION_NAME.down.sql with versions applied in ascending order.
+	//go:embed migrations/*.sql
+	Files embed.FS
+
+	ErrMigrationName       = xerrors.New("migration file must be named VERSION_NAME.up.sql or VERSION_NAME.down.sql")
+	ErrMigrationDuplicate  = xerrors.New("migration version is used twice")
+	ErrMigrationIncomplete = xerrors.New("migration needs both an up and a down file")
+
+	fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+)
+
+// Migration is one schema change, Up applies it and Down reverts it.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads the migrations under the migrations directory of source, sorted by version.
+func Load(source fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(source, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		matches := fileName.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, xerrors.Errorf("%s: %w", entry.Name(), ErrMigrationName)
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 32)
+		if err != nil {
+			return nil, xerrors.Errorf("%s: %w", entry.Name(), ErrMigrationName)
+		}
+
+		content, err := fs.ReadFile(source, path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: matches[2]}
+			byVersion[uint(version)] = migration
+		}
+
+		if migration.Name != matches[2] {
+			return nil, xerrors.Errorf("%s: %w", entry.Name(), ErrMigrationDuplicate)
+		}
+
+		if matches[3] == directionUp {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, xerrors.Errorf("%d_%s: %w", migration.Version, migration.Name, ErrMigrationIncomplete)
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
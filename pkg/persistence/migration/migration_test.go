@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	source := fstest.MapFS{
+		"migrations/0002_card.up.sql":      {Data: []byte("CREATE TABLE card ();")},
+		"migrations/0002_card.down.sql":    {Data: []byte("DROP TABLE card;")},
+		"migrations/0001_account.up.sql":   {Data: []byte("CREATE TABLE account ();")},
+		"migrations/0001_account.down.sql": {Data: []byte("DROP TABLE account;")},
+	}
+
+	migrations, err := Load(source)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Migration{
+		{Version: 1, Name: "account", Up: "CREATE TABLE account ();", Down: "DROP TABLE account;"},
+		{Version: 2, Name: "card", Up: "CREATE TABLE card ();", Down: "DROP TABLE card;"},
+	}, migrations)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	cases := []struct {
+		source   fstest.MapFS
+		expected error
+	}{
+		{
+			source:   fstest.MapFS{"migrations/account.up.sql": {Data: []byte("SELECT 1;")}},
+			expected: ErrMigrationName,
+		},
+		{
+			source: fstest.MapFS{
+				"migrations/0001_account.up.sql":   {Data: []byte("SELECT 1;")},
+				"migrations/0001_account.down.sql": {Data: []byte("SELECT 1;")},
+				"migrations/0001_card.up.sql":      {Data: []byte("SELECT 1;")},
+			},
+			expected: ErrMigrationDuplicate,
+		},
+		{
+			source:   fstest.MapFS{"migrations/0001_account.up.sql": {Data: []byte("SELECT 1;")}},
+			expected: ErrMigrationIncomplete,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.expected.Error(), func(t *testing.T) {
+			migrations, err := Load(tt.source)
+			assert.Nil(t, migrations)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestLoad_Files(t *testing.T) {
+	migrations, err := Load(Files)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.Equal(t, uint(i+1), migration.Version, "versions must follow each other")
+	}
+}
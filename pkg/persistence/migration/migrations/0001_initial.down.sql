@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS "api_key";
+DROP TABLE IF EXISTS "authorization_attempt";
+DROP TABLE IF EXISTS "risk_decision";
+DROP TABLE IF EXISTS "spending_control";
+DROP TABLE IF EXISTS "card";
+DROP TABLE IF EXISTS "posting";
+DROP TABLE IF EXISTS "journal_entry";
+DROP TABLE IF EXISTS "statement_item";
+DROP TABLE IF EXISTS "statement";
+DROP TABLE IF EXISTS "billing_cycle";
+DROP TABLE IF EXISTS "installment";
+DROP TABLE IF EXISTS "installment_plan";
+DROP TABLE IF EXISTS "hold";
+DROP TABLE IF EXISTS "idempotency_key";
+DROP TABLE IF EXISTS "transaction";
+DROP TABLE IF EXISTS "account_change";
+DROP TABLE IF EXISTS "account";
+DROP TABLE IF EXISTS "operation";
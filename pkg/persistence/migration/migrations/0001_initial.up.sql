@@ -0,0 +1,266 @@
+-- Schema as created by db.AutoMigrate before versioned migrations, every statement is
+-- guarded so databases created that way are adopted as they are.
+
+CREATE TABLE IF NOT EXISTS "operation" (
+    "id"          bigserial,
+    "description" varchar(80),
+    "debit"       boolean DEFAULT false,
+    "fee"         boolean DEFAULT false,
+    "created_by"  varchar(64),
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "account" (
+    "id"              bigserial,
+    "document_number" varchar(11) UNIQUE,
+    "limit"           integer,
+    "currency"        varchar(3) DEFAULT 'BRL',
+    "status"          varchar(10) DEFAULT 'active',
+    "created_by"      varchar(64),
+    "deleted_at"      timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_account_deleted_at" ON "account" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "account_change" (
+    "id"         bigserial,
+    "account_id" integer,
+    "field"      varchar(20),
+    "from_value" varchar(64),
+    "to_value"   varchar(64),
+    "reason"     varchar(255),
+    "created_by" varchar(64),
+    "created_at" timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_account_change_account" ON "account_change" ("account_id");
+
+CREATE TABLE IF NOT EXISTS "transaction" (
+    "id"                  bigserial,
+    "account_id"          integer,
+    "operation_id"        integer,
+    "amount"              integer,
+    "hold_id"             integer,
+    "parent_id"           integer,
+    "installment_plan_id" integer,
+    "card_id"             integer,
+    "currency"            varchar(3),
+    "original_amount"     integer,
+    "rate"                varchar(32),
+    "tax"                 integer,
+    "created_by"          varchar(64),
+    "created_at"          timestamp without time zone,
+    "merchant_id"         varchar(32),
+    "merchant_name"       varchar(100),
+    "mcc"                 varchar(4),
+    "merchant_city"       varchar(64),
+    "merchant_country"    varchar(2),
+    "terminal_id"         varchar(16),
+    "entry_mode"          varchar(16),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_transaction_mcc" ON "transaction" ("mcc");
+
+CREATE TABLE IF NOT EXISTS "idempotency_key" (
+    "id"             bigserial,
+    "key"            varchar(255) UNIQUE,
+    "hash"           varchar(64),
+    "transaction_id" integer,
+    "created_at"     timestamp without time zone,
+    "expires_at"     timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "hold" (
+    "id"              bigserial,
+    "account_id"      integer,
+    "operation_id"    integer,
+    "amount"          integer,
+    "captured_amount" integer DEFAULT 0,
+    "status"          varchar(20),
+    "expires_at"      timestamp without time zone,
+    "created_by"      varchar(64),
+    "created_at"      timestamp without time zone,
+    "updated_at"      timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "installment_plan" (
+    "id"               bigserial,
+    "account_id"       integer,
+    "operation_id"     integer,
+    "card_id"          integer,
+    "amount"           integer,
+    "installments"     integer,
+    "created_by"       varchar(64),
+    "created_at"       timestamp without time zone,
+    "merchant_id"      varchar(32),
+    "merchant_name"    varchar(100),
+    "mcc"              varchar(4),
+    "merchant_city"    varchar(64),
+    "merchant_country" varchar(2),
+    "terminal_id"      varchar(16),
+    "entry_mode"       varchar(16),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_installment_plan_mcc" ON "installment_plan" ("mcc");
+
+CREATE TABLE IF NOT EXISTS "installment" (
+    "id"                  bigserial,
+    "installment_plan_id" bigint,
+    "number"              integer,
+    "amount"              integer,
+    "due_date"            timestamp without time zone,
+    "transaction_id"      integer,
+    "posted_at"           timestamp without time zone,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_installment_plan_installments" FOREIGN KEY ("installment_plan_id") REFERENCES "installment_plan" ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "billing_cycle" (
+    "account_id"      bigint,
+    "closing_day"     integer,
+    "due_days"        integer,
+    "next_closing_at" timestamp without time zone,
+    "updated_at"      timestamp without time zone,
+    PRIMARY KEY ("account_id")
+);
+
+CREATE TABLE IF NOT EXISTS "statement" (
+    "id"              bigserial,
+    "account_id"      integer,
+    "period"          varchar(7),
+    "period_start"    timestamp without time zone,
+    "closing_date"    timestamp without time zone,
+    "due_date"        timestamp without time zone,
+    "opening_balance" integer,
+    "purchases"       integer,
+    "payments"        integer,
+    "fees"            integer,
+    "closing_balance" integer,
+    "minimum_payment" integer,
+    "created_at"      timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_statement_account_period" ON "statement" ("account_id", "period");
+
+CREATE TABLE IF NOT EXISTS "statement_item" (
+    "id"             bigserial,
+    "statement_id"   bigint,
+    "transaction_id" integer,
+    "operation_id"   integer,
+    "amount"         integer,
+    "created_at"     timestamp without time zone,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_statement_items" FOREIGN KEY ("statement_id") REFERENCES "statement" ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "journal_entry" (
+    "id"          bigserial,
+    "account_id"  integer,
+    "description" varchar(80),
+    "created_at"  timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_journal_entry_account" ON "journal_entry" ("account_id");
+
+CREATE TABLE IF NOT EXISTS "posting" (
+    "id"               bigserial,
+    "journal_entry_id" bigint,
+    "account_id"       integer,
+    "ledger"           varchar(20),
+    "amount"           integer,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_journal_entry_postings" FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entry" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_posting_account_ledger" ON "posting" ("account_id", "ledger");
+
+CREATE TABLE IF NOT EXISTS "card" (
+    "id"         bigserial,
+    "account_id" integer,
+    "token"      varchar(64) UNIQUE,
+    "last_four"  varchar(4),
+    "type"       varchar(20),
+    "status"     varchar(20),
+    "expires_at" timestamp without time zone,
+    "created_by" varchar(64),
+    "created_at" timestamp without time zone,
+    "updated_at" timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_card_account" ON "card" ("account_id");
+
+CREATE TABLE IF NOT EXISTS "spending_control" (
+    "account_id"          bigint,
+    "card_id"             bigint,
+    "max_amount"          integer,
+    "daily_limit"         integer,
+    "monthly_limit"       integer,
+    "blocked_mccs"        varchar(1024),
+    "block_ecommerce"     boolean,
+    "block_international" boolean,
+    "updated_at"          timestamp without time zone,
+    PRIMARY KEY ("account_id", "card_id")
+);
+
+CREATE TABLE IF NOT EXISTS "risk_decision" (
+    "id"             bigserial,
+    "account_id"     integer,
+    "card_id"        integer,
+    "transaction_id" integer,
+    "amount"         integer,
+    "outcome"        varchar(10),
+    "score"          integer,
+    "reasons"        varchar(1024),
+    "created_at"     timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_risk_decision_account" ON "risk_decision" ("account_id");
+
+CREATE TABLE IF NOT EXISTS "authorization_attempt" (
+    "id"               bigserial,
+    "account_id"       integer,
+    "operation_id"     integer,
+    "card_id"          integer,
+    "transaction_id"   integer,
+    "hold_id"          integer,
+    "amount"           integer,
+    "currency"         varchar(3),
+    "outcome"          varchar(10),
+    "code"             varchar(32),
+    "reason"           text,
+    "created_by"       varchar(64),
+    "created_at"       timestamp without time zone,
+    "merchant_id"      varchar(32),
+    "merchant_name"    varchar(100),
+    "mcc"              varchar(4),
+    "merchant_city"    varchar(64),
+    "merchant_country" varchar(2),
+    "terminal_id"      varchar(16),
+    "entry_mode"       varchar(16),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_authorization_attempt_mcc" ON "authorization_attempt" ("mcc");
+CREATE INDEX IF NOT EXISTS "idx_authorization_attempt_account" ON "authorization_attempt" ("account_id");
+
+CREATE TABLE IF NOT EXISTS "api_key" (
+    "id"         bigserial,
+    "client_id"  varchar(64),
+    "hash"       varchar(64) UNIQUE,
+    "scopes"     varchar(1024),
+    "created_at" timestamp without time zone,
+    "revoked_at" timestamp without time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_api_key_client" ON "api_key" ("client_id");
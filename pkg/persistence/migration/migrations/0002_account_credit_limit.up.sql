@@ -0,0 +1,7 @@
+-- The credit limit gets its own column, "limit" keeps the available limit. Accounts opened
+-- before then get it back from the credit line ledger, whose balance is minus the limit.
+ALTER TABLE "account" ADD COLUMN IF NOT EXISTS "credit_limit" integer NOT NULL DEFAULT 0;
+
+UPDATE "account" SET "credit_limit" = -ledger.balance
+FROM (SELECT "account_id", SUM("amount") AS balance FROM "posting" WHERE "ledger" = 'credit_line' GROUP BY "account_id") ledger
+WHERE ledger.account_id = "account"."id" AND "account"."credit_limit" = 0;
@@ -0,0 +1,3 @@
+-- Fails while CNPJs are stored, they don't fit the narrower column.
+ALTER TABLE "account" DROP COLUMN IF EXISTS "document_type";
+ALTER TABLE "account" ALTER COLUMN "document_number" TYPE varchar(11);
@@ -0,0 +1,8 @@
+-- Documents are stored with digits only and fit CNPJs, the column only fitted CPFs before.
+ALTER TABLE "account" ALTER COLUMN "document_number" TYPE varchar(14);
+ALTER TABLE "account" ADD COLUMN IF NOT EXISTS "document_type" varchar(4);
+
+UPDATE "account" SET
+    "document_number" = regexp_replace("document_number", '[^0-9]', '', 'g'),
+    "document_type"   = CASE WHEN length(regexp_replace("document_number", '[^0-9]', '', 'g')) = 11 THEN 'cpf' ELSE '' END
+WHERE "document_type" IS NULL;
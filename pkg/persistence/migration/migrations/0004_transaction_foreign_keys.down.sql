@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_transaction_operation";
+DROP INDEX IF EXISTS "idx_transaction_account_created_at";
+
+ALTER TABLE "transaction" DROP CONSTRAINT IF EXISTS "fk_transaction_operation";
+ALTER TABLE "transaction" DROP CONSTRAINT IF EXISTS "fk_transaction_account";
@@ -0,0 +1,10 @@
+-- NOT VALID enforces the keys on new rows without checking the ones already there, orphans
+-- left from before can be cleaned up and the keys validated later with
+-- ALTER TABLE "transaction" VALIDATE CONSTRAINT "fk_transaction_account".
+ALTER TABLE "transaction"
+    ADD CONSTRAINT "fk_transaction_account" FOREIGN KEY ("account_id") REFERENCES "account" ("id") NOT VALID;
+ALTER TABLE "transaction"
+    ADD CONSTRAINT "fk_transaction_operation" FOREIGN KEY ("operation_id") REFERENCES "operation" ("id") NOT VALID;
+
+CREATE INDEX IF NOT EXISTS "idx_transaction_account_created_at" ON "transaction" ("account_id", "created_at");
+CREATE INDEX IF NOT EXISTS "idx_transaction_operation" ON "transaction" ("operation_id");
@@ -0,0 +1,2 @@
+ALTER TABLE "authorization_attempt" ALTER COLUMN "created_at" TYPE timestamp without time zone USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
+ALTER TABLE "transaction" ALTER COLUMN "created_at" TYPE timestamp without time zone USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
@@ -0,0 +1,4 @@
+-- created_at was written from time.Now() as a wall clock of the API time zone, the
+-- America/Sao_Paulo of build/docker/Dockerfile, and is read back as that zone's instant.
+ALTER TABLE "transaction" ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
+ALTER TABLE "authorization_attempt" ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
@@ -0,0 +1,2 @@
+UPDATE "account" SET "credit_limit" = 0
+WHERE "credit_limit" = "limit" AND NOT EXISTS (SELECT 1 FROM "posting" WHERE "posting"."account_id" = "account"."id");
@@ -0,0 +1,5 @@
+-- 0002 recovered the credit limit from the credit line ledger, accounts opened before the
+-- ledger have no postings and were left at zero. Nothing was spent on them through the
+-- ledger either, so their available limit is the whole credit limit.
+UPDATE "account" SET "credit_limit" = "limit"
+WHERE "credit_limit" = 0 AND NOT EXISTS (SELECT 1 FROM "posting" WHERE "posting"."account_id" = "account"."id");
@@ -0,0 +1,2 @@
+DELETE FROM "posting" WHERE "journal_entry_id" IN (SELECT "id" FROM "journal_entry" WHERE "description" = 'opening balance');
+DELETE FROM "journal_entry" WHERE "description" = 'opening balance';
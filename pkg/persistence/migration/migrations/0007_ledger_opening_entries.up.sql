@@ -0,0 +1,22 @@
+-- Accounts opened before the ledger have no postings, so the reconcile worker would report
+-- their whole available limit as drift. They get an opening entry that grants the credit
+-- limit on the credit line and splits it between what is available and what is used.
+WITH "opening" AS (
+    SELECT "id", "limit", "credit_limit" FROM "account"
+    WHERE ("limit" <> 0 OR "credit_limit" <> 0)
+      AND NOT EXISTS (SELECT 1 FROM "posting" WHERE "posting"."account_id" = "account"."id")
+), "entry" AS (
+    INSERT INTO "journal_entry" ("account_id", "description", "created_at")
+    SELECT "id", 'opening balance', now() AT TIME ZONE 'America/Sao_Paulo' FROM "opening"
+    RETURNING "id", "account_id"
+)
+INSERT INTO "posting" ("journal_entry_id", "account_id", "ledger", "amount")
+SELECT "entry"."id", "entry"."account_id", "balance"."ledger", "balance"."amount"
+FROM "entry"
+JOIN "opening" ON "opening"."id" = "entry"."account_id"
+CROSS JOIN LATERAL (VALUES
+    ('credit_line', -"opening"."credit_limit"),
+    ('available', "opening"."limit"),
+    ('used', "opening"."credit_limit" - "opening"."limit")
+) AS "balance" ("ledger", "amount")
+WHERE "balance"."amount" <> 0;
@@ -0,0 +1 @@
+ALTER TABLE "installment" DROP COLUMN IF EXISTS "cancelled_at";
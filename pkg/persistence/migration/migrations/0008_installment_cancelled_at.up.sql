@@ -0,0 +1,2 @@
+-- Reversing a purchase in installments cancels the installments not posted yet.
+ALTER TABLE "installment" ADD COLUMN IF NOT EXISTS "cancelled_at" timestamp without time zone;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_idempotency_key_client_key";
+
+ALTER TABLE "idempotency_key" ADD CONSTRAINT "idempotency_key_key_key" UNIQUE ("key");
+
+ALTER TABLE "idempotency_key" DROP COLUMN IF EXISTS "client_id";
@@ -0,0 +1,11 @@
+-- Idempotency keys are picked by the clients, two of them may pick the same one: keys are
+-- unique per client. Existing keys belong to the client of the transaction they booked.
+ALTER TABLE "idempotency_key" ADD COLUMN IF NOT EXISTS "client_id" varchar(64) NOT NULL DEFAULT '';
+
+UPDATE "idempotency_key" SET "client_id" = COALESCE("transaction"."created_by", '')
+FROM "transaction"
+WHERE "transaction"."id" = "idempotency_key"."transaction_id";
+
+ALTER TABLE "idempotency_key" DROP CONSTRAINT IF EXISTS "idempotency_key_key_key";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_idempotency_key_client_key" ON "idempotency_key" ("client_id", "key");
@@ -0,0 +1,30 @@
+ALTER TABLE "hold"
+    ALTER COLUMN "expires_at" TYPE timestamp without time zone USING "expires_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "created_at" TYPE timestamp without time zone USING "created_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "updated_at" TYPE timestamp without time zone USING "updated_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "card"
+    ALTER COLUMN "expires_at" TYPE timestamp without time zone USING "expires_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "created_at" TYPE timestamp without time zone USING "created_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "updated_at" TYPE timestamp without time zone USING "updated_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "installment_plan"
+    ALTER COLUMN "created_at" TYPE timestamp without time zone USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "installment"
+    ALTER COLUMN "due_date" TYPE timestamp without time zone USING "due_date" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "posted_at" TYPE timestamp without time zone USING "posted_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "cancelled_at" TYPE timestamp without time zone USING "cancelled_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "billing_cycle"
+    ALTER COLUMN "next_closing_at" TYPE timestamp without time zone USING "next_closing_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "updated_at" TYPE timestamp without time zone USING "updated_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "statement"
+    ALTER COLUMN "period_start" TYPE timestamp without time zone USING "period_start" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "closing_date" TYPE timestamp without time zone USING "closing_date" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "due_date" TYPE timestamp without time zone USING "due_date" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "created_at" TYPE timestamp without time zone USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "statement_item"
+    ALTER COLUMN "created_at" TYPE timestamp without time zone USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
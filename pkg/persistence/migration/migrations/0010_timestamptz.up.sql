@@ -0,0 +1,34 @@
+-- The dates the services compare with the transactions created_at and with the clock: the
+-- holds, cards and installments expirations and due dates and the billing cycles and
+-- statements periods. Like created_at in 0005 they were written as a wall clock of the
+-- America/Sao_Paulo time zone of the API.
+ALTER TABLE "hold"
+    ALTER COLUMN "expires_at" TYPE timestamptz USING "expires_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "updated_at" TYPE timestamptz USING "updated_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "card"
+    ALTER COLUMN "expires_at" TYPE timestamptz USING "expires_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "updated_at" TYPE timestamptz USING "updated_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "installment_plan"
+    ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "installment"
+    ALTER COLUMN "due_date" TYPE timestamptz USING "due_date" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "posted_at" TYPE timestamptz USING "posted_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "cancelled_at" TYPE timestamptz USING "cancelled_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "billing_cycle"
+    ALTER COLUMN "next_closing_at" TYPE timestamptz USING "next_closing_at" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "updated_at" TYPE timestamptz USING "updated_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "statement"
+    ALTER COLUMN "period_start" TYPE timestamptz USING "period_start" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "closing_date" TYPE timestamptz USING "closing_date" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "due_date" TYPE timestamptz USING "due_date" AT TIME ZONE 'America/Sao_Paulo',
+    ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
+
+ALTER TABLE "statement_item"
+    ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'America/Sao_Paulo';
@@ -0,0 +1,9 @@
+ALTER TABLE "hold"
+    DROP COLUMN IF EXISTS "card_id",
+    DROP COLUMN IF EXISTS "merchant_id",
+    DROP COLUMN IF EXISTS "merchant_name",
+    DROP COLUMN IF EXISTS "mcc",
+    DROP COLUMN IF EXISTS "merchant_city",
+    DROP COLUMN IF EXISTS "merchant_country",
+    DROP COLUMN IF EXISTS "terminal_id",
+    DROP COLUMN IF EXISTS "entry_mode";
@@ -0,0 +1,11 @@
+-- Holds carry the card and the merchant of the purchase, as transactions do, and hand them
+-- to the transactions that capture them.
+ALTER TABLE "hold"
+    ADD COLUMN IF NOT EXISTS "card_id"          integer,
+    ADD COLUMN IF NOT EXISTS "merchant_id"      varchar(32),
+    ADD COLUMN IF NOT EXISTS "merchant_name"    varchar(100),
+    ADD COLUMN IF NOT EXISTS "mcc"              varchar(4),
+    ADD COLUMN IF NOT EXISTS "merchant_city"    varchar(64),
+    ADD COLUMN IF NOT EXISTS "merchant_country" varchar(2),
+    ADD COLUMN IF NOT EXISTS "terminal_id"      varchar(16),
+    ADD COLUMN IF NOT EXISTS "entry_mode"       varchar(16);
@@ -0,0 +1,3 @@
+-- Reversals are looked up by the purchase they reverse, the spending caps net every purchase
+-- of the period of them.
+CREATE INDEX IF NOT EXISTS "idx_transaction_parent" ON "transaction" ("parent_id");
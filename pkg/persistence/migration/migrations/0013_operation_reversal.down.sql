@@ -0,0 +1,9 @@
+UPDATE "transaction" SET "operation_id" = "parent"."operation_id"
+FROM "transaction" AS "parent"
+WHERE "parent"."id" = "transaction"."parent_id";
+
+DELETE FROM "operation" WHERE "reversal";
+
+DROP INDEX IF EXISTS "idx_operation_reversal";
+
+ALTER TABLE "operation" DROP COLUMN IF EXISTS "reversal";
@@ -0,0 +1,12 @@
+-- Reversals were booked under the operation of the purchase they reverse, a debit operation
+-- on a positive amount. They get a credit operation of their own, flagged as the reversal one.
+ALTER TABLE "operation" ADD COLUMN IF NOT EXISTS "reversal" boolean DEFAULT false;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_operation_reversal" ON "operation" ("reversal") WHERE "reversal";
+
+INSERT INTO "operation" ("description", "debit", "fee", "reversal")
+SELECT 'ESTORNO', false, false, true
+WHERE NOT EXISTS (SELECT 1 FROM "operation" WHERE "reversal");
+
+UPDATE "transaction" SET "operation_id" = (SELECT "id" FROM "operation" WHERE "reversal")
+WHERE "parent_id" IS NOT NULL;
@@ -0,0 +1,218 @@
+package migration
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"os"
+	"testing"
+	"time"
+)
+
+// The tests below run the SQL of the embedded migrations, which sqlmock can't, against the
+// database of API_TEST_DB_DSN. Each one works in a schema of its own dropped at the end, they
+// are skipped without a database.
+
+func openSchema(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("API_TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("API_TEST_DB_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// a single connection keeps the search_path for every statement
+	sqlDB, err := db.DB()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("migration_test_%d", time.Now().UnixNano())
+	exec(t, db, `CREATE SCHEMA `+schema)
+	exec(t, db, `SET search_path TO `+schema)
+	t.Cleanup(func() {
+		_ = db.Exec(`DROP SCHEMA ` + schema + ` CASCADE`).Error
+		_ = sqlDB.Close()
+	})
+
+	return db
+}
+
+func exec(t *testing.T, db *gorm.DB, sql string, values ...interface{}) {
+	if !assert.NoError(t, db.Exec(sql, values...).Error) {
+		t.FailNow()
+	}
+}
+
+// migrateTo applies the up of every embedded migration from one version to another.
+func migrateTo(t *testing.T, db *gorm.DB, from uint, to uint) {
+	migrations, err := Load(Files)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for _, migration := range migrations {
+		if migration.Version >= from && migration.Version <= to {
+			exec(t, db, migration.Up)
+		}
+	}
+}
+
+func revert(t *testing.T, db *gorm.DB, version uint) {
+	migrations, err := Load(Files)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	exec(t, db, migrations[version-1].Down)
+}
+
+func creditLimit(t *testing.T, db *gorm.DB, account uint) int64 {
+	var limit int64
+	assert.NoError(t, db.Raw(`SELECT "credit_limit" FROM "account" WHERE "id" = ?`, account).Scan(&limit).Error)
+	return limit
+}
+
+func TestMigration_0006_Account_Credit_Limit_Legacy(t *testing.T) {
+	db := openSchema(t)
+	migrateTo(t, db, 1, 5)
+
+	// account 1 was opened before the ledger, account 2 after with a limit of 800.00 of
+	// which 300.00 are used
+	exec(t, db, `INSERT INTO "account" ("id", "document_number", "limit") VALUES (1, '11111111111', 50000), (2, '22222222222', 50000)`)
+	exec(t, db, `UPDATE "account" SET "credit_limit" = 80000 WHERE "id" = 2`)
+	exec(t, db, `INSERT INTO "journal_entry" ("id", "account_id", "description", "created_at") VALUES (1, 2, 'credit line granted', now())`)
+	exec(t, db, `INSERT INTO "posting" ("journal_entry_id", "account_id", "ledger", "amount") VALUES
+		(1, 2, 'credit_line', -80000), (1, 2, 'available', 50000), (1, 2, 'used', 30000)`)
+
+	migrateTo(t, db, 6, 6)
+	assert.Equal(t, int64(50000), creditLimit(t, db, 1))
+	assert.Equal(t, int64(80000), creditLimit(t, db, 2))
+
+	revert(t, db, 6)
+	assert.Equal(t, int64(0), creditLimit(t, db, 1))
+	assert.Equal(t, int64(80000), creditLimit(t, db, 2))
+}
+
+func TestMigration_0007_Ledger_Opening_Entries(t *testing.T) {
+	db := openSchema(t)
+	migrateTo(t, db, 1, 5)
+
+	// account 1 was opened before the ledger with 200.00 of its 500.00 used, account 2 after
+	exec(t, db, `INSERT INTO "account" ("id", "document_number", "limit", "credit_limit") VALUES (1, '11111111111', 30000, 50000), (2, '22222222222', 80000, 80000)`)
+	// the entry id comes from its sequence, the opening entries are numbered after it
+	exec(t, db, `INSERT INTO "journal_entry" ("account_id", "description", "created_at") VALUES (2, 'credit line granted', now())`)
+	exec(t, db, `INSERT INTO "posting" ("journal_entry_id", "account_id", "ledger", "amount")
+		SELECT "id", 2, 'credit_line', -80000 FROM "journal_entry" UNION ALL SELECT "id", 2, 'available', 80000 FROM "journal_entry"`)
+
+	migrateTo(t, db, 6, 7)
+
+	type balance struct {
+		Ledger string
+		Amount int64
+	}
+
+	balances := func(account uint) []balance {
+		var rows []balance
+		assert.NoError(t, db.Raw(`SELECT "ledger", SUM("amount") AS "amount" FROM "posting" WHERE "account_id" = ? GROUP BY "ledger" ORDER BY "ledger"`, account).
+			Scan(&rows).Error)
+		return rows
+	}
+
+	assert.Equal(t, []balance{{"available", 30000}, {"credit_line", -50000}, {"used", 20000}}, balances(1))
+	assert.Equal(t, []balance{{"available", 80000}, {"credit_line", -80000}}, balances(2))
+
+	revert(t, db, 7)
+	assert.Empty(t, balances(1))
+	assert.Equal(t, []balance{{"available", 80000}, {"credit_line", -80000}}, balances(2))
+}
+
+func TestMigration_0009_Idempotency_Key_Client(t *testing.T) {
+	db := openSchema(t)
+	migrateTo(t, db, 1, 8)
+
+	exec(t, db, `INSERT INTO "account" ("id", "document_number", "limit") VALUES (1, '11111111111', 50000)`)
+	exec(t, db, `INSERT INTO "operation" ("id", "description", "debit") VALUES (1, 'purchase', true)`)
+	exec(t, db, `INSERT INTO "transaction" ("account_id", "operation_id", "amount", "created_by", "created_at") VALUES (1, 1, -1000, 'checkout', now())`)
+	exec(t, db, `INSERT INTO "idempotency_key" ("key", "hash", "transaction_id", "created_at", "expires_at")
+		SELECT 'key', 'hash', "id", now(), now() FROM "transaction"`)
+
+	migrateTo(t, db, 9, 9)
+
+	var client string
+	assert.NoError(t, db.Raw(`SELECT "client_id" FROM "idempotency_key" WHERE "key" = 'key'`).Scan(&client).Error)
+	assert.Equal(t, "checkout", client)
+
+	// another client may use the same key, the same client may not
+	exec(t, db, `INSERT INTO "idempotency_key" ("client_id", "key", "hash") VALUES ('backoffice', 'key', 'hash')`)
+	assert.Error(t, db.Exec(`INSERT INTO "idempotency_key" ("client_id", "key", "hash") VALUES ('checkout', 'key', 'hash')`).Error)
+
+	exec(t, db, `DELETE FROM "idempotency_key" WHERE "client_id" = 'backoffice'`)
+	revert(t, db, 9)
+	assert.Error(t, db.Exec(`INSERT INTO "idempotency_key" ("key", "hash") VALUES ('key', 'hash')`).Error)
+}
+
+func TestMigration_0010_Timestamptz(t *testing.T) {
+	db := openSchema(t)
+	migrateTo(t, db, 1, 9)
+
+	// the cycle closes at midnight of March 10th in America/Sao_Paulo, written as its wall
+	// clock, and a purchase is made at 21:30 the day before
+	exec(t, db, `INSERT INTO "account" ("id", "document_number", "limit") VALUES (1, '11111111111', 50000)`)
+	exec(t, db, `INSERT INTO "operation" ("id", "description", "debit") VALUES (1, 'purchase', true)`)
+	exec(t, db, `INSERT INTO "billing_cycle" ("account_id", "closing_day", "due_days", "next_closing_at") VALUES (1, 10, 10, '2022-03-10 00:00:00')`)
+	exec(t, db, `INSERT INTO "transaction" ("account_id", "operation_id", "amount", "created_at") VALUES (1, 1, -1000, '2022-03-09 21:30:00-03')`)
+
+	migrateTo(t, db, 10, 10)
+
+	var closing time.Time
+	assert.NoError(t, db.Raw(`SELECT "next_closing_at" FROM "billing_cycle" WHERE "account_id" = 1`).Scan(&closing).Error)
+	assert.True(t, time.Date(2022, time.March, 10, 3, 0, 0, 0, time.UTC).Equal(closing))
+
+	var count int64
+	assert.NoError(t, db.Raw(`SELECT COUNT(*) FROM "transaction" WHERE "created_at" < ?`, closing).Scan(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	revert(t, db, 10)
+	var naive string
+	assert.NoError(t, db.Raw(`SELECT "next_closing_at"::text FROM "billing_cycle" WHERE "account_id" = 1`).Scan(&naive).Error)
+	assert.Equal(t, "2022-03-10 00:00:00", naive)
+}
+
+func TestMigration_0013_Operation_Reversal(t *testing.T) {
+	db := openSchema(t)
+	migrateTo(t, db, 1, 12)
+
+	exec(t, db, `INSERT INTO "account" ("id", "document_number", "limit") VALUES (1, '11111111111', 50000)`)
+	exec(t, db, `INSERT INTO "operation" ("description", "debit") VALUES ('purchase', true)`)
+	exec(t, db, `INSERT INTO "transaction" ("id", "account_id", "operation_id", "amount", "created_at") VALUES (1, 1, 1, -1000, now())`)
+	exec(t, db, `INSERT INTO "transaction" ("id", "account_id", "operation_id", "amount", "parent_id", "created_at") VALUES (2, 1, 1, 400, 1, now())`)
+
+	migrateTo(t, db, 13, 13)
+
+	var reversal struct {
+		ID    uint
+		Debit bool
+	}
+	assert.NoError(t, db.Raw(`SELECT "id", "debit" FROM "operation" WHERE "reversal"`).Scan(&reversal).Error)
+	assert.NotZero(t, reversal.ID)
+	assert.False(t, reversal.Debit)
+
+	var operation uint
+	assert.NoError(t, db.Raw(`SELECT "operation_id" FROM "transaction" WHERE "id" = 2`).Scan(&operation).Error)
+	assert.Equal(t, reversal.ID, operation)
+	assert.NoError(t, db.Raw(`SELECT "operation_id" FROM "transaction" WHERE "id" = 1`).Scan(&operation).Error)
+	assert.Equal(t, uint(1), operation)
+
+	// a single operation is the reversal one
+	assert.Error(t, db.Exec(`INSERT INTO "operation" ("description", "reversal") VALUES ('ESTORNO', true)`).Error)
+
+	revert(t, db, 13)
+	assert.NoError(t, db.Raw(`SELECT "operation_id" FROM "transaction" WHERE "id" = 2`).Scan(&operation).Error)
+	assert.Equal(t, uint(1), operation)
+}
@@ -0,0 +1,369 @@
+package migration
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+const (
+	TableName = "schema_migrations"
+
+	// lockKey is the postgres advisory lock held while migrating, instances starting together
+	// wait for the first one instead of applying the same migration twice.
+	lockKey = 7412053
+)
+
+var (
+	ErrMigrationDirty   = xerrors.New("schema is dirty, a migration failed halfway: fix it by hand and force the version")
+	ErrMigrationPending = xerrors.New("schema has pending migrations, run the migrate command")
+	ErrMigrationVersion = xerrors.New("unknown migration version")
+)
+
+type (
+	// Status is a known migration and whether the database has it.
+	Status struct {
+		Version   uint       `json:"version"`
+		Name      string     `json:"name"`
+		Applied   bool       `json:"applied"`
+		Dirty     bool       `json:"dirty"`
+		AppliedAt *time.Time `json:"applied_at,omitempty"`
+	}
+
+	// applied is a row of the schema_migrations table.
+	applied struct {
+		Version   uint      `gorm:"column:version"`
+		Name      string    `gorm:"column:name"`
+		Dirty     bool      `gorm:"column:dirty"`
+		AppliedAt time.Time `gorm:"column:applied_at"`
+	}
+
+	Migrator struct {
+		logger     common.Logger
+		adapter    *gorm.DB
+		migrations []*Migration
+	}
+)
+
+func NewMigrator(logger common.Logger, adapter *gorm.DB, migrations []*Migration) *Migrator {
+	return &Migrator{
+		logger:     logger,
+		adapter:    adapter,
+		migrations: migrations,
+	}
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return m.migrate(ctx, m.latest())
+}
+
+// Down reverts the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return m.locked(ctx, func(conn *gorm.DB) error {
+		versions, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		if err := clean(versions); err != nil {
+			return err
+		}
+
+		for i := len(versions) - 1; i >= 0 && steps > 0; i-- {
+			if err := m.down(conn, versions[i].Version); err != nil {
+				return err
+			}
+
+			steps--
+		}
+
+		return nil
+	})
+}
+
+// To applies or reverts migrations until version is the last one applied, zero reverts them all.
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if version != 0 && m.find(version) == nil {
+		return ErrMigrationVersion
+	}
+
+	return m.migrate(ctx, version)
+}
+
+// Force records version as the last migration applied and clears the dirty flag, without
+// running anything. It is the way out once a failed migration was fixed by hand.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if version != 0 && m.find(version) == nil {
+		return ErrMigrationVersion
+	}
+
+	return m.locked(ctx, func(conn *gorm.DB) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`DELETE FROM "schema_migrations"`).Error; err != nil {
+				m.logger.Errorf("tx.Exec() failed with %s\n", err)
+				return err
+			}
+
+			now := time.Now()
+			for _, migration := range m.migrations {
+				if migration.Version > version {
+					break
+				}
+
+				if err := tx.Table(TableName).Create(&applied{Version: migration.Version, Name: migration.Name, AppliedAt: now}).Error; err != nil {
+					m.logger.Errorf("tx.Create() failed with %s\n", err)
+					return err
+				}
+			}
+
+			return nil
+		})
+	})
+}
+
+// Status lists the known migrations and the applied ones, a dirty version the binary doesn't
+// know about is listed as well.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	versions, err := m.applied(m.adapter.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]applied, len(versions))
+	for _, version := range versions {
+		byVersion[version.Version] = version
+	}
+
+	statuses := make([]*Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &Status{Version: migration.Version, Name: migration.Name}
+		if version, ok := byVersion[migration.Version]; ok {
+			appliedAt := version.AppliedAt
+			status.Applied = true
+			status.Dirty = version.Dirty
+			status.AppliedAt = &appliedAt
+			delete(byVersion, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for _, version := range versions {
+		if _, ok := byVersion[version.Version]; ok {
+			appliedAt := version.AppliedAt
+			statuses = append(statuses, &Status{Version: version.Version, Name: version.Name, Applied: true, Dirty: version.Dirty, AppliedAt: &appliedAt})
+		}
+	}
+
+	return statuses, nil
+}
+
+// Check fails when the schema is dirty or misses a migration, the API refuses to start then.
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if status.Dirty {
+			return ErrMigrationDirty
+		}
+	}
+
+	for _, status := range statuses {
+		if !status.Applied {
+			return ErrMigrationPending
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) migrate(ctx context.Context, target uint) error {
+	return m.locked(ctx, func(conn *gorm.DB) error {
+		versions, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		if err := clean(versions); err != nil {
+			return err
+		}
+
+		done := make(map[uint]bool, len(versions))
+		for i := len(versions) - 1; i >= 0; i-- {
+			if versions[i].Version <= target {
+				done[versions[i].Version] = true
+				continue
+			}
+
+			if err := m.down(conn, versions[i].Version); err != nil {
+				return err
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > target {
+				break
+			}
+
+			if done[migration.Version] {
+				continue
+			}
+
+			if err := m.up(conn, migration); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// up marks the version dirty before running it, the mark is only cleared in the same
+// transaction as the migration so a failure leaves it dirty for someone to look at.
+func (m *Migrator) up(conn *gorm.DB, migration *Migration) error {
+	m.logger.Infof("applying migration %d_%s\n", migration.Version, migration.Name)
+
+	err := conn.Exec(`INSERT INTO "schema_migrations" ("version", "name", "dirty", "applied_at") VALUES (?, ?, true, ?)`,
+		migration.Version, migration.Name, time.Now()).Error
+	if err != nil {
+		m.logger.Errorf("conn.Exec() failed with %s\n", err)
+		return err
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			m.logger.Errorf("migration %d_%s failed with %s\n", migration.Version, migration.Name, err)
+			return err
+		}
+
+		if err := tx.Exec(`UPDATE "schema_migrations" SET "dirty" = false WHERE "version" = ?`, migration.Version).Error; err != nil {
+			m.logger.Errorf("tx.Exec() failed with %s\n", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (m *Migrator) down(conn *gorm.DB, version uint) error {
+	migration := m.find(version)
+	if migration == nil {
+		return xerrors.Errorf("%d: %w", version, ErrMigrationVersion)
+	}
+
+	m.logger.Infof("reverting migration %d_%s\n", migration.Version, migration.Name)
+
+	if err := conn.Exec(`UPDATE "schema_migrations" SET "dirty" = true WHERE "version" = ?`, version).Error; err != nil {
+		m.logger.Errorf("conn.Exec() failed with %s\n", err)
+		return err
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			m.logger.Errorf("migration %d_%s failed with %s\n", migration.Version, migration.Name, err)
+			return err
+		}
+
+		if err := tx.Exec(`DELETE FROM "schema_migrations" WHERE "version" = ?`, version).Error; err != nil {
+			m.logger.Errorf("tx.Exec() failed with %s\n", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// locked runs fn on a single connection holding the advisory lock, creating the
+// schema_migrations table on the first run.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.adapter.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec(`SELECT pg_advisory_lock(?)`, lockKey).Error; err != nil {
+			m.logger.Errorf("pg_advisory_lock() failed with %s\n", err)
+			return err
+		}
+
+		defer func() {
+			if err := conn.Exec(`SELECT pg_advisory_unlock(?)`, lockKey).Error; err != nil {
+				m.logger.Errorf("pg_advisory_unlock() failed with %s\n", err)
+			}
+		}()
+
+		err := conn.Exec(`CREATE TABLE IF NOT EXISTS "schema_migrations" (
+			"version"    bigint PRIMARY KEY,
+			"name"       varchar(255) NOT NULL,
+			"dirty"      boolean NOT NULL DEFAULT false,
+			"applied_at" timestamp without time zone NOT NULL
+		)`).Error
+		if err != nil {
+			m.logger.Errorf("conn.Exec() failed with %s\n", err)
+			return err
+		}
+
+		return fn(conn)
+	})
+}
+
+// applied lists the versions recorded in schema_migrations, none when the table is missing.
+func (m *Migrator) applied(conn *gorm.DB) ([]applied, error) {
+	versions := make([]applied, 0)
+	if !conn.Migrator().HasTable(TableName) {
+		return versions, nil
+	}
+
+	if err := conn.Table(TableName).Order("version").Find(&versions).Error; err != nil {
+		m.logger.Errorf("conn.Find() failed with %s\n", err)
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (m *Migrator) latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) find(version uint) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+
+	return nil
+}
+
+func clean(versions []applied) error {
+	for _, version := range versions {
+		if version.Dirty {
+			return ErrMigrationDirty
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package migration
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var testMigrations = []*Migration{
+	{Version: 1, Name: "account", Up: "CREATE TABLE account ();", Down: "DROP TABLE account;"},
+	{Version: 2, Name: "card", Up: "CREATE TABLE card ();", Down: "DROP TABLE card;"},
+}
+
+func expectHasTable(dbmock sqlmock.Sqlmock, exists bool) {
+	count := 0
+	if exists {
+		count = 1
+	}
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM information_schema.tables`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+func expectLock(dbmock sqlmock.Sqlmock) {
+	dbmock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1)`)).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "schema_migrations"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(dbmock sqlmock.Sqlmock) {
+	dbmock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1)`)).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectApplied(dbmock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+	expectHasTable(dbmock, true)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations" ORDER BY version`)).WillReturnRows(rows)
+}
+
+func appliedRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"version", "name", "dirty", "applied_at"})
+}
+
+func newMigrator(t *testing.T, logger common.Logger) (*Migrator, sqlmock.Sqlmock) {
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = mockdb.Close() })
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return NewMigrator(logger, gormdb, testMigrations), dbmock
+}
+
+func TestMigrator_Up(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Infof("applying migration %d_%s\n", uint(2), "card")
+
+	migrator, dbmock := newMigrator(t, logger)
+	expectLock(dbmock)
+	expectApplied(dbmock, appliedRows().AddRow(1, "account", false, time.Now()))
+	dbmock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "schema_migrations" ("version", "name", "dirty", "applied_at") VALUES ($1, $2, true, $3)`)).
+		WithArgs(2, "card", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE card ();`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectExec(regexp.QuoteMeta(`UPDATE "schema_migrations" SET "dirty" = false WHERE "version" = $1`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectCommit()
+	expectUnlock(dbmock)
+
+	assert.NoError(t, migrator.Up(context.Background()))
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMigrator_Up_Failure_Leaves_Dirty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errExpected := errors.New("syntax error")
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Infof("applying migration %d_%s\n", uint(1), "account")
+	logger.EXPECT().Errorf("migration %d_%s failed with %s\n", uint(1), "account", errExpected)
+
+	migrator, dbmock := newMigrator(t, logger)
+	expectLock(dbmock)
+	expectApplied(dbmock, appliedRows())
+	dbmock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "schema_migrations"`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE account ();`)).WillReturnError(errExpected)
+	dbmock.ExpectRollback()
+	expectUnlock(dbmock)
+
+	assert.Equal(t, errExpected, migrator.Up(context.Background()))
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMigrator_Up_Dirty_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	migrator, dbmock := newMigrator(t, common.NewMockLogger(ctrl))
+	expectLock(dbmock)
+	expectApplied(dbmock, appliedRows().AddRow(1, "account", true, time.Now()))
+	expectUnlock(dbmock)
+
+	assert.Equal(t, ErrMigrationDirty, migrator.Up(context.Background()))
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Infof("reverting migration %d_%s\n", uint(2), "card")
+
+	migrator, dbmock := newMigrator(t, logger)
+	expectLock(dbmock)
+	expectApplied(dbmock, appliedRows().AddRow(1, "account", false, time.Now()).AddRow(2, "card", false, time.Now()))
+	dbmock.ExpectExec(regexp.QuoteMeta(`UPDATE "schema_migrations" SET "dirty" = true WHERE "version" = $1`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(`DROP TABLE card;`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "schema_migrations" WHERE "version" = $1`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectCommit()
+	expectUnlock(dbmock)
+
+	assert.NoError(t, migrator.Down(context.Background(), 1))
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMigrator_To_Unknown_Version(t *testing.T) {
+	migrator := NewMigrator(nil, nil, testMigrations)
+	assert.Equal(t, ErrMigrationVersion, migrator.To(context.Background(), 7))
+	assert.Equal(t, ErrMigrationVersion, migrator.Force(context.Background(), 7))
+}
+
+func TestMigrator_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appliedAt := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+	migrator, dbmock := newMigrator(t, common.NewMockLogger(ctrl))
+	expectApplied(dbmock, appliedRows().AddRow(1, "account", false, appliedAt))
+
+	statuses, err := migrator.Status(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*Status{
+		{Version: 1, Name: "account", Applied: true, AppliedAt: &appliedAt},
+		{Version: 2, Name: "card"},
+	}, statuses)
+}
+
+func TestMigrator_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cases := []struct {
+		rows     *sqlmock.Rows
+		expected error
+	}{
+		{
+			rows: appliedRows().AddRow(1, "account", false, time.Now()).AddRow(2, "card", false, time.Now()),
+		},
+		{
+			rows:     appliedRows().AddRow(1, "account", false, time.Now()),
+			expected: ErrMigrationPending,
+		},
+		{
+			rows:     appliedRows().AddRow(1, "account", false, time.Now()).AddRow(2, "card", true, time.Now()),
+			expected: ErrMigrationDirty,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run("", func(t *testing.T) {
+			migrator, dbmock := newMigrator(t, common.NewMockLogger(ctrl))
+			expectApplied(dbmock, tt.rows)
+			assert.Equal(t, tt.expected, migrator.Check(context.Background()))
+		})
+	}
+}
+
+func TestMigrator_Check_Missing_Table(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	migrator, dbmock := newMigrator(t, common.NewMockLogger(ctrl))
+	expectHasTable(dbmock, false)
+	assert.Equal(t, ErrMigrationPending, migrator.Check(context.Background()))
+}
@@ -1,23 +1,100 @@
 package persistence
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"net/url"
+	"strconv"
+)
 
 const (
 	PaginatorPageDefault = 1
 	PaginatorSizeDefault = 10
+	PaginatorSizeMax     = 100
 )
 
+// Pagination is the envelope metadata of a paginated collection. Page is zero when the
+// collection was fetched by cursor, in which case NextCursor and PrevCursor carry the ids
+// to continue from.
+type Pagination struct {
+	Page       int    `json:"page,omitempty"`
+	Size       int    `json:"size"`
+	Total      int64  `json:"total"`
+	NextCursor uint   `json:"next_cursor,omitempty"`
+	PrevCursor uint   `json:"prev_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+}
+
 func Paginator(page int, size int) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		if page == 0 {
-			page = PaginatorPageDefault
+		page, size := Normalize(page, size)
+		offset := (page - 1) * size
+		return db.Offset(offset).Limit(size)
+	}
+}
+
+// Normalize applies the defaults to an unset page or size, a size above the maximum is
+// capped instead of being discarded.
+func Normalize(page int, size int) (int, int) {
+	if page <= 0 {
+		page = PaginatorPageDefault
+	}
+
+	if size <= 0 {
+		size = PaginatorSizeDefault
+	}
+
+	if size > PaginatorSizeMax {
+		size = PaginatorSizeMax
+	}
+
+	return page, size
+}
+
+func NewPagination(page int, size int, total int64) Pagination {
+	page, size = Normalize(page, size)
+	return Pagination{Page: page, Size: size, Total: total}
+}
+
+// Link fills the next and prev links from the request url, keeping its other query
+// parameters. Offset pages link by page number, cursor pages by after and before. An offset
+// page with a NextCursor links its next page by cursor too. Cursor links leave sort out, cursor
+// pages are read in id order and take no sort.
+func (p *Pagination) Link(u *url.URL) {
+	if p.Page == 0 {
+		if p.NextCursor != 0 {
+			p.Next = link(u, "after", p.NextCursor)
 		}
 
-		if size == 0 || size > 100 {
-			size = PaginatorSizeDefault
+		if p.PrevCursor != 0 {
+			p.Prev = link(u, "before", p.PrevCursor)
 		}
 
-		offset := (page - 1) * size
-		return db.Offset(offset).Limit(size)
+		return
+	}
+
+	switch {
+	case p.NextCursor != 0:
+		p.Next = link(u, "after", p.NextCursor)
+	case int64(p.Page*p.Size) < p.Total:
+		p.Next = link(u, "page", uint(p.Page+1))
 	}
+
+	if p.Page > 1 {
+		p.Prev = link(u, "page", uint(p.Page-1))
+	}
+}
+
+func link(u *url.URL, key string, value uint) string {
+	query := u.Query()
+	query.Del("page")
+	query.Del("after")
+	query.Del("before")
+	if key != "page" {
+		query.Del("sort")
+	}
+
+	query.Set(key, strconv.FormatUint(uint64(value), 10))
+
+	return (&url.URL{Path: u.Path, RawQuery: query.Encode()}).String()
 }
@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	page, size := Normalize(0, 0)
+	assert.Equal(t, PaginatorPageDefault, page)
+	assert.Equal(t, PaginatorSizeDefault, size)
+
+	page, size = Normalize(3, 500)
+	assert.Equal(t, 3, page)
+	assert.Equal(t, PaginatorSizeMax, size)
+}
+
+func TestPagination_Link(t *testing.T) {
+	u, _ := url.Parse("/transactions?account_id=1&page=2&size=10")
+
+	pagination := NewPagination(2, 10, 25)
+	pagination.Link(u)
+	assert.Equal(t, "/transactions?account_id=1&page=3&size=10", pagination.Next)
+	assert.Equal(t, "/transactions?account_id=1&page=1&size=10", pagination.Prev)
+
+	pagination = NewPagination(3, 10, 25)
+	pagination.Link(u)
+	assert.Empty(t, pagination.Next)
+	assert.Equal(t, "/transactions?account_id=1&page=2&size=10", pagination.Prev)
+}
+
+func TestPagination_Link_Cursor(t *testing.T) {
+	u, _ := url.Parse("/transactions?after=10&size=2")
+
+	pagination := Pagination{Size: 2, NextCursor: 12, PrevCursor: 11}
+	pagination.Link(u)
+	assert.Equal(t, "/transactions?after=12&size=2", pagination.Next)
+	assert.Equal(t, "/transactions?before=11&size=2", pagination.Prev)
+}
+
+func TestPagination_Link_Page_Cursor(t *testing.T) {
+	u, _ := url.Parse("/transactions?account_id=1&page=2&size=10")
+
+	pagination := NewPagination(2, 10, 25)
+	pagination.NextCursor = 20
+	pagination.Link(u)
+	assert.Equal(t, "/transactions?account_id=1&after=20&size=10", pagination.Next)
+	assert.Equal(t, "/transactions?account_id=1&page=1&size=10", pagination.Prev)
+
+	u, _ = url.Parse("/transactions?page=2&size=10&sort=id")
+	pagination.Link(u)
+	assert.Equal(t, "/transactions?after=20&size=10", pagination.Next)
+	assert.Equal(t, "/transactions?page=1&size=10&sort=id", pagination.Prev)
+}
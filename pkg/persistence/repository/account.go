@@ -5,7 +5,9 @@ import (
 	"golang.org/x/net/context"
 	"golang.org/x/xerrors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
 	"ms/card/pkg/telemetry/jaeger"
 
 	"ms/card/pkg/persistence"
@@ -14,22 +16,40 @@ import (
 )
 
 var (
-	ErrAccountCreate              = xerrors.New("failed to create new account")
-	ErrAccountCreateAlreadyExists = xerrors.New("account already exists")
-	ErrAccountCreateNotFound      = xerrors.New("account not found")
-	ErrAccountFindByID            = xerrors.New("failed fetch the account")
+	ErrAccountCreate              = domain.Internal("account_create_failed", "failed to create new account")
+	ErrAccountCreateAlreadyExists = domain.Conflict("account_already_exists", "account already exists")
+	ErrAccountCreateNotFound      = domain.NotFound("account_not_found", "account not found")
+	ErrAccountFindByID            = domain.Internal("account_find_failed", "failed fetch the account")
+	ErrAccountCount               = domain.Internal("account_count_failed", "failed to count the accounts")
+	ErrAccountUpdate              = domain.Internal("account_update_failed", "failed to update the account")
+	ErrAccountDelete              = domain.Internal("account_delete_failed", "failed to delete the account")
 )
 
 const (
-	UniqueKeyCodeConstraint = "23505"
+	UniqueKeyCodeConstraint  = "23505"
+	ForeignKeyCodeConstraint = "23503"
 )
 
+var accountColumns = []string{
+	"id",
+	"document_number",
+	"document_type",
+	"credit_limit",
+	"limit",
+	"currency",
+	"status",
+	"created_by",
+}
+
 type (
 	Accounts interface {
 		Create(ctx context.Context, structure entity.Account) (*entity.Account, error)
 		UpdateLimit(ctx context.Context, structure *entity.Account) error
+		UpdateStatus(ctx context.Context, structure *entity.Account) error
+		Delete(ctx context.Context, id uint) error
 		FindByID(ctx context.Context, id uint) (*entity.Account, error)
-		FindAll(ctx context.Context, filters filter.AccountCollection) ([]*entity.Account, error)
+		FindByIDForUpdate(ctx context.Context, id uint) (*entity.Account, error)
+		FindAll(ctx context.Context, filters filter.AccountCollection) (*entity.AccountCollection, error)
 	}
 
 	Account struct {
@@ -49,7 +69,7 @@ func (a *Account) Create(ctx context.Context, structure entity.Account) (*entity
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
-	tx := a.adapter.WithContext(ctx)
+	tx := session(ctx, a.adapter)
 	if result := tx.Create(&structure); result.Error != nil {
 		a.logger.Errorf("tx.Create() failed with %s\n", result.Error)
 		var err *pgconn.PgError
@@ -68,8 +88,8 @@ func (a *Account) FindByID(ctx context.Context, id uint) (*entity.Account, error
 	defer span.End()
 
 	var account entity.Account
-	tx := a.adapter.WithContext(ctx)
-	if result := tx.Select([]string{"id", "document_number", "limit"}).First(&account, id); result.Error != nil {
+	tx := session(ctx, a.adapter)
+	if result := tx.Select(accountColumns).First(&account, id); result.Error != nil {
 		a.logger.Errorf("tx.First() failed with %s\n", result.Error)
 		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, ErrAccountCreateNotFound
@@ -81,29 +101,90 @@ func (a *Account) FindByID(ctx context.Context, id uint) (*entity.Account, error
 	return &account, nil
 }
 
-func (a *Account) FindAll(ctx context.Context, filters filter.AccountCollection) ([]*entity.Account, error) {
+// FindByIDForUpdate locks the account row until the surrounding unit of work finishes,
+// concurrent limit changes on the same account are serialized.
+func (a *Account) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Account, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var account entity.Account
+	tx := session(ctx, a.adapter).Clauses(clause.Locking{Strength: "UPDATE"})
+	if result := tx.Select(accountColumns).First(&account, id); result.Error != nil {
+		a.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrAccountCreateNotFound
+		}
+
+		return nil, ErrAccountFindByID
+	}
+
+	return &account, nil
+}
+
+func (a *Account) FindAll(ctx context.Context, filters filter.AccountCollection) (*entity.AccountCollection, error) {
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
 	accounts := make([]*entity.Account, 0)
-	tx := a.adapter.WithContext(ctx)
-	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).Select([]string{
-		"id",
-		"document_number",
-		"limit",
-	}).Find(&accounts)
-
-	return accounts, find.Error
+	tx := session(ctx, a.adapter)
+	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).Select(accountColumns).Order(filters.Sort.Order()).Find(&accounts)
+
+	if find.Error != nil {
+		return nil, find.Error
+	}
+
+	var total int64
+	if count := tx.Model(&entity.Account{}).Scopes(filters.Filter()).Count(&total); count.Error != nil {
+		a.logger.Errorf("tx.Count() failed with %s\n", count.Error)
+		return nil, ErrAccountCount
+	}
+
+	return &entity.AccountCollection{
+		Pagination: persistence.NewPagination(filters.Page, filters.Size, total),
+		Data:       accounts,
+	}, nil
 }
 
+// UpdateLimit only writes the credit and available limits, the rest of the row is left as
+// it is.
 func (a *Account) UpdateLimit(ctx context.Context, structure *entity.Account) error {
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
-	tx := a.adapter.WithContext(ctx)
-	if err := tx.Save(structure); err.Error != nil {
+	tx := session(ctx, a.adapter)
+	if err := tx.Model(structure).Updates(map[string]interface{}{
+		"credit_limit": structure.CreditLimit,
+		"limit":        structure.AvailableLimit,
+	}); err.Error != nil {
 		return err.Error
 	}
 
 	return nil
 }
+
+func (a *Account) UpdateStatus(ctx context.Context, structure *entity.Account) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, a.adapter)
+	if result := tx.Model(structure).Update("status", structure.Status); result.Error != nil {
+		a.logger.Errorf("tx.Update() failed with %s\n", result.Error)
+		return ErrAccountUpdate
+	}
+
+	return nil
+}
+
+// Delete is soft, the row is kept with deleted_at set and is no longer found.
+func (a *Account) Delete(ctx context.Context, id uint) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, a.adapter)
+	if result := tx.Delete(&entity.Account{}, id); result.Error != nil {
+		a.logger.Errorf("tx.Delete() failed with %s\n", result.Error)
+		return ErrAccountDelete
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrAccountChangeCreate = domain.Internal("account_change_create_failed", "failed to create new account change")
+	ErrAccountChangeFind   = domain.Internal("account_change_find_failed", "failed fetch the account changes")
+)
+
+type (
+	// AccountChanges is append-only, the trail is never updated or deleted.
+	AccountChanges interface {
+		Create(ctx context.Context, structure entity.AccountChange) (*entity.AccountChange, error)
+		FindAll(ctx context.Context, filters filter.AccountChangeCollection) ([]*entity.AccountChange, error)
+	}
+
+	AccountChange struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewAccountChange(logger common.Logger, adapter *gorm.DB) *AccountChange {
+	return &AccountChange{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (a *AccountChange) Create(ctx context.Context, structure entity.AccountChange) (*entity.AccountChange, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, a.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		a.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		return nil, ErrAccountChangeCreate
+	}
+
+	return &structure, nil
+}
+
+func (a *AccountChange) FindAll(ctx context.Context, filters filter.AccountChangeCollection) ([]*entity.AccountChange, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	changes := make([]*entity.AccountChange, 0)
+	tx := session(ctx, a.adapter)
+	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).Order("id").Find(&changes)
+	if find.Error != nil {
+		a.logger.Errorf("tx.Find() failed with %s\n", find.Error)
+		return nil, ErrAccountChangeFind
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/account_change.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	filter "ms/card/pkg/persistence/filter"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockAccountChanges is a mock of AccountChanges interface.
+type MockAccountChanges struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountChangesMockRecorder
+}
+
+// MockAccountChangesMockRecorder is the mock recorder for MockAccountChanges.
+type MockAccountChangesMockRecorder struct {
+	mock *MockAccountChanges
+}
+
+// NewMockAccountChanges creates a new mock instance.
+func NewMockAccountChanges(ctrl *gomock.Controller) *MockAccountChanges {
+	mock := &MockAccountChanges{ctrl: ctrl}
+	mock.recorder = &MockAccountChangesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountChanges) EXPECT() *MockAccountChangesMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAccountChanges) Create(ctx context.Context, structure entity.AccountChange) (*entity.AccountChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, structure)
+	ret0, _ := ret[0].(*entity.AccountChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAccountChangesMockRecorder) Create(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountChanges)(nil).Create), ctx, structure)
+}
+
+// FindAll mocks base method.
+func (m *MockAccountChanges) FindAll(ctx context.Context, filters filter.AccountChangeCollection) ([]*entity.AccountChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
+	ret0, _ := ret[0].([]*entity.AccountChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAccountChangesMockRecorder) FindAll(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAccountChanges)(nil).FindAll), ctx, filters)
+}
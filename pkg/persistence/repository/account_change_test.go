@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAccountChangeRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "account_change" ("account_id","field","from_value","to_value","reason","created_by","created_at")
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+		RETURNING "id"
+	`)).WithArgs(1, "limit", "5000", "8000", "income review", "backoffice", now).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectCommit()
+
+	accountChangeRepository := NewAccountChange(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	change, err := accountChangeRepository.Create(ctx, entity.AccountChange{
+		Account:   1,
+		Field:     entity.AccountChangeLimit,
+		From:      "5000",
+		To:        "8000",
+		Reason:    "income review",
+		CreatedBy: "backoffice",
+		CreatedAt: now,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), change.ID)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAccountChangeRepository_Create_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "account_change"`)).WillReturnError(gorm.ErrInvalidData)
+	dbmock.ExpectRollback()
+
+	accountChangeRepository := NewAccountChange(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	change, err := accountChangeRepository.Create(ctx, entity.AccountChange{Account: 1})
+	assert.Nil(t, change)
+	assert.EqualError(t, err, ErrAccountChangeCreate.Error())
+}
+
+func TestAccountChangeRepository_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "account_change" WHERE account_id = $1 AND field = $2 ORDER BY id LIMIT 10
+	`)).WithArgs(1, "status").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "field", "from_value", "to_value"}).AddRow(uint(2), uint(1), "status", "active", "blocked"),
+	)
+
+	accountChangeRepository := NewAccountChange(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	changes, err := accountChangeRepository.FindAll(ctx, filter.AccountChangeCollection{Account: 1, Field: "status"})
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, "active", changes[0].From)
+		assert.Equal(t, "blocked", changes[0].To)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
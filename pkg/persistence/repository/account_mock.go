@@ -51,11 +51,25 @@ func (mr *MockAccountsMockRecorder) Create(ctx, structure interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccounts)(nil).Create), ctx, structure)
 }
 
+// Delete mocks base method.
+func (m *MockAccounts) Delete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAccountsMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAccounts)(nil).Delete), ctx, id)
+}
+
 // FindAll mocks base method.
-func (m *MockAccounts) FindAll(ctx context.Context, filters filter.AccountCollection) ([]*entity.Account, error) {
+func (m *MockAccounts) FindAll(ctx context.Context, filters filter.AccountCollection) (*entity.AccountCollection, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
-	ret0, _ := ret[0].([]*entity.Account)
+	ret0, _ := ret[0].(*entity.AccountCollection)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -81,6 +95,21 @@ func (mr *MockAccountsMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAccounts)(nil).FindByID), ctx, id)
 }
 
+// FindByIDForUpdate mocks base method.
+func (m *MockAccounts) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
+func (mr *MockAccountsMockRecorder) FindByIDForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockAccounts)(nil).FindByIDForUpdate), ctx, id)
+}
+
 // UpdateLimit mocks base method.
 func (m *MockAccounts) UpdateLimit(ctx context.Context, structure *entity.Account) error {
 	m.ctrl.T.Helper()
@@ -94,3 +123,17 @@ func (mr *MockAccountsMockRecorder) UpdateLimit(ctx, structure interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLimit", reflect.TypeOf((*MockAccounts)(nil).UpdateLimit), ctx, structure)
 }
+
+// UpdateStatus mocks base method.
+func (m *MockAccounts) UpdateStatus(ctx context.Context, structure *entity.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, structure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAccountsMockRecorder) UpdateStatus(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAccounts)(nil).UpdateStatus), ctx, structure)
+}
@@ -10,6 +10,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"ms/card/pkg/common"
+	"ms/card/pkg/persistence"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"regexp"
@@ -32,10 +33,10 @@ func TestAccountRepository_Create(t *testing.T) {
 
 	dbmock.ExpectBegin()
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO "account" ("document_number","limit") 
-		VALUES ($1,$2) 
+		INSERT INTO "account" ("document_number","document_type","credit_limit","limit","currency","status","created_by","deleted_at") 
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8) 
 		RETURNING "id"
-	`)).WithArgs("64715245019", int64(2000)).WillReturnRows(
+	`)).WithArgs("64715245019", "cpf", int64(2000), int64(2000), "BRL", "active", "", nil).WillReturnRows(
 		sqlmock.NewRows([]string{"id"}).AddRow("1"),
 	)
 	dbmock.ExpectCommit()
@@ -45,12 +46,14 @@ func TestAccountRepository_Create(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 	account, err := accountRepository.Create(ctx, entity.Account{
-		Document: "64715245019",
-		Limit:    2000,
+		Document:       "64715245019",
+		DocumentType:   "cpf",
+		CreditLimit:    2000,
+		AvailableLimit: 2000,
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, uint(1), account.ID)
-	assert.Equal(t, int64(2000), account.Limit)
+	assert.Equal(t, int64(2000), account.AvailableLimit)
 
 	if err := dbmock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -143,12 +146,12 @@ func TestAccountRepository_FindByID(t *testing.T) {
 	assert.NoError(t, err)
 
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","document_number","limit"
+		SELECT "id","document_number","document_type","credit_limit","limit","currency","status","created_by"
 		FROM "account" 
-		WHERE "account"."id" = $1 
+		WHERE "account"."id" = $1 AND "account"."deleted_at" IS NULL 
 		ORDER BY "account"."id" 
 		LIMIT 1
-	`)).WithArgs(uint(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "limit"}).AddRow(uint(1), "64715245019", int64(2000)))
+	`)).WithArgs(uint(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "credit_limit", "limit"}).AddRow(uint(1), "64715245019", int64(2000), int64(2000)))
 
 	accountRepository := NewAccount(logger, gormdb)
 
@@ -160,7 +163,7 @@ func TestAccountRepository_FindByID(t *testing.T) {
 
 	assert.Equal(t, uint(1), account.ID)
 	assert.Equal(t, "64715245019", account.Document)
-	assert.Equal(t, int64(2000), account.Limit)
+	assert.Equal(t, int64(2000), account.AvailableLimit)
 
 	if err := dbmock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -182,9 +185,9 @@ func TestAccountRepository_FindByID_Error(t *testing.T) {
 	assert.NoError(t, err)
 
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","document_number","limit"
+		SELECT "id","document_number","document_type","credit_limit","limit","currency","status","created_by"
 		FROM "account" 
-		WHERE "account"."id" = $1 
+		WHERE "account"."id" = $1 AND "account"."deleted_at" IS NULL 
 		ORDER BY "account"."id" 
 		LIMIT 1
 	`)).WithArgs(uint(1)).WillReturnError(ErrAccountFindByID)
@@ -217,9 +220,9 @@ func TestAccountRepository_FindByID_RecordNotFound_Error(t *testing.T) {
 	assert.NoError(t, err)
 
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","document_number","limit"
+		SELECT "id","document_number","document_type","credit_limit","limit","currency","status","created_by"
 		FROM "account" 
-		WHERE "account"."id" = $1 
+		WHERE "account"."id" = $1 AND "account"."deleted_at" IS NULL 
 		ORDER BY "account"."id" 
 		LIMIT 1
 	`)).WithArgs(uint(1)).WillReturnError(gorm.ErrRecordNotFound)
@@ -237,6 +240,41 @@ func TestAccountRepository_FindByID_RecordNotFound_Error(t *testing.T) {
 	}
 }
 
+func TestAccountRepository_FindByIDForUpdate_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","document_number","document_type","credit_limit","limit","currency","status","created_by"
+		FROM "account"
+		WHERE "account"."id" = $1 AND "account"."deleted_at" IS NULL
+		ORDER BY "account"."id"
+		LIMIT 1 FOR UPDATE
+	`)).WithArgs(uint(1)).WillReturnError(gorm.ErrRecordNotFound)
+
+	accountRepository := NewAccount(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	account, err := accountRepository.FindByIDForUpdate(ctx, 1)
+	assert.Nil(t, account)
+	assert.EqualError(t, err, ErrAccountCreateNotFound.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestAccountRepository_Collection(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -251,12 +289,17 @@ func TestAccountRepository_Collection(t *testing.T) {
 	assert.NoError(t, err)
 
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","document_number","limit"
+		SELECT "id","document_number","document_type","credit_limit","limit","currency","status","created_by"
 		FROM "account"
+		WHERE "account"."deleted_at" IS NULL
+		ORDER BY id
 		LIMIT 10
-	`)).WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "limit"}).
-		AddRow(uint(1), "64715245019", int64(2000)).
-		AddRow(uint(2), "11115245019", int64(2000)),
+	`)).WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "credit_limit", "limit"}).
+		AddRow(uint(1), "64715245019", int64(2000), int64(2000)).
+		AddRow(uint(2), "11115245019", int64(2000), int64(2000)),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "account" WHERE "account"."deleted_at" IS NULL`)).WillReturnRows(
+		sqlmock.NewRows([]string{"count"}).AddRow(int64(12)),
 	)
 
 	accountRepository := NewAccount(logger, gormdb)
@@ -264,8 +307,89 @@ func TestAccountRepository_Collection(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 	accounts, err := accountRepository.FindAll(ctx, filter.AccountCollection{})
-	assert.Len(t, accounts, 2)
 	assert.NoError(t, err)
+	assert.Len(t, accounts.Data, 2)
+	assert.Equal(t, persistence.Pagination{Page: 1, Size: 10, Total: 12}, accounts.Pagination)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAccountRepository_Collection_Sorted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","document_number","document_type","credit_limit","limit","currency","status","created_by"
+		FROM "account"
+		WHERE "account"."deleted_at" IS NULL
+		ORDER BY "limit" DESC, id DESC
+		LIMIT 10
+	`)).WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "credit_limit", "limit"}).
+		AddRow(uint(2), "11115245019", int64(3000), int64(3000)).
+		AddRow(uint(1), "64715245019", int64(2000), int64(2000)),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "account" WHERE "account"."deleted_at" IS NULL`)).WillReturnRows(
+		sqlmock.NewRows([]string{"count"}).AddRow(int64(2)),
+	)
+
+	accountRepository := NewAccount(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	accounts, err := accountRepository.FindAll(ctx, filter.AccountCollection{Sort: filter.Sort{Column: `"limit"`, Descending: true}})
+	assert.NoError(t, err)
+	if assert.Len(t, accounts.Data, 2) {
+		assert.Equal(t, uint(2), accounts.Data[0].ID)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAccountRepository_Collection_Count_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf("tx.Count() failed with %s\n", gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","document_number","document_type","credit_limit","limit","currency","status","created_by"
+		FROM "account"
+		WHERE document_number LIKE $1 AND "account"."deleted_at" IS NULL
+		ORDER BY id
+		LIMIT 100
+	`)).WithArgs("%647%").WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "credit_limit", "limit"}))
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "account" WHERE document_number LIKE $1 AND "account"."deleted_at" IS NULL`)).
+		WithArgs("%647%").
+		WillReturnError(errors.New("count failed"))
+
+	accountRepository := NewAccount(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	accounts, err := accountRepository.FindAll(ctx, filter.AccountCollection{Size: 500, Document: "647."})
+	assert.Nil(t, accounts)
+	assert.ErrorIs(t, err, ErrAccountCount)
 
 	if err := dbmock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -287,7 +411,7 @@ func TestAccount_UpdateLimit(t *testing.T) {
 
 	dbmock.ExpectBegin()
 	dbmock.ExpectExec(regexp.QuoteMeta(
-		`UPDATE "account" SET "document_number"=$1,"limit"=$2 WHERE "id" = $3`,
+		`UPDATE "account" SET "credit_limit"=$1,"limit"=$2 WHERE "account"."deleted_at" IS NULL AND "id" = $3`,
 	)).WillReturnResult(sqlmock.NewResult(1, 1))
 	dbmock.ExpectCommit()
 
@@ -296,15 +420,16 @@ func TestAccount_UpdateLimit(t *testing.T) {
 	defer cancel()
 
 	accountEntity := &entity.Account{
-		ID:       1,
-		Document: "64715245019",
-		Limit:    2000,
+		ID:             1,
+		Document:       "64715245019",
+		CreditLimit:    2000,
+		AvailableLimit: 2000,
 	}
 
 	err = accountRepository.UpdateLimit(ctx, accountEntity)
 	assert.NoError(t, err)
 	assert.Equal(t, uint(1), accountEntity.ID)
-	assert.Equal(t, int64(2000), accountEntity.Limit)
+	assert.Equal(t, int64(2000), accountEntity.AvailableLimit)
 
 	if err := dbmock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -327,7 +452,7 @@ func TestAccount_UpdateLimit_Error(t *testing.T) {
 
 	errExpected := errors.New("update err")
 	dbmock.ExpectBegin()
-	dbmock.ExpectExec(regexp.QuoteMeta(`UPDATE "account" SET "document_number"=$1,"limit"=$2 WHERE "id" = $3`)).WillReturnError(errExpected)
+	dbmock.ExpectExec(regexp.QuoteMeta(`UPDATE "account" SET "credit_limit"=$1,"limit"=$2 WHERE "account"."deleted_at" IS NULL AND "id" = $3`)).WillReturnError(errExpected)
 	dbmock.ExpectRollback()
 
 	accountRepository := NewAccount(logger, gormdb)
@@ -335,9 +460,10 @@ func TestAccount_UpdateLimit_Error(t *testing.T) {
 	defer cancel()
 
 	accountEntity := &entity.Account{
-		ID:       1,
-		Document: "64715245019",
-		Limit:    2000,
+		ID:             1,
+		Document:       "64715245019",
+		CreditLimit:    2000,
+		AvailableLimit: 2000,
 	}
 
 	err = accountRepository.UpdateLimit(ctx, accountEntity)
@@ -347,3 +473,90 @@ func TestAccount_UpdateLimit_Error(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestAccountRepository_UpdateStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE "account" SET "status"=$1 WHERE "account"."deleted_at" IS NULL AND "id" = $2`,
+	)).WithArgs("blocked", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectCommit()
+
+	accountRepository := NewAccount(logger, gormdb)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	err = accountRepository.UpdateStatus(ctx, &entity.Account{ID: 1, Status: entity.AccountStatusBlocked})
+	assert.NoError(t, err)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAccountRepository_UpdateStatus_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf("tx.Update() failed with %s\n", gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(`UPDATE "account" SET "status"=$1`)).WillReturnError(errors.New("update err"))
+	dbmock.ExpectRollback()
+
+	accountRepository := NewAccount(logger, gormdb)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	err = accountRepository.UpdateStatus(ctx, &entity.Account{ID: 1, Status: entity.AccountStatusBlocked})
+	assert.EqualError(t, err, ErrAccountUpdate.Error())
+}
+
+func TestAccountRepository_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE "account" SET "deleted_at"=$1 WHERE "account"."id" = $2 AND "account"."deleted_at" IS NULL`,
+	)).WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectCommit()
+
+	accountRepository := NewAccount(logger, gormdb)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	assert.NoError(t, accountRepository.Delete(ctx, 1))
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/jackc/pgconn"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrAPIKeyCreate              = domain.Internal("api_key_create_failed", "failed to create new api key")
+	ErrAPIKeyCreateAlreadyExists = domain.Conflict("api_key_already_exists", "api key already exists")
+	ErrAPIKeyNotFound            = domain.NotFound("api_key_not_found", "api key not found")
+	ErrAPIKeyFindByHash          = domain.Internal("api_key_find_failed", "failed fetch the api key")
+)
+
+type (
+	APIKeys interface {
+		Create(ctx context.Context, structure entity.APIKey) (*entity.APIKey, error)
+		FindByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+	}
+
+	APIKey struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewAPIKey(logger common.Logger, adapter *gorm.DB) *APIKey {
+	return &APIKey{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (a *APIKey) Create(ctx context.Context, structure entity.APIKey) (*entity.APIKey, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, a.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		a.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		var err *pgconn.PgError
+		if xerrors.As(result.Error, &err) && err.Code == UniqueKeyCodeConstraint {
+			return nil, ErrAPIKeyCreateAlreadyExists
+		}
+
+		return nil, ErrAPIKeyCreate
+	}
+
+	return &structure, nil
+}
+
+func (a *APIKey) FindByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var key entity.APIKey
+	tx := session(ctx, a.adapter)
+	if result := tx.Where("hash = ?", hash).First(&key); result.Error != nil {
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+
+		a.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		return nil, ErrAPIKeyFindByHash
+	}
+
+	return &key, nil
+}
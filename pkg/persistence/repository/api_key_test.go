@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "api_key" ("client_id","hash","scopes","created_at","revoked_at")
+		VALUES ($1,$2,$3,$4,$5)
+		RETURNING "id"
+	`)).WithArgs("checkout", "5e88", "accounts:read,transactions:create", now, nil).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectCommit()
+
+	apiKeyRepository := NewAPIKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	key, err := apiKeyRepository.Create(ctx, entity.APIKey{
+		Client:    "checkout",
+		Hash:      "5e88",
+		Scopes:    entity.List{"accounts:read", "transactions:create"},
+		CreatedAt: now,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), key.ID)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAPIKeyRepository_Create_AlreadyExists_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "api_key"`)).WillReturnError(&pgconn.PgError{Code: UniqueKeyCodeConstraint})
+	dbmock.ExpectRollback()
+
+	apiKeyRepository := NewAPIKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	key, err := apiKeyRepository.Create(ctx, entity.APIKey{Client: "checkout", Hash: "5e88"})
+	assert.Nil(t, key)
+	assert.ErrorIs(t, err, ErrAPIKeyCreateAlreadyExists)
+}
+
+func TestAPIKeyRepository_FindByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "api_key"
+		WHERE hash = $1
+		ORDER BY "api_key"."id"
+		LIMIT 1
+	`)).WithArgs("5e88").WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "hash", "scopes"}).AddRow(uint(1), "checkout", "5e88", "transactions:create"))
+
+	apiKeyRepository := NewAPIKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	key, err := apiKeyRepository.FindByHash(ctx, "5e88")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "checkout", key.Client)
+		assert.Equal(t, entity.List{"transactions:create"}, key.Scopes)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAPIKeyRepository_FindByHash_NotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_key"`)).WillReturnError(gorm.ErrRecordNotFound)
+
+	apiKeyRepository := NewAPIKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	key, err := apiKeyRepository.FindByHash(ctx, "5e88")
+	assert.Nil(t, key)
+	assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrAuthorizationCreate = domain.Internal("authorization_create_failed", "failed to create new authorization")
+	ErrAuthorizationFind   = domain.Internal("authorization_find_failed", "failed fetch the authorizations")
+	ErrAuthorizationCount  = domain.Internal("authorization_count_failed", "failed to count the authorizations")
+)
+
+type (
+	Authorizations interface {
+		Create(ctx context.Context, structure entity.Authorization) (*entity.Authorization, error)
+		FindAll(ctx context.Context, filters filter.AuthorizationCollection) (*entity.AuthorizationCollection, error)
+	}
+
+	Authorization struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewAuthorization(logger common.Logger, adapter *gorm.DB) *Authorization {
+	return &Authorization{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (a *Authorization) Create(ctx context.Context, structure entity.Authorization) (*entity.Authorization, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, a.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		a.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		return nil, ErrAuthorizationCreate
+	}
+
+	return &structure, nil
+}
+
+func (a *Authorization) FindAll(ctx context.Context, filters filter.AuthorizationCollection) (*entity.AuthorizationCollection, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	authorizations := make([]*entity.Authorization, 0)
+	tx := session(ctx, a.adapter)
+	find := tx.Scopes(filters.Filter(), page(filters.TransactionCollection)).Find(&authorizations)
+	if find.Error != nil {
+		a.logger.Errorf("tx.Find() failed with %s\n", find.Error)
+		return nil, ErrAuthorizationFind
+	}
+
+	collection := &entity.AuthorizationCollection{Data: authorizations}
+	if filters.After != 0 || filters.Before != 0 {
+		a.cursor(collection, filters)
+		return collection, nil
+	}
+
+	var total int64
+	if count := tx.Model(&entity.Authorization{}).Scopes(filters.Filter()).Count(&total); count.Error != nil {
+		a.logger.Errorf("tx.Count() failed with %s\n", count.Error)
+		return nil, ErrAuthorizationCount
+	}
+
+	collection.Pagination = persistence.NewPagination(filters.Page, filters.Size, total)
+
+	// as transactions, a page in id order links its next one by cursor
+	more := int64(collection.Page*collection.Size) < collection.Total
+	if more && filters.Sort.Order() == "id" && len(authorizations) > 0 {
+		collection.NextCursor = authorizations[len(authorizations)-1].ID
+	}
+
+	return collection, nil
+}
+
+// cursor fills a page read by after or before, fetched with one extra row and, going
+// backwards, in descending order. Cursor pages skip the count, as transaction ones do.
+func (a *Authorization) cursor(collection *entity.AuthorizationCollection, filters filter.AuthorizationCollection) {
+	authorizations := collection.Data
+	_, size := persistence.Normalize(0, filters.Size)
+	collection.Pagination = persistence.Pagination{Size: size}
+
+	more := len(authorizations) > size
+	if more {
+		authorizations = authorizations[:size]
+	}
+
+	if filters.Before != 0 {
+		for i, j := 0, len(authorizations)-1; i < j; i, j = i+1, j-1 {
+			authorizations[i], authorizations[j] = authorizations[j], authorizations[i]
+		}
+	}
+
+	collection.Data = authorizations
+	if len(authorizations) == 0 {
+		return
+	}
+
+	first, last := authorizations[0].ID, authorizations[len(authorizations)-1].ID
+	if filters.After != 0 {
+		collection.PrevCursor = first
+		if more {
+			collection.NextCursor = last
+		}
+	} else {
+		collection.NextCursor = last
+		if more {
+			collection.PrevCursor = first
+		}
+	}
+}
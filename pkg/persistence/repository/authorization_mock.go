@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/authorization.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	filter "ms/card/pkg/persistence/filter"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockAuthorizations is a mock of Authorizations interface.
+type MockAuthorizations struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationsMockRecorder
+}
+
+// MockAuthorizationsMockRecorder is the mock recorder for MockAuthorizations.
+type MockAuthorizationsMockRecorder struct {
+	mock *MockAuthorizations
+}
+
+// NewMockAuthorizations creates a new mock instance.
+func NewMockAuthorizations(ctrl *gomock.Controller) *MockAuthorizations {
+	mock := &MockAuthorizations{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizations) EXPECT() *MockAuthorizationsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuthorizations) Create(ctx context.Context, structure entity.Authorization) (*entity.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, structure)
+	ret0, _ := ret[0].(*entity.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuthorizationsMockRecorder) Create(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorizations)(nil).Create), ctx, structure)
+}
+
+// FindAll mocks base method.
+func (m *MockAuthorizations) FindAll(ctx context.Context, filters filter.AuthorizationCollection) (*entity.AuthorizationCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
+	ret0, _ := ret[0].(*entity.AuthorizationCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAuthorizationsMockRecorder) FindAll(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuthorizations)(nil).FindAll), ctx, filters)
+}
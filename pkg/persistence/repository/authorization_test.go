@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAuthorizationRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "authorization_attempt" ("account_id","operation_id","card_id","transaction_id","hold_id","amount","currency","outcome","code","reason","created_by","created_at","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode")
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19)
+		RETURNING "id"
+	`)).WithArgs(1, 1, nil, nil, nil, 5000, "", "declined", "limit_exceeded", "account limit exceeded, operation not allowed", "", now, "", "", "5411", "", "", "", "").WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectCommit()
+
+	authorizationRepository := NewAuthorization(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	authorization, err := authorizationRepository.Create(ctx, entity.Authorization{
+		Account:   1,
+		Type:      1,
+		Amount:    5000,
+		Outcome:   entity.AuthorizationOutcomeDeclined,
+		Code:      "limit_exceeded",
+		Reason:    "account limit exceeded, operation not allowed",
+		CreatedAt: now,
+		Merchant:  entity.Merchant{MCC: "5411"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), authorization.ID)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAuthorizationRepository_Create_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "authorization_attempt"`)).WillReturnError(gorm.ErrInvalidData)
+	dbmock.ExpectRollback()
+
+	authorizationRepository := NewAuthorization(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	authorization, err := authorizationRepository.Create(ctx, entity.Authorization{Account: 1})
+	assert.Nil(t, authorization)
+	assert.EqualError(t, err, ErrAuthorizationCreate.Error())
+}
+
+func TestAuthorizationRepository_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "authorization_attempt" WHERE account_id = $1 AND mcc = $2 AND outcome = $3 ORDER BY id LIMIT 10
+	`)).WithArgs(1, "5411", "declined").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "outcome", "code"}).AddRow(uint(3), uint(1), "declined", "limit_exceeded"),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT count(*) FROM "authorization_attempt" WHERE account_id = $1 AND mcc = $2 AND outcome = $3
+	`)).WithArgs(1, "5411", "declined").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	authorizationRepository := NewAuthorization(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	authorizations, err := authorizationRepository.FindAll(ctx, filter.AuthorizationCollection{
+		TransactionCollection: filter.TransactionCollection{Accounts: []uint{1}, MCC: "5411"},
+		Outcome:               entity.AuthorizationOutcomeDeclined,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, persistence.Pagination{Page: 1, Size: 10, Total: 1}, authorizations.Pagination)
+	if assert.Len(t, authorizations.Data, 1) {
+		assert.Equal(t, "limit_exceeded", authorizations.Data[0].Code)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAuthorizationRepository_FindAll_After(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "authorization_attempt" WHERE outcome = $1 AND id > $2 ORDER BY id LIMIT 3
+	`)).WithArgs("declined", 10).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "outcome"}).
+			AddRow(uint(11), uint(1), "declined").
+			AddRow(uint(12), uint(1), "declined").
+			AddRow(uint(13), uint(1), "declined"),
+	)
+
+	authorizationRepository := NewAuthorization(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	authorizations, err := authorizationRepository.FindAll(ctx, filter.AuthorizationCollection{
+		TransactionCollection: filter.TransactionCollection{Size: 2, After: 10},
+		Outcome:               entity.AuthorizationOutcomeDeclined,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, authorizations.Data, 2)
+	assert.Equal(t, persistence.Pagination{Size: 2, NextCursor: 12, PrevCursor: 11}, authorizations.Pagination)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAuthorizationRepository_FindAll_Before(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "authorization_attempt" WHERE id < $1 ORDER BY id DESC LIMIT 3
+	`)).WithArgs(3).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id"}).
+			AddRow(uint(2), uint(1)).
+			AddRow(uint(1), uint(1)),
+	)
+
+	authorizationRepository := NewAuthorization(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	authorizations, err := authorizationRepository.FindAll(ctx, filter.AuthorizationCollection{
+		TransactionCollection: filter.TransactionCollection{Size: 2, Before: 3},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), authorizations.Data[0].ID)
+	assert.Equal(t, uint(2), authorizations.Data[1].ID)
+	assert.Equal(t, persistence.Pagination{Size: 2, NextCursor: 2}, authorizations.Pagination)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAuthorizationRepository_FindAll_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf("tx.Find() failed with %s\n", gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "authorization_attempt"`)).WillReturnError(errors.New("find failed"))
+
+	authorizationRepository := NewAuthorization(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	authorizations, err := authorizationRepository.FindAll(ctx, filter.AuthorizationCollection{})
+	assert.Nil(t, authorizations)
+	assert.ErrorIs(t, err, ErrAuthorizationFind)
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+var (
+	ErrBillingCycleSave     = domain.Internal("billing_cycle_save_failed", "failed to save billing cycle")
+	ErrBillingCycleNotFound = domain.NotFound("billing_cycle_not_found", "billing cycle not found")
+	ErrBillingCycleFind     = domain.Internal("billing_cycle_find_failed", "failed fetch the billing cycle")
+	ErrBillingCycleFindDue  = domain.Internal("billing_cycle_find_due_failed", "failed fetch due billing cycles")
+)
+
+type (
+	BillingCycles interface {
+		Save(ctx context.Context, structure *entity.BillingCycle) error
+		FindByAccount(ctx context.Context, account uint) (*entity.BillingCycle, error)
+		FindByAccountForUpdate(ctx context.Context, account uint) (*entity.BillingCycle, error)
+		FindDue(ctx context.Context, now time.Time, size int) ([]uint, error)
+	}
+
+	BillingCycle struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewBillingCycle(logger common.Logger, adapter *gorm.DB) *BillingCycle {
+	return &BillingCycle{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+// Save creates or replaces the billing cycle of the account.
+func (b *BillingCycle) Save(ctx context.Context, structure *entity.BillingCycle) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, b.adapter)
+	if result := tx.Save(structure); result.Error != nil {
+		b.logger.Errorf("tx.Save() failed with %s\n", result.Error)
+		return ErrBillingCycleSave
+	}
+
+	return nil
+}
+
+func (b *BillingCycle) FindByAccount(ctx context.Context, account uint) (*entity.BillingCycle, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return b.first(session(ctx, b.adapter), account)
+}
+
+// FindByAccountForUpdate locks the billing cycle row, concurrent closings of the same cycle are serialized.
+func (b *BillingCycle) FindByAccountForUpdate(ctx context.Context, account uint) (*entity.BillingCycle, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return b.first(session(ctx, b.adapter).Clauses(clause.Locking{Strength: "UPDATE"}), account)
+}
+
+// FindDue returns the accounts whose billing cycle reached the closing date.
+func (b *BillingCycle) FindDue(ctx context.Context, now time.Time, size int) ([]uint, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	accounts := make([]uint, 0)
+	tx := session(ctx, b.adapter)
+	find := tx.Model(&entity.BillingCycle{}).
+		Where("next_closing_at <= ?", now).
+		Order("next_closing_at").
+		Limit(size).
+		Pluck("account_id", &accounts)
+
+	if find.Error != nil {
+		b.logger.Errorf("tx.Pluck() failed with %s\n", find.Error)
+		return nil, ErrBillingCycleFindDue
+	}
+
+	return accounts, nil
+}
+
+func (b *BillingCycle) first(tx *gorm.DB, account uint) (*entity.BillingCycle, error) {
+	var cycle entity.BillingCycle
+	if result := tx.Where("account_id = ?", account).First(&cycle); result.Error != nil {
+		b.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrBillingCycleNotFound
+		}
+
+		return nil, ErrBillingCycleFind
+	}
+
+	return &cycle, nil
+}
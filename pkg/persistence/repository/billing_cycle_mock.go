@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/billing_cycle.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockBillingCycles is a mock of BillingCycles interface.
+type MockBillingCycles struct {
+	ctrl     *gomock.Controller
+	recorder *MockBillingCyclesMockRecorder
+}
+
+// MockBillingCyclesMockRecorder is the mock recorder for MockBillingCycles.
+type MockBillingCyclesMockRecorder struct {
+	mock *MockBillingCycles
+}
+
+// NewMockBillingCycles creates a new mock instance.
+func NewMockBillingCycles(ctrl *gomock.Controller) *MockBillingCycles {
+	mock := &MockBillingCycles{ctrl: ctrl}
+	mock.recorder = &MockBillingCyclesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBillingCycles) EXPECT() *MockBillingCyclesMockRecorder {
+	return m.recorder
+}
+
+// FindByAccount mocks base method.
+func (m *MockBillingCycles) FindByAccount(ctx context.Context, account uint) (*entity.BillingCycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAccount", ctx, account)
+	ret0, _ := ret[0].(*entity.BillingCycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAccount indicates an expected call of FindByAccount.
+func (mr *MockBillingCyclesMockRecorder) FindByAccount(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccount", reflect.TypeOf((*MockBillingCycles)(nil).FindByAccount), ctx, account)
+}
+
+// FindByAccountForUpdate mocks base method.
+func (m *MockBillingCycles) FindByAccountForUpdate(ctx context.Context, account uint) (*entity.BillingCycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAccountForUpdate", ctx, account)
+	ret0, _ := ret[0].(*entity.BillingCycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAccountForUpdate indicates an expected call of FindByAccountForUpdate.
+func (mr *MockBillingCyclesMockRecorder) FindByAccountForUpdate(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccountForUpdate", reflect.TypeOf((*MockBillingCycles)(nil).FindByAccountForUpdate), ctx, account)
+}
+
+// FindDue mocks base method.
+func (m *MockBillingCycles) FindDue(ctx context.Context, now time.Time, size int) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, now, size)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockBillingCyclesMockRecorder) FindDue(ctx, now, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockBillingCycles)(nil).FindDue), ctx, now, size)
+}
+
+// Save mocks base method.
+func (m *MockBillingCycles) Save(ctx context.Context, structure *entity.BillingCycle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, structure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockBillingCyclesMockRecorder) Save(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBillingCycles)(nil).Save), ctx, structure)
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestBillingCycleRepository_FindByAccountForUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "billing_cycle"
+		WHERE account_id = $1
+		ORDER BY "billing_cycle"."account_id"
+		LIMIT 1 FOR UPDATE
+	`)).WithArgs(1).WillReturnRows(
+		sqlmock.NewRows([]string{"account_id", "closing_day", "due_days", "next_closing_at"}).AddRow(uint(1), 10, 7, now),
+	)
+
+	billingCycleRepository := NewBillingCycle(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	cycle, err := billingCycleRepository.FindByAccountForUpdate(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, cycle.ClosingDay)
+	assert.Equal(t, now, cycle.NextClosingAt)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestBillingCycleRepository_FindByAccount_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "billing_cycle"
+		WHERE account_id = $1
+		ORDER BY "billing_cycle"."account_id"
+		LIMIT 1
+	`)).WithArgs(1).WillReturnError(gorm.ErrRecordNotFound)
+
+	billingCycleRepository := NewBillingCycle(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	cycle, err := billingCycleRepository.FindByAccount(ctx, 1)
+	assert.Nil(t, cycle)
+	assert.EqualError(t, err, ErrBillingCycleNotFound.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestBillingCycleRepository_FindDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "account_id" FROM "billing_cycle"
+		WHERE next_closing_at <= $1
+		ORDER BY next_closing_at
+		LIMIT 100
+	`)).WithArgs(now).WillReturnRows(
+		sqlmock.NewRows([]string{"account_id"}).AddRow(uint(1)).AddRow(uint(4)),
+	)
+
+	billingCycleRepository := NewBillingCycle(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	accounts, err := billingCycleRepository.FindDue(ctx, now, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1, 4}, accounts)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"github.com/jackc/pgconn"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrCardCreate              = domain.Internal("card_create_failed", "failed to create new card")
+	ErrCardCreateAlreadyExists = domain.Conflict("card_already_exists", "card already exists")
+	ErrCardNotFound            = domain.NotFound("card_not_found", "card not found")
+	ErrCardFindByID            = domain.Internal("card_find_failed", "failed fetch the card")
+	ErrCardUpdate              = domain.Internal("card_update_failed", "failed to update card")
+)
+
+type (
+	Cards interface {
+		Create(ctx context.Context, structure entity.Card) (*entity.Card, error)
+		Update(ctx context.Context, structure *entity.Card) error
+		FindByID(ctx context.Context, id uint) (*entity.Card, error)
+		FindByIDForUpdate(ctx context.Context, id uint) (*entity.Card, error)
+		FindAll(ctx context.Context, filters filter.CardCollection) ([]*entity.Card, error)
+	}
+
+	Card struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewCard(logger common.Logger, adapter *gorm.DB) *Card {
+	return &Card{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (c *Card) Create(ctx context.Context, structure entity.Card) (*entity.Card, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, c.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		c.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		var err *pgconn.PgError
+		if xerrors.As(result.Error, &err) && err.Code == UniqueKeyCodeConstraint {
+			return nil, ErrCardCreateAlreadyExists
+		}
+
+		return nil, ErrCardCreate
+	}
+
+	return &structure, nil
+}
+
+func (c *Card) Update(ctx context.Context, structure *entity.Card) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, c.adapter)
+	if result := tx.Save(structure); result.Error != nil {
+		c.logger.Errorf("tx.Save() failed with %s\n", result.Error)
+		return ErrCardUpdate
+	}
+
+	return nil
+}
+
+func (c *Card) FindByID(ctx context.Context, id uint) (*entity.Card, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return c.first(session(ctx, c.adapter), id)
+}
+
+// FindByIDForUpdate locks the card row until the surrounding unit of work finishes, status
+// changes on the same card are serialized.
+func (c *Card) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Card, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return c.first(session(ctx, c.adapter).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (c *Card) FindAll(ctx context.Context, filters filter.CardCollection) ([]*entity.Card, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	cards := make([]*entity.Card, 0)
+	tx := session(ctx, c.adapter)
+	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).
+		Order("id").
+		Find(&cards)
+
+	return cards, find.Error
+}
+
+func (c *Card) first(tx *gorm.DB, id uint) (*entity.Card, error) {
+	var card entity.Card
+	if result := tx.First(&card, id); result.Error != nil {
+		c.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+
+		return nil, ErrCardFindByID
+	}
+
+	return &card, nil
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/card.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	filter "ms/card/pkg/persistence/filter"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockCards is a mock of Cards interface.
+type MockCards struct {
+	ctrl     *gomock.Controller
+	recorder *MockCardsMockRecorder
+}
+
+// MockCardsMockRecorder is the mock recorder for MockCards.
+type MockCardsMockRecorder struct {
+	mock *MockCards
+}
+
+// NewMockCards creates a new mock instance.
+func NewMockCards(ctrl *gomock.Controller) *MockCards {
+	mock := &MockCards{ctrl: ctrl}
+	mock.recorder = &MockCardsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCards) EXPECT() *MockCardsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCards) Create(ctx context.Context, structure entity.Card) (*entity.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, structure)
+	ret0, _ := ret[0].(*entity.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCardsMockRecorder) Create(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCards)(nil).Create), ctx, structure)
+}
+
+// FindAll mocks base method.
+func (m *MockCards) FindAll(ctx context.Context, filters filter.CardCollection) ([]*entity.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
+	ret0, _ := ret[0].([]*entity.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCardsMockRecorder) FindAll(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCards)(nil).FindAll), ctx, filters)
+}
+
+// FindByID mocks base method.
+func (m *MockCards) FindByID(ctx context.Context, id uint) (*entity.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCardsMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCards)(nil).FindByID), ctx, id)
+}
+
+// FindByIDForUpdate mocks base method.
+func (m *MockCards) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
+func (mr *MockCardsMockRecorder) FindByIDForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockCards)(nil).FindByIDForUpdate), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockCards) Update(ctx context.Context, structure *entity.Card) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, structure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCardsMockRecorder) Update(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCards)(nil).Update), ctx, structure)
+}
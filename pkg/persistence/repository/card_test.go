@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCardRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	expires := time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "card" ("account_id","token","last_four","type","status","expires_at","created_by","created_at","updated_at")
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		RETURNING "id"
+	`)).WithArgs(1, "token", "1234", entity.CardTypeVirtual, entity.CardStatusActive, expires, "", now, now).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectCommit()
+
+	cardRepository := NewCard(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	card, err := cardRepository.Create(ctx, entity.Card{
+		Account:   1,
+		Token:     "token",
+		LastFour:  "1234",
+		PAN:       "5502090000001234",
+		Type:      entity.CardTypeVirtual,
+		Status:    entity.CardStatusActive,
+		ExpiresAt: expires,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), card.ID)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCardRepository_Create_AlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "card"`)).WillReturnError(&pgconn.PgError{Code: UniqueKeyCodeConstraint})
+	dbmock.ExpectRollback()
+
+	cardRepository := NewCard(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	card, err := cardRepository.Create(ctx, entity.Card{Account: 1, Token: "token"})
+	assert.Nil(t, card)
+	assert.ErrorIs(t, err, ErrCardCreateAlreadyExists)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCardRepository_FindByIDForUpdate_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "card"
+		WHERE "card"."id" = $1
+		ORDER BY "card"."id"
+		LIMIT 1 FOR UPDATE
+	`)).WithArgs(1).WillReturnError(gorm.ErrRecordNotFound)
+
+	cardRepository := NewCard(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	card, err := cardRepository.FindByIDForUpdate(ctx, 1)
+	assert.Nil(t, card)
+	assert.ErrorIs(t, err, ErrCardNotFound)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCardRepository_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "card"
+		WHERE account_id = $1 AND status = $2
+		ORDER BY id
+		LIMIT 10
+	`)).WithArgs(1, entity.CardStatusActive).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "last_four", "status"}).
+			AddRow(uint(1), uint(1), "1234", entity.CardStatusActive).
+			AddRow(uint(2), uint(1), "5678", entity.CardStatusActive),
+	)
+
+	cardRepository := NewCard(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	cards, err := cardRepository.FindAll(ctx, filter.CardCollection{Account: 1, Status: entity.CardStatusActive})
+	assert.NoError(t, err)
+	assert.Len(t, cards, 2)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+var (
+	ErrHoldCreate      = domain.Internal("hold_create_failed", "failed to create new hold")
+	ErrHoldNotFound    = domain.NotFound("hold_not_found", "hold not found")
+	ErrHoldFindByID    = domain.Internal("hold_find_failed", "failed fetch the hold")
+	ErrHoldUpdate      = domain.Internal("hold_update_failed", "failed to update hold")
+	ErrHoldFindExpired = domain.Internal("hold_find_expired_failed", "failed fetch expired holds")
+)
+
+type (
+	Holds interface {
+		Create(ctx context.Context, structure entity.Hold) (*entity.Hold, error)
+		Update(ctx context.Context, structure *entity.Hold) error
+		FindByID(ctx context.Context, id uint) (*entity.Hold, error)
+		FindByIDForUpdate(ctx context.Context, id uint) (*entity.Hold, error)
+		FindExpired(ctx context.Context, now time.Time, size int) ([]uint, error)
+	}
+
+	Hold struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewHold(logger common.Logger, adapter *gorm.DB) *Hold {
+	return &Hold{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (h *Hold) Create(ctx context.Context, structure entity.Hold) (*entity.Hold, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, h.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		h.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		return nil, ErrHoldCreate
+	}
+
+	return &structure, nil
+}
+
+func (h *Hold) Update(ctx context.Context, structure *entity.Hold) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, h.adapter)
+	if result := tx.Save(structure); result.Error != nil {
+		h.logger.Errorf("tx.Save() failed with %s\n", result.Error)
+		return ErrHoldUpdate
+	}
+
+	return nil
+}
+
+func (h *Hold) FindByID(ctx context.Context, id uint) (*entity.Hold, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return h.first(session(ctx, h.adapter), id)
+}
+
+// FindByIDForUpdate locks the hold row until the surrounding unit of work finishes.
+func (h *Hold) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Hold, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return h.first(session(ctx, h.adapter).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+// FindExpired returns the ids of open holds whose expiry is due, oldest first.
+func (h *Hold) FindExpired(ctx context.Context, now time.Time, size int) ([]uint, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	ids := make([]uint, 0)
+	tx := session(ctx, h.adapter)
+	find := tx.Model(&entity.Hold{}).
+		Where("status IN ?", []string{entity.HoldStatusAuthorized, entity.HoldStatusPartiallyCaptured}).
+		Where("expires_at <= ?", now).
+		Order("expires_at").
+		Limit(size).
+		Pluck("id", &ids)
+
+	if find.Error != nil {
+		h.logger.Errorf("tx.Pluck() failed with %s\n", find.Error)
+		return nil, ErrHoldFindExpired
+	}
+
+	return ids, nil
+}
+
+func (h *Hold) first(tx *gorm.DB, id uint) (*entity.Hold, error) {
+	var hold entity.Hold
+	if result := tx.First(&hold, id); result.Error != nil {
+		h.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrHoldNotFound
+		}
+
+		return nil, ErrHoldFindByID
+	}
+
+	return &hold, nil
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/hold.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockHolds is a mock of Holds interface.
+type MockHolds struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldsMockRecorder
+}
+
+// MockHoldsMockRecorder is the mock recorder for MockHolds.
+type MockHoldsMockRecorder struct {
+	mock *MockHolds
+}
+
+// NewMockHolds creates a new mock instance.
+func NewMockHolds(ctrl *gomock.Controller) *MockHolds {
+	mock := &MockHolds{ctrl: ctrl}
+	mock.recorder = &MockHoldsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHolds) EXPECT() *MockHoldsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHolds) Create(ctx context.Context, structure entity.Hold) (*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, structure)
+	ret0, _ := ret[0].(*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHoldsMockRecorder) Create(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHolds)(nil).Create), ctx, structure)
+}
+
+// FindByID mocks base method.
+func (m *MockHolds) FindByID(ctx context.Context, id uint) (*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockHoldsMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockHolds)(nil).FindByID), ctx, id)
+}
+
+// FindByIDForUpdate mocks base method.
+func (m *MockHolds) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
+func (mr *MockHoldsMockRecorder) FindByIDForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockHolds)(nil).FindByIDForUpdate), ctx, id)
+}
+
+// FindExpired mocks base method.
+func (m *MockHolds) FindExpired(ctx context.Context, now time.Time, size int) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpired", ctx, now, size)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpired indicates an expected call of FindExpired.
+func (mr *MockHoldsMockRecorder) FindExpired(ctx, now, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpired", reflect.TypeOf((*MockHolds)(nil).FindExpired), ctx, now, size)
+}
+
+// Update mocks base method.
+func (m *MockHolds) Update(ctx context.Context, structure *entity.Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, structure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHoldsMockRecorder) Update(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHolds)(nil).Update), ctx, structure)
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestHoldRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "hold" ("account_id","operation_id","card_id","amount","captured_amount","status","expires_at","created_by","created_at","updated_at","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode")
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17)
+		RETURNING "id"
+	`)).WithArgs(1, 1, 3, 1000, 0, entity.HoldStatusAuthorized, now.Add(time.Hour), "", now, now, "", "HOTEL", "7011", "", "", "", "").WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectCommit()
+
+	holdRepository := NewHold(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	card := uint(3)
+	hold, err := holdRepository.Create(ctx, entity.Hold{
+		Account:   1,
+		Type:      1,
+		Card:      &card,
+		Amount:    1000,
+		Status:    entity.HoldStatusAuthorized,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Merchant:  entity.Merchant{MerchantName: "HOTEL", MCC: "7011"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), hold.ID)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestHoldRepository_FindByIDForUpdate_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "hold"
+		WHERE "hold"."id" = $1
+		ORDER BY "hold"."id"
+		LIMIT 1 FOR UPDATE
+	`)).WithArgs(uint(1)).WillReturnError(gorm.ErrRecordNotFound)
+
+	holdRepository := NewHold(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	hold, err := holdRepository.FindByIDForUpdate(ctx, 1)
+	assert.Nil(t, hold)
+	assert.EqualError(t, err, ErrHoldNotFound.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestHoldRepository_FindExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id" FROM "hold"
+		WHERE status IN ($1,$2) AND expires_at <= $3
+		ORDER BY expires_at
+		LIMIT 100
+	`)).WithArgs(entity.HoldStatusAuthorized, entity.HoldStatusPartiallyCaptured, now).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow(uint(1)).AddRow(uint(2)),
+	)
+
+	holdRepository := NewHold(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	ids, err := holdRepository.FindExpired(ctx, now, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, ids)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"github.com/jackc/pgconn"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyCreate              = domain.Internal("idempotency_key_create_failed", "failed to create new idempotency key")
+	ErrIdempotencyKeyCreateAlreadyExists = domain.Conflict("idempotency_key_already_exists", "idempotency key already exists")
+	ErrIdempotencyKeyNotFound            = domain.NotFound("idempotency_key_not_found", "idempotency key not found")
+	ErrIdempotencyKeyFindByKey           = domain.Internal("idempotency_key_find_failed", "failed fetch the idempotency key")
+	ErrIdempotencyKeyDelete              = domain.Internal("idempotency_key_delete_failed", "failed to delete idempotency key")
+)
+
+type (
+	IdempotencyKeys interface {
+		Create(ctx context.Context, structure entity.IdempotencyKey) (*entity.IdempotencyKey, error)
+		FindByKey(ctx context.Context, client string, key string) (*entity.IdempotencyKey, error)
+		Delete(ctx context.Context, structure *entity.IdempotencyKey) error
+		DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	}
+
+	IdempotencyKey struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewIdempotencyKey(logger common.Logger, adapter *gorm.DB) *IdempotencyKey {
+	return &IdempotencyKey{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (i *IdempotencyKey) Create(ctx context.Context, structure entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, i.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		i.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		var err *pgconn.PgError
+		if xerrors.As(result.Error, &err) && err.Code == UniqueKeyCodeConstraint {
+			return nil, ErrIdempotencyKeyCreateAlreadyExists
+		}
+
+		return nil, ErrIdempotencyKeyCreate
+	}
+
+	return &structure, nil
+}
+
+// FindByKey finds the key among the ones of the client, the same key of another client is
+// not a replay.
+func (i *IdempotencyKey) FindByKey(ctx context.Context, client string, key string) (*entity.IdempotencyKey, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var idempotencyKey entity.IdempotencyKey
+	tx := session(ctx, i.adapter)
+	if result := tx.Where("client_id = ? AND key = ?", client, key).First(&idempotencyKey); result.Error != nil {
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrIdempotencyKeyNotFound
+		}
+
+		i.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		return nil, ErrIdempotencyKeyFindByKey
+	}
+
+	return &idempotencyKey, nil
+}
+
+func (i *IdempotencyKey) Delete(ctx context.Context, structure *entity.IdempotencyKey) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, i.adapter)
+	if result := tx.Delete(structure); result.Error != nil {
+		i.logger.Errorf("tx.Delete() failed with %s\n", result.Error)
+		return ErrIdempotencyKeyDelete
+	}
+
+	return nil
+}
+
+func (i *IdempotencyKey) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, i.adapter)
+	result := tx.Where("expires_at <= ?", now).Delete(&entity.IdempotencyKey{})
+	if result.Error != nil {
+		i.logger.Errorf("tx.Delete() failed with %s\n", result.Error)
+		return 0, ErrIdempotencyKeyDelete
+	}
+
+	return result.RowsAffected, nil
+}
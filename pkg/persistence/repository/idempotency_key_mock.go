@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/idempotency_key.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockIdempotencyKeys is a mock of IdempotencyKeys interface.
+type MockIdempotencyKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyKeysMockRecorder
+}
+
+// MockIdempotencyKeysMockRecorder is the mock recorder for MockIdempotencyKeys.
+type MockIdempotencyKeysMockRecorder struct {
+	mock *MockIdempotencyKeys
+}
+
+// NewMockIdempotencyKeys creates a new mock instance.
+func NewMockIdempotencyKeys(ctrl *gomock.Controller) *MockIdempotencyKeys {
+	mock := &MockIdempotencyKeys{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyKeys) EXPECT() *MockIdempotencyKeysMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIdempotencyKeys) Create(ctx context.Context, structure entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, structure)
+	ret0, _ := ret[0].(*entity.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIdempotencyKeysMockRecorder) Create(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdempotencyKeys)(nil).Create), ctx, structure)
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyKeys) Delete(ctx context.Context, structure *entity.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, structure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyKeysMockRecorder) Delete(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyKeys)(nil).Delete), ctx, structure)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockIdempotencyKeys) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockIdempotencyKeysMockRecorder) DeleteExpired(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockIdempotencyKeys)(nil).DeleteExpired), ctx, now)
+}
+
+// FindByKey mocks base method.
+func (m *MockIdempotencyKeys) FindByKey(ctx context.Context, client, key string) (*entity.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByKey", ctx, client, key)
+	ret0, _ := ret[0].(*entity.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByKey indicates an expected call of FindByKey.
+func (mr *MockIdempotencyKeysMockRecorder) FindByKey(ctx, client, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByKey", reflect.TypeOf((*MockIdempotencyKeys)(nil).FindByKey), ctx, client, key)
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKeyRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "idempotency_key" ("client_id","key","hash","transaction_id","created_at","expires_at")
+		VALUES ($1,$2,$3,$4,$5,$6)
+		RETURNING "id"
+	`)).WithArgs("checkout", "key", "hash", 1, now, now.Add(time.Hour)).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectCommit()
+
+	idempotencyKeyRepository := NewIdempotencyKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	idempotencyKey, err := idempotencyKeyRepository.Create(ctx, entity.IdempotencyKey{
+		Client:      "checkout",
+		Key:         "key",
+		Hash:        "hash",
+		Transaction: 1,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), idempotencyKey.ID)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIdempotencyKeyRepository_Create_Persist_ValidateUniqueKeyConstraint_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery("^INSERT INTO \"idempotency_key\"(.+)$").WillReturnError(&pgconn.PgError{
+		Code:    UniqueKeyCodeConstraint,
+		Message: ErrIdempotencyKeyCreateAlreadyExists.Error(),
+	})
+	dbmock.ExpectRollback()
+
+	idempotencyKeyRepository := NewIdempotencyKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	idempotencyKey, err := idempotencyKeyRepository.Create(ctx, entity.IdempotencyKey{Key: "key"})
+	assert.Nil(t, idempotencyKey)
+	assert.EqualError(t, err, ErrIdempotencyKeyCreateAlreadyExists.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIdempotencyKeyRepository_FindByKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "idempotency_key"
+		WHERE client_id = $1 AND key = $2
+		ORDER BY "idempotency_key"."id"
+		LIMIT 1
+	`)).WithArgs("checkout", "key").WillReturnRows(sqlmock.NewRows([]string{"id", "key", "hash", "transaction_id"}).AddRow(uint(1), "key", "hash", uint(3)))
+
+	idempotencyKeyRepository := NewIdempotencyKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	idempotencyKey, err := idempotencyKeyRepository.FindByKey(ctx, "checkout", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), idempotencyKey.Transaction)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIdempotencyKeyRepository_FindByKey_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery("^SELECT (.+) FROM \"idempotency_key\"(.+)$").WithArgs("checkout", "key").WillReturnError(gorm.ErrRecordNotFound)
+
+	idempotencyKeyRepository := NewIdempotencyKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	idempotencyKey, err := idempotencyKeyRepository.FindByKey(ctx, "checkout", "key")
+	assert.Nil(t, idempotencyKey)
+	assert.EqualError(t, err, ErrIdempotencyKeyNotFound.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIdempotencyKeyRepository_DeleteExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "idempotency_key" WHERE expires_at <= $1`)).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbmock.ExpectCommit()
+
+	idempotencyKeyRepository := NewIdempotencyKey(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	deleted, err := idempotencyKeyRepository.DeleteExpired(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+var (
+	ErrInstallmentPlanCreate   = domain.Internal("installment_plan_create_failed", "failed to create new installment plan")
+	ErrInstallmentPlanNotFound = domain.NotFound("installment_plan_not_found", "installment plan not found")
+	ErrInstallmentPlanFindByID = domain.Internal("installment_plan_find_failed", "failed fetch the installment plan")
+	ErrInstallmentNotFound     = domain.NotFound("installment_not_found", "installment not found")
+	ErrInstallmentFindByID     = domain.Internal("installment_find_failed", "failed fetch the installment")
+	ErrInstallmentFindDue      = domain.Internal("installment_find_due_failed", "failed fetch due installments")
+	ErrInstallmentFindByPlan   = domain.Internal("installment_find_plan_failed", "failed fetch the installments of the plan")
+	ErrInstallmentUpdate       = domain.Internal("installment_update_failed", "failed to update installment")
+)
+
+type (
+	Installments interface {
+		CreatePlan(ctx context.Context, structure entity.InstallmentPlan) (*entity.InstallmentPlan, error)
+		FindPlanByID(ctx context.Context, id uint) (*entity.InstallmentPlan, error)
+		FindPlans(ctx context.Context, filters filter.InstallmentPlanCollection) ([]*entity.InstallmentPlan, error)
+		FindDue(ctx context.Context, now time.Time, size int) ([]uint, error)
+		FindByIDForUpdate(ctx context.Context, id uint) (*entity.Installment, error)
+		FindByPlanForUpdate(ctx context.Context, plan uint) ([]*entity.Installment, error)
+		Update(ctx context.Context, structure *entity.Installment) error
+	}
+
+	Installment struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewInstallment(logger common.Logger, adapter *gorm.DB) *Installment {
+	return &Installment{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+// CreatePlan stores the plan together with its installment schedule.
+func (i *Installment) CreatePlan(ctx context.Context, structure entity.InstallmentPlan) (*entity.InstallmentPlan, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, i.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		i.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		return nil, ErrInstallmentPlanCreate
+	}
+
+	return &structure, nil
+}
+
+func (i *Installment) FindPlanByID(ctx context.Context, id uint) (*entity.InstallmentPlan, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var plan entity.InstallmentPlan
+	tx := session(ctx, i.adapter)
+	if result := tx.First(&plan, id); result.Error != nil {
+		i.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrInstallmentPlanNotFound
+		}
+
+		return nil, ErrInstallmentPlanFindByID
+	}
+
+	return &plan, nil
+}
+
+func (i *Installment) FindPlans(ctx context.Context, filters filter.InstallmentPlanCollection) ([]*entity.InstallmentPlan, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	plans := make([]*entity.InstallmentPlan, 0)
+	tx := session(ctx, i.adapter)
+	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number")
+		}).
+		Order("id").
+		Find(&plans)
+
+	return plans, find.Error
+}
+
+// FindDue returns the ids of installments neither posted nor cancelled whose due date has arrived.
+func (i *Installment) FindDue(ctx context.Context, now time.Time, size int) ([]uint, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	ids := make([]uint, 0)
+	tx := session(ctx, i.adapter)
+	find := tx.Model(&entity.Installment{}).
+		Where("transaction_id IS NULL").
+		Where("cancelled_at IS NULL").
+		Where("due_date <= ?", now).
+		Order("due_date").
+		Limit(size).
+		Pluck("id", &ids)
+
+	if find.Error != nil {
+		i.logger.Errorf("tx.Pluck() failed with %s\n", find.Error)
+		return nil, ErrInstallmentFindDue
+	}
+
+	return ids, nil
+}
+
+// FindByIDForUpdate locks the installment row until the surrounding unit of work finishes.
+func (i *Installment) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Installment, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var installment entity.Installment
+	tx := session(ctx, i.adapter).Clauses(clause.Locking{Strength: "UPDATE"})
+	if result := tx.First(&installment, id); result.Error != nil {
+		i.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrInstallmentNotFound
+		}
+
+		return nil, ErrInstallmentFindByID
+	}
+
+	return &installment, nil
+}
+
+// FindByPlanForUpdate locks the schedule of the plan until the surrounding unit of work
+// finishes, in order.
+func (i *Installment) FindByPlanForUpdate(ctx context.Context, plan uint) ([]*entity.Installment, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	installments := make([]*entity.Installment, 0)
+	tx := session(ctx, i.adapter).Clauses(clause.Locking{Strength: "UPDATE"})
+	if result := tx.Where("installment_plan_id = ?", plan).Order("number").Find(&installments); result.Error != nil {
+		i.logger.Errorf("tx.Find() failed with %s\n", result.Error)
+		return nil, ErrInstallmentFindByPlan
+	}
+
+	return installments, nil
+}
+
+func (i *Installment) Update(ctx context.Context, structure *entity.Installment) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, i.adapter)
+	if result := tx.Save(structure); result.Error != nil {
+		i.logger.Errorf("tx.Save() failed with %s\n", result.Error)
+		return ErrInstallmentUpdate
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/installment.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	filter "ms/card/pkg/persistence/filter"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockInstallments is a mock of Installments interface.
+type MockInstallments struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstallmentsMockRecorder
+}
+
+// MockInstallmentsMockRecorder is the mock recorder for MockInstallments.
+type MockInstallmentsMockRecorder struct {
+	mock *MockInstallments
+}
+
+// NewMockInstallments creates a new mock instance.
+func NewMockInstallments(ctrl *gomock.Controller) *MockInstallments {
+	mock := &MockInstallments{ctrl: ctrl}
+	mock.recorder = &MockInstallmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstallments) EXPECT() *MockInstallmentsMockRecorder {
+	return m.recorder
+}
+
+// CreatePlan mocks base method.
+func (m *MockInstallments) CreatePlan(ctx context.Context, structure entity.InstallmentPlan) (*entity.InstallmentPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlan", ctx, structure)
+	ret0, _ := ret[0].(*entity.InstallmentPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlan indicates an expected call of CreatePlan.
+func (mr *MockInstallmentsMockRecorder) CreatePlan(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlan", reflect.TypeOf((*MockInstallments)(nil).CreatePlan), ctx, structure)
+}
+
+// FindByIDForUpdate mocks base method.
+func (m *MockInstallments) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
+func (mr *MockInstallmentsMockRecorder) FindByIDForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockInstallments)(nil).FindByIDForUpdate), ctx, id)
+}
+
+// FindByPlanForUpdate mocks base method.
+func (m *MockInstallments) FindByPlanForUpdate(ctx context.Context, plan uint) ([]*entity.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPlanForUpdate", ctx, plan)
+	ret0, _ := ret[0].([]*entity.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPlanForUpdate indicates an expected call of FindByPlanForUpdate.
+func (mr *MockInstallmentsMockRecorder) FindByPlanForUpdate(ctx, plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPlanForUpdate", reflect.TypeOf((*MockInstallments)(nil).FindByPlanForUpdate), ctx, plan)
+}
+
+// FindDue mocks base method.
+func (m *MockInstallments) FindDue(ctx context.Context, now time.Time, size int) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, now, size)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockInstallmentsMockRecorder) FindDue(ctx, now, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockInstallments)(nil).FindDue), ctx, now, size)
+}
+
+// FindPlanByID mocks base method.
+func (m *MockInstallments) FindPlanByID(ctx context.Context, id uint) (*entity.InstallmentPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPlanByID", ctx, id)
+	ret0, _ := ret[0].(*entity.InstallmentPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPlanByID indicates an expected call of FindPlanByID.
+func (mr *MockInstallmentsMockRecorder) FindPlanByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPlanByID", reflect.TypeOf((*MockInstallments)(nil).FindPlanByID), ctx, id)
+}
+
+// FindPlans mocks base method.
+func (m *MockInstallments) FindPlans(ctx context.Context, filters filter.InstallmentPlanCollection) ([]*entity.InstallmentPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPlans", ctx, filters)
+	ret0, _ := ret[0].([]*entity.InstallmentPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPlans indicates an expected call of FindPlans.
+func (mr *MockInstallmentsMockRecorder) FindPlans(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPlans", reflect.TypeOf((*MockInstallments)(nil).FindPlans), ctx, filters)
+}
+
+// Update mocks base method.
+func (m *MockInstallments) Update(ctx context.Context, structure *entity.Installment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, structure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockInstallmentsMockRecorder) Update(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInstallments)(nil).Update), ctx, structure)
+}
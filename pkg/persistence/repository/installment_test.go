@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestInstallmentRepository_CreatePlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "installment_plan" ("account_id","operation_id","card_id","amount","installments","created_by","created_at","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode")
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+		RETURNING "id"
+	`)).WithArgs(1, 1, nil, 1000, 2, "", now, "", "", "", "", "", "", "").WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "installment"`)).
+		WithArgs(1, 1, 500, now, nil, nil, nil, 1, 2, 500, now.AddDate(0, 1, 0), nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+	dbmock.ExpectCommit()
+
+	installmentRepository := NewInstallment(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	plan, err := installmentRepository.CreatePlan(ctx, entity.InstallmentPlan{
+		Account:   1,
+		Type:      1,
+		Amount:    1000,
+		Count:     2,
+		CreatedAt: now,
+		Installments: []*entity.Installment{
+			{Number: 1, Amount: 500, DueDate: now},
+			{Number: 2, Amount: 500, DueDate: now.AddDate(0, 1, 0)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), plan.ID)
+	assert.Equal(t, uint(1), plan.Installments[0].Plan)
+	assert.Equal(t, uint(2), plan.Installments[1].ID)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestInstallmentRepository_CreatePlan_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "installment_plan"`)).WillReturnError(gorm.ErrInvalidData)
+	dbmock.ExpectRollback()
+
+	installmentRepository := NewInstallment(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	plan, err := installmentRepository.CreatePlan(ctx, entity.InstallmentPlan{Account: 1, Type: 1, Amount: 1000, Count: 2})
+	assert.Nil(t, plan)
+	assert.EqualError(t, err, ErrInstallmentPlanCreate.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestInstallmentRepository_FindDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id" FROM "installment"
+		WHERE transaction_id IS NULL AND cancelled_at IS NULL AND due_date <= $1
+		ORDER BY due_date
+		LIMIT 100
+	`)).WithArgs(now).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow(uint(3)).AddRow(uint(7)),
+	)
+
+	installmentRepository := NewInstallment(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	ids, err := installmentRepository.FindDue(ctx, now, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{3, 7}, ids)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestInstallmentRepository_FindByIDForUpdate_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "installment"
+		WHERE "installment"."id" = $1
+		ORDER BY "installment"."id"
+		LIMIT 1 FOR UPDATE
+	`)).WithArgs(uint(1)).WillReturnError(gorm.ErrRecordNotFound)
+
+	installmentRepository := NewInstallment(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	installment, err := installmentRepository.FindByIDForUpdate(ctx, 1)
+	assert.Nil(t, installment)
+	assert.EqualError(t, err, ErrInstallmentNotFound.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestInstallmentRepository_FindByPlanForUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "installment" WHERE installment_plan_id = $1 ORDER BY number FOR UPDATE`)).
+		WithArgs(uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "installment_plan_id", "number", "amount"}).AddRow(1, 1, 1, 500).AddRow(2, 1, 2, 500))
+
+	installmentRepository := NewInstallment(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	installments, err := installmentRepository.FindByPlanForUpdate(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, installments, 2)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
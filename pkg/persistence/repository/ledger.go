@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrJournalEntryCreate = domain.Internal("journal_entry_create_failed", "failed to create new journal entry")
+	ErrLedgerBalance      = domain.Internal("ledger_balance_failed", "failed to compute the ledger balance")
+	ErrLedgerDrift        = domain.Internal("ledger_reconcile_failed", "failed to reconcile the ledger")
+)
+
+type (
+	// Ledgers is append-only, journal entries are never updated or deleted.
+	Ledgers interface {
+		Create(ctx context.Context, structure entity.JournalEntry) (*entity.JournalEntry, error)
+		FindAll(ctx context.Context, filters filter.JournalEntryCollection) ([]*entity.JournalEntry, error)
+		Balance(ctx context.Context, account uint, ledger string) (int64, error)
+		FindDrifts(ctx context.Context) ([]*entity.LedgerDrift, error)
+	}
+
+	Ledger struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewLedger(logger common.Logger, adapter *gorm.DB) *Ledger {
+	return &Ledger{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+// Create stores the journal entry together with its postings.
+func (l *Ledger) Create(ctx context.Context, structure entity.JournalEntry) (*entity.JournalEntry, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, l.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		l.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		return nil, ErrJournalEntryCreate
+	}
+
+	return &structure, nil
+}
+
+func (l *Ledger) FindAll(ctx context.Context, filters filter.JournalEntryCollection) ([]*entity.JournalEntry, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	entries := make([]*entity.JournalEntry, 0)
+	tx := session(ctx, l.adapter)
+	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).
+		Preload("Postings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Order("id").
+		Find(&entries)
+
+	return entries, find.Error
+}
+
+// Balance sums the postings of one ledger of the account.
+func (l *Ledger) Balance(ctx context.Context, account uint, ledger string) (int64, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var sum int64
+	tx := session(ctx, l.adapter)
+	result := tx.Model(&entity.Posting{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("account_id = ? AND ledger = ?", account, ledger).
+		Scan(&sum)
+
+	if result.Error != nil {
+		l.logger.Errorf("tx.Scan() failed with %s\n", result.Error)
+		return 0, ErrLedgerBalance
+	}
+
+	return sum, nil
+}
+
+// FindDrifts returns every account whose limit column differs from its available ledger balance.
+func (l *Ledger) FindDrifts(ctx context.Context) ([]*entity.LedgerDrift, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	drifts := make([]*entity.LedgerDrift, 0)
+	tx := session(ctx, l.adapter)
+	result := tx.Raw(`
+		SELECT account.id AS account_id, account."limit" AS "limit", COALESCE(SUM(posting.amount), 0) AS ledger
+		FROM account
+		LEFT JOIN posting ON posting.account_id = account.id AND posting.ledger = ?
+		GROUP BY account.id, account."limit"
+		HAVING account."limit" <> COALESCE(SUM(posting.amount), 0)
+		ORDER BY account.id
+	`, entity.LedgerAvailable).Scan(&drifts)
+
+	if result.Error != nil {
+		l.logger.Errorf("tx.Scan() failed with %s\n", result.Error)
+		return nil, ErrLedgerDrift
+	}
+
+	return drifts, nil
+}
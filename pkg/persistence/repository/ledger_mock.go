@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/ledger.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	filter "ms/card/pkg/persistence/filter"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockLedgers is a mock of Ledgers interface.
+type MockLedgers struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgersMockRecorder
+}
+
+// MockLedgersMockRecorder is the mock recorder for MockLedgers.
+type MockLedgersMockRecorder struct {
+	mock *MockLedgers
+}
+
+// NewMockLedgers creates a new mock instance.
+func NewMockLedgers(ctrl *gomock.Controller) *MockLedgers {
+	mock := &MockLedgers{ctrl: ctrl}
+	mock.recorder = &MockLedgersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgers) EXPECT() *MockLedgersMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method.
+func (m *MockLedgers) Balance(ctx context.Context, account uint, ledger string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", ctx, account, ledger)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockLedgersMockRecorder) Balance(ctx, account, ledger interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockLedgers)(nil).Balance), ctx, account, ledger)
+}
+
+// Create mocks base method.
+func (m *MockLedgers) Create(ctx context.Context, structure entity.JournalEntry) (*entity.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, structure)
+	ret0, _ := ret[0].(*entity.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLedgersMockRecorder) Create(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLedgers)(nil).Create), ctx, structure)
+}
+
+// FindAll mocks base method.
+func (m *MockLedgers) FindAll(ctx context.Context, filters filter.JournalEntryCollection) ([]*entity.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
+	ret0, _ := ret[0].([]*entity.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockLedgersMockRecorder) FindAll(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockLedgers)(nil).FindAll), ctx, filters)
+}
+
+// FindDrifts mocks base method.
+func (m *MockLedgers) FindDrifts(ctx context.Context) ([]*entity.LedgerDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDrifts", ctx)
+	ret0, _ := ret[0].([]*entity.LedgerDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDrifts indicates an expected call of FindDrifts.
+func (mr *MockLedgersMockRecorder) FindDrifts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDrifts", reflect.TypeOf((*MockLedgers)(nil).FindDrifts), ctx)
+}
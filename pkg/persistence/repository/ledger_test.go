@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestLedgerRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "journal_entry" ("account_id","description","created_at")
+		VALUES ($1,$2,$3)
+		RETURNING "id"
+	`)).WithArgs(1, "limit consumed", now).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "posting"`)).
+		WithArgs(1, 1, entity.LedgerAvailable, -1000, 1, 1, entity.LedgerUsed, 1000).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+	dbmock.ExpectCommit()
+
+	ledgerRepository := NewLedger(logger, gormdb)
+
+	entry := entity.JournalEntry{Account: 1, Description: "limit consumed", CreatedAt: now}
+	entry.Transfer(entity.LedgerAvailable, entity.LedgerUsed, 1000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	created, err := ledgerRepository.Create(ctx, entry)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), created.ID)
+	assert.Equal(t, uint(1), created.Postings[1].Entry)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLedgerRepository_Balance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "posting" WHERE account_id = $1 AND ledger = $2`)).
+		WithArgs(1, entity.LedgerAvailable).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(1500)))
+
+	ledgerRepository := NewLedger(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	balance, err := ledgerRepository.Balance(ctx, 1, entity.LedgerAvailable)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500), balance)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLedgerRepository_FindDrifts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT account.id AS account_id, account."limit" AS "limit", COALESCE(SUM(posting.amount), 0) AS ledger
+		FROM account
+		LEFT JOIN posting ON posting.account_id = account.id AND posting.ledger = $1
+		GROUP BY account.id, account."limit"
+		HAVING account."limit" <> COALESCE(SUM(posting.amount), 0)
+		ORDER BY account.id
+	`)).WithArgs(entity.LedgerAvailable).WillReturnRows(
+		sqlmock.NewRows([]string{"account_id", "limit", "ledger"}).AddRow(uint(3), 900, 1000),
+	)
+
+	ledgerRepository := NewLedger(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	drifts, err := ledgerRepository.FindDrifts(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.LedgerDrift{{Account: 3, Limit: 900, Ledger: 1000}}, drifts)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLedgerRepository_FindDrifts_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT account.id AS account_id`)).WillReturnError(gorm.ErrInvalidDB)
+
+	ledgerRepository := NewLedger(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	drifts, err := ledgerRepository.FindDrifts(ctx)
+	assert.Nil(t, drifts)
+	assert.EqualError(t, err, ErrLedgerDrift.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
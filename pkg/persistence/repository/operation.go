@@ -6,6 +6,7 @@ import (
 	"golang.org/x/xerrors"
 	"gorm.io/gorm"
 	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
 	"ms/card/pkg/persistence"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
@@ -13,17 +14,20 @@ import (
 )
 
 var (
-	ErrOperationCreate              = xerrors.New("failed to create new operation")
-	ErrOperationCreateAlreadyExists = xerrors.New("operation already exists")
-	ErrOperationCreateNotFound      = xerrors.New("operation not found")
-	ErrOperationFindByID            = xerrors.New("failed fetch operation")
+	ErrOperationCreate              = domain.Internal("operation_create_failed", "failed to create new operation")
+	ErrOperationCreateAlreadyExists = domain.Conflict("operation_already_exists", "operation already exists")
+	ErrOperationCreateNotFound      = domain.NotFound("operation_not_found", "operation not found")
+	ErrOperationFindByID            = domain.Internal("operation_find_failed", "failed fetch operation")
+	ErrOperationCount               = domain.Internal("operation_count_failed", "failed to count the operations")
+	ErrOperationReversalNotFound    = domain.Internal("operation_reversal_not_found", "reversal operation not found")
 )
 
 type (
 	Operations interface {
 		Create(ctx context.Context, structure entity.Operation) (*entity.Operation, error)
 		FindByID(ctx context.Context, id uint) (*entity.Operation, error)
-		FindAll(ctx context.Context, filters filter.OperationCollection) ([]*entity.Operation, error)
+		FindReversal(ctx context.Context) (*entity.Operation, error)
+		FindAll(ctx context.Context, filters filter.OperationCollection) (*entity.OperationCollection, error)
 	}
 
 	Operation struct {
@@ -43,7 +47,7 @@ func (a *Operation) Create(ctx context.Context, structure entity.Operation) (*en
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
-	tx := a.adapter.WithContext(ctx)
+	tx := session(ctx, a.adapter)
 	if result := tx.Create(&structure); result.Error != nil {
 		a.logger.Errorf("tx.Create() failed with %s\n", result.Error)
 		var err *pgconn.PgError
@@ -62,8 +66,8 @@ func (a *Operation) FindByID(ctx context.Context, id uint) (*entity.Operation, e
 	defer span.End()
 
 	var operation entity.Operation
-	tx := a.adapter.WithContext(ctx)
-	if result := tx.Select([]string{"id", "description", "debit"}).First(&operation, id); result.Error != nil {
+	tx := session(ctx, a.adapter)
+	if result := tx.Select([]string{"id", "description", "debit", "fee", "reversal"}).First(&operation, id); result.Error != nil {
 		a.logger.Errorf("tx.First() failed with %s\n", result.Error)
 		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, ErrOperationCreateNotFound
@@ -75,17 +79,52 @@ func (a *Operation) FindByID(ctx context.Context, id uint) (*entity.Operation, e
 	return &operation, nil
 }
 
-func (a *Operation) FindAll(ctx context.Context, filters filter.OperationCollection) ([]*entity.Operation, error) {
+// FindReversal returns the credit operation reversals are booked under, created by migration
+// 0013_operation_reversal.
+func (a *Operation) FindReversal(ctx context.Context) (*entity.Operation, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var operation entity.Operation
+	tx := session(ctx, a.adapter)
+	if result := tx.Select([]string{"id", "description", "debit", "fee", "reversal"}).Where("reversal").First(&operation); result.Error != nil {
+		a.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrOperationReversalNotFound
+		}
+
+		return nil, ErrOperationFindByID
+	}
+
+	return &operation, nil
+}
+
+func (a *Operation) FindAll(ctx context.Context, filters filter.OperationCollection) (*entity.OperationCollection, error) {
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
 	operations := make([]*entity.Operation, 0)
-	tx := a.adapter.WithContext(ctx)
+	tx := session(ctx, a.adapter)
 	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).Select([]string{
 		"id",
 		"description",
 		"debit",
-	}).Find(&operations)
+		"fee",
+		"reversal",
+	}).Order(filters.Sort.Order()).Find(&operations)
+
+	if find.Error != nil {
+		return nil, find.Error
+	}
+
+	var total int64
+	if count := tx.Model(&entity.Operation{}).Scopes(filters.Filter()).Count(&total); count.Error != nil {
+		a.logger.Errorf("tx.Count() failed with %s\n", count.Error)
+		return nil, ErrOperationCount
+	}
 
-	return operations, find.Error
+	return &entity.OperationCollection{
+		Pagination: persistence.NewPagination(filters.Page, filters.Size, total),
+		Data:       operations,
+	}, nil
 }
@@ -52,10 +52,10 @@ func (mr *MockOperationsMockRecorder) Create(ctx, structure interface{}) *gomock
 }
 
 // FindAll mocks base method.
-func (m *MockOperations) FindAll(ctx context.Context, filters filter.OperationCollection) ([]*entity.Operation, error) {
+func (m *MockOperations) FindAll(ctx context.Context, filters filter.OperationCollection) (*entity.OperationCollection, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
-	ret0, _ := ret[0].([]*entity.Operation)
+	ret0, _ := ret[0].(*entity.OperationCollection)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -80,3 +80,18 @@ func (mr *MockOperationsMockRecorder) FindByID(ctx, id interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOperations)(nil).FindByID), ctx, id)
 }
+
+// FindReversal mocks base method.
+func (m *MockOperations) FindReversal(ctx context.Context) (*entity.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReversal", ctx)
+	ret0, _ := ret[0].(*entity.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReversal indicates an expected call of FindReversal.
+func (mr *MockOperationsMockRecorder) FindReversal(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReversal", reflect.TypeOf((*MockOperations)(nil).FindReversal), ctx)
+}
@@ -9,6 +9,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"ms/card/pkg/common"
+	"ms/card/pkg/persistence"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"regexp"
@@ -31,10 +32,10 @@ func TestOperationRepository_Create(t *testing.T) {
 
 	dbmock.ExpectBegin()
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO "operation" ("description","debit") 
-		VALUES ($1,$2) 
+		INSERT INTO "operation" ("description","debit","fee","reversal","created_by") 
+		VALUES ($1,$2,$3,$4,$5) 
 		RETURNING "id"
-	`)).WithArgs("COMPRA A VISTA", true).WillReturnRows(
+	`)).WithArgs("COMPRA A VISTA", true, false, false, "").WillReturnRows(
 		sqlmock.NewRows([]string{"id"}).AddRow(uint(1)),
 	)
 	dbmock.ExpectCommit()
@@ -143,7 +144,7 @@ func TestOperationRepository_FindByID(t *testing.T) {
 	assert.NoError(t, err)
 
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","description","debit"
+		SELECT "id","description","debit","fee","reversal"
 		FROM "operation" 
 		WHERE "operation"."id" = $1 
 		ORDER BY "operation"."id" 
@@ -181,7 +182,7 @@ func TestOperationRepository_FindByID_Error(t *testing.T) {
 	assert.NoError(t, err)
 
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","description","debit"
+		SELECT "id","description","debit","fee","reversal"
 		FROM "operation" 
 		WHERE "operation"."id" = $1 
 		ORDER BY "operation"."id" 
@@ -216,7 +217,7 @@ func TestOperationRepository_FindByID_RecordNotFound_Error(t *testing.T) {
 	assert.NoError(t, err)
 
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","description","debit"
+		SELECT "id","description","debit","fee","reversal"
 		FROM "operation" 
 		WHERE "operation"."id" = $1 
 		ORDER BY "operation"."id" 
@@ -236,6 +237,67 @@ func TestOperationRepository_FindByID_RecordNotFound_Error(t *testing.T) {
 	}
 }
 
+func TestOperationRepository_FindReversal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","description","debit","fee","reversal"
+		FROM "operation"
+		WHERE reversal
+		ORDER BY "operation"."id"
+		LIMIT 1
+	`)).WillReturnRows(sqlmock.NewRows([]string{"id", "description", "debit", "reversal"}).AddRow(uint(6), "ESTORNO", false, true))
+
+	OperationRepository := NewOperation(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	Operation, err := OperationRepository.FindReversal(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(6), Operation.ID)
+	assert.True(t, Operation.Reversal)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOperationRepository_FindReversal_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","description","debit","fee","reversal" FROM "operation" WHERE reversal`)).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	OperationRepository := NewOperation(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	Operation, err := OperationRepository.FindReversal(ctx)
+	assert.Nil(t, Operation)
+	assert.ErrorIs(t, err, ErrOperationReversalNotFound)
+}
+
 func TestOperationRepository_Collection(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -250,21 +312,26 @@ func TestOperationRepository_Collection(t *testing.T) {
 	assert.NoError(t, err)
 
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","description","debit"
+		SELECT "id","description","debit","fee","reversal"
 		FROM "operation"
+		ORDER BY id
 		LIMIT 10
 	`)).WillReturnRows(sqlmock.NewRows([]string{"id", "description"}).
 		AddRow(uint(1), "COMPRA A VISTA").
 		AddRow(uint(2), "COMPRA PARCELADA"),
 	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "operation"`)).WillReturnRows(
+		sqlmock.NewRows([]string{"count"}).AddRow(int64(2)),
+	)
 
 	OperationRepository := NewOperation(logger, gormdb)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 	Operations, err := OperationRepository.FindAll(ctx, filter.OperationCollection{})
-	assert.Len(t, Operations, 2)
 	assert.NoError(t, err)
+	assert.Len(t, Operations.Data, 2)
+	assert.Equal(t, persistence.Pagination{Page: 1, Size: 10, Total: 2}, Operations.Pagination)
 
 	if err := dbmock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrRiskDecisionCreate = domain.Internal("risk_decision_create_failed", "failed to create new risk decision")
+	ErrRiskDecisionFind   = domain.Internal("risk_decision_find_failed", "failed fetch the risk decisions")
+)
+
+type (
+	RiskDecisions interface {
+		Create(ctx context.Context, structure entity.RiskDecision) (*entity.RiskDecision, error)
+		FindAll(ctx context.Context, filters filter.RiskDecisionCollection) ([]*entity.RiskDecision, error)
+	}
+
+	RiskDecision struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewRiskDecision(logger common.Logger, adapter *gorm.DB) *RiskDecision {
+	return &RiskDecision{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (r *RiskDecision) Create(ctx context.Context, structure entity.RiskDecision) (*entity.RiskDecision, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, r.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		r.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		return nil, ErrRiskDecisionCreate
+	}
+
+	return &structure, nil
+}
+
+func (r *RiskDecision) FindAll(ctx context.Context, filters filter.RiskDecisionCollection) ([]*entity.RiskDecision, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	decisions := make([]*entity.RiskDecision, 0)
+	tx := session(ctx, r.adapter)
+	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).Order("id").Find(&decisions)
+	if find.Error != nil {
+		r.logger.Errorf("tx.Find() failed with %s\n", find.Error)
+		return nil, ErrRiskDecisionFind
+	}
+
+	return decisions, nil
+}
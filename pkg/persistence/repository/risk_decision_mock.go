@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/risk_decision.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	filter "ms/card/pkg/persistence/filter"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockRiskDecisions is a mock of RiskDecisions interface.
+type MockRiskDecisions struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskDecisionsMockRecorder
+}
+
+// MockRiskDecisionsMockRecorder is the mock recorder for MockRiskDecisions.
+type MockRiskDecisionsMockRecorder struct {
+	mock *MockRiskDecisions
+}
+
+// NewMockRiskDecisions creates a new mock instance.
+func NewMockRiskDecisions(ctrl *gomock.Controller) *MockRiskDecisions {
+	mock := &MockRiskDecisions{ctrl: ctrl}
+	mock.recorder = &MockRiskDecisionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskDecisions) EXPECT() *MockRiskDecisionsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRiskDecisions) Create(ctx context.Context, structure entity.RiskDecision) (*entity.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, structure)
+	ret0, _ := ret[0].(*entity.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRiskDecisionsMockRecorder) Create(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRiskDecisions)(nil).Create), ctx, structure)
+}
+
+// FindAll mocks base method.
+func (m *MockRiskDecisions) FindAll(ctx context.Context, filters filter.RiskDecisionCollection) ([]*entity.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
+	ret0, _ := ret[0].([]*entity.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockRiskDecisionsMockRecorder) FindAll(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRiskDecisions)(nil).FindAll), ctx, filters)
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestRiskDecisionRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO "risk_decision" ("account_id","card_id","transaction_id","amount","outcome","score","reasons","created_at")
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		RETURNING "id"
+	`)).WithArgs(1, nil, nil, 6000, "decline", 90, "rules:velocity,rules:amount_spike", now).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectCommit()
+
+	riskDecisionRepository := NewRiskDecision(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	decision, err := riskDecisionRepository.Create(ctx, entity.RiskDecision{
+		Account:   1,
+		Amount:    6000,
+		Outcome:   "decline",
+		Score:     90,
+		Reasons:   entity.List{"rules:velocity", "rules:amount_spike"},
+		CreatedAt: now,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), decision.ID)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRiskDecisionRepository_Create_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "risk_decision"`)).WillReturnError(gorm.ErrInvalidData)
+	dbmock.ExpectRollback()
+
+	riskDecisionRepository := NewRiskDecision(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	decision, err := riskDecisionRepository.Create(ctx, entity.RiskDecision{Account: 1})
+	assert.Nil(t, decision)
+	assert.EqualError(t, err, ErrRiskDecisionCreate.Error())
+}
+
+func TestRiskDecisionRepository_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "risk_decision" WHERE account_id = $1 AND outcome = $2 ORDER BY id LIMIT 10
+	`)).WithArgs(1, "review").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "outcome", "score", "reasons"}).AddRow(uint(3), uint(1), "review", 50, "rules:velocity"),
+	)
+
+	riskDecisionRepository := NewRiskDecision(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	decisions, err := riskDecisionRepository.FindAll(ctx, filter.RiskDecisionCollection{Account: 1, Outcome: "review"})
+	assert.NoError(t, err)
+	if assert.Len(t, decisions, 1) {
+		assert.Equal(t, entity.List{"rules:velocity"}, decisions[0].Reasons)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrSpendingControlSave     = domain.Internal("spending_control_save_failed", "failed to save spending control")
+	ErrSpendingControlNotFound = domain.NotFound("spending_control_not_found", "spending control not found")
+	ErrSpendingControlFind     = domain.Internal("spending_control_find_failed", "failed fetch the spending controls")
+)
+
+type (
+	SpendingControls interface {
+		Save(ctx context.Context, structure *entity.SpendingControl) error
+		FindByOwner(ctx context.Context, account uint, card uint) (*entity.SpendingControl, error)
+		FindApplicable(ctx context.Context, account uint, card uint) ([]*entity.SpendingControl, error)
+	}
+
+	SpendingControl struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewSpendingControl(logger common.Logger, adapter *gorm.DB) *SpendingControl {
+	return &SpendingControl{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+// Save creates or replaces the spending control of the account, or of the card when it is set.
+func (s *SpendingControl) Save(ctx context.Context, structure *entity.SpendingControl) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, s.adapter).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "card_id"}},
+		UpdateAll: true,
+	})
+
+	if result := tx.Create(structure); result.Error != nil {
+		s.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		return ErrSpendingControlSave
+	}
+
+	return nil
+}
+
+func (s *SpendingControl) FindByOwner(ctx context.Context, account uint, card uint) (*entity.SpendingControl, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var control entity.SpendingControl
+	tx := session(ctx, s.adapter)
+	if result := tx.Where("account_id = ? AND card_id = ?", account, card).First(&control); result.Error != nil {
+		s.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrSpendingControlNotFound
+		}
+
+		return nil, ErrSpendingControlFind
+	}
+
+	return &control, nil
+}
+
+// FindApplicable returns the account wide control and the one of the card, whichever exist.
+func (s *SpendingControl) FindApplicable(ctx context.Context, account uint, card uint) ([]*entity.SpendingControl, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	controls := make([]*entity.SpendingControl, 0)
+	tx := session(ctx, s.adapter)
+	if result := tx.Where("account_id = ? AND card_id IN ?", account, []uint{0, card}).Order("card_id").Find(&controls); result.Error != nil {
+		s.logger.Errorf("tx.Find() failed with %s\n", result.Error)
+		return nil, ErrSpendingControlFind
+	}
+
+	return controls, nil
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/spending_control.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockSpendingControls is a mock of SpendingControls interface.
+type MockSpendingControls struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpendingControlsMockRecorder
+}
+
+// MockSpendingControlsMockRecorder is the mock recorder for MockSpendingControls.
+type MockSpendingControlsMockRecorder struct {
+	mock *MockSpendingControls
+}
+
+// NewMockSpendingControls creates a new mock instance.
+func NewMockSpendingControls(ctrl *gomock.Controller) *MockSpendingControls {
+	mock := &MockSpendingControls{ctrl: ctrl}
+	mock.recorder = &MockSpendingControlsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpendingControls) EXPECT() *MockSpendingControlsMockRecorder {
+	return m.recorder
+}
+
+// FindApplicable mocks base method.
+func (m *MockSpendingControls) FindApplicable(ctx context.Context, account, card uint) ([]*entity.SpendingControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApplicable", ctx, account, card)
+	ret0, _ := ret[0].([]*entity.SpendingControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApplicable indicates an expected call of FindApplicable.
+func (mr *MockSpendingControlsMockRecorder) FindApplicable(ctx, account, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicable", reflect.TypeOf((*MockSpendingControls)(nil).FindApplicable), ctx, account, card)
+}
+
+// FindByOwner mocks base method.
+func (m *MockSpendingControls) FindByOwner(ctx context.Context, account, card uint) (*entity.SpendingControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOwner", ctx, account, card)
+	ret0, _ := ret[0].(*entity.SpendingControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOwner indicates an expected call of FindByOwner.
+func (mr *MockSpendingControlsMockRecorder) FindByOwner(ctx, account, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOwner", reflect.TypeOf((*MockSpendingControls)(nil).FindByOwner), ctx, account, card)
+}
+
+// Save mocks base method.
+func (m *MockSpendingControls) Save(ctx context.Context, structure *entity.SpendingControl) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, structure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSpendingControlsMockRecorder) Save(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSpendingControls)(nil).Save), ctx, structure)
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestSpendingControlRepository_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(`
+		INSERT INTO "spending_control" ("account_id","card_id","max_amount","daily_limit","monthly_limit","blocked_mccs","block_ecommerce","block_international","updated_at")
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		ON CONFLICT ("account_id","card_id") DO UPDATE SET
+		"updated_at"=$10,"max_amount"="excluded"."max_amount","daily_limit"="excluded"."daily_limit","monthly_limit"="excluded"."monthly_limit","blocked_mccs"="excluded"."blocked_mccs","block_ecommerce"="excluded"."block_ecommerce","block_international"="excluded"."block_international"
+	`)).
+		WithArgs(uint(1), uint(0), int64(5000), int64(10000), int64(0), "7995,5933", true, false, now, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectCommit()
+
+	spendingControlRepository := NewSpendingControl(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	err = spendingControlRepository.Save(ctx, &entity.SpendingControl{
+		Account:        1,
+		MaxAmount:      5000,
+		DailyLimit:     10000,
+		BlockedMCCs:    entity.List{"7995", "5933"},
+		BlockEcommerce: true,
+		UpdatedAt:      now,
+	})
+	assert.NoError(t, err)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSpendingControlRepository_FindByOwner_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "spending_control"
+		WHERE account_id = $1 AND card_id = $2
+		ORDER BY "spending_control"."account_id"
+		LIMIT 1
+	`)).WithArgs(1, 2).WillReturnError(gorm.ErrRecordNotFound)
+
+	spendingControlRepository := NewSpendingControl(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	control, err := spendingControlRepository.FindByOwner(ctx, 1, 2)
+	assert.Nil(t, control)
+	assert.EqualError(t, err, ErrSpendingControlNotFound.Error())
+}
+
+func TestSpendingControlRepository_FindApplicable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "spending_control"
+		WHERE account_id = $1 AND card_id IN ($2,$3)
+		ORDER BY card_id
+	`)).WithArgs(1, 0, 2).WillReturnRows(
+		sqlmock.NewRows([]string{"account_id", "card_id", "max_amount", "blocked_mccs"}).
+			AddRow(uint(1), uint(0), int64(5000), "7995").
+			AddRow(uint(1), uint(2), int64(1000), ""),
+	)
+
+	spendingControlRepository := NewSpendingControl(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	controls, err := spendingControlRepository.FindApplicable(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, controls, 2)
+	assert.Equal(t, entity.List{"7995"}, controls[0].BlockedMCCs)
+	assert.Equal(t, uint(2), controls[1].Card)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
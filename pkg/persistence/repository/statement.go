@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/filter"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrStatementCreate   = domain.Internal("statement_create_failed", "failed to create new statement")
+	ErrStatementNotFound = domain.NotFound("statement_not_found", "statement not found")
+	ErrStatementFind     = domain.Internal("statement_find_failed", "failed fetch the statement")
+)
+
+type (
+	// Statements has no update or delete, a closed statement is immutable.
+	Statements interface {
+		Create(ctx context.Context, structure entity.Statement) (*entity.Statement, error)
+		FindLast(ctx context.Context, account uint) (*entity.Statement, error)
+		FindByPeriod(ctx context.Context, account uint, period string) (*entity.Statement, error)
+		FindAll(ctx context.Context, filters filter.StatementCollection) ([]*entity.Statement, error)
+	}
+
+	Statement struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+)
+
+func NewStatement(logger common.Logger, adapter *gorm.DB) *Statement {
+	return &Statement{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+// Create stores the statement together with its items.
+func (s *Statement) Create(ctx context.Context, structure entity.Statement) (*entity.Statement, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, s.adapter)
+	if result := tx.Create(&structure); result.Error != nil {
+		s.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		return nil, ErrStatementCreate
+	}
+
+	return &structure, nil
+}
+
+// FindLast returns the most recent statement of the account, without its items.
+func (s *Statement) FindLast(ctx context.Context, account uint) (*entity.Statement, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var statement entity.Statement
+	tx := session(ctx, s.adapter)
+	if result := tx.Where("account_id = ?", account).Order("closing_date DESC").Take(&statement); result.Error != nil {
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrStatementNotFound
+		}
+
+		s.logger.Errorf("tx.Take() failed with %s\n", result.Error)
+		return nil, ErrStatementFind
+	}
+
+	return &statement, nil
+}
+
+func (s *Statement) FindByPeriod(ctx context.Context, account uint, period string) (*entity.Statement, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var statement entity.Statement
+	tx := session(ctx, s.adapter)
+	result := tx.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	}).Where("account_id = ? AND period = ?", account, period).Take(&statement)
+
+	if result.Error != nil {
+		s.logger.Errorf("tx.Take() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrStatementNotFound
+		}
+
+		return nil, ErrStatementFind
+	}
+
+	return &statement, nil
+}
+
+func (s *Statement) FindAll(ctx context.Context, filters filter.StatementCollection) ([]*entity.Statement, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	statements := make([]*entity.Statement, 0)
+	tx := session(ctx, s.adapter)
+	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).
+		Order("closing_date DESC").
+		Find(&statements)
+
+	return statements, find.Error
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/persistence/repository/statement.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	filter "ms/card/pkg/persistence/filter"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockStatements is a mock of Statements interface.
+type MockStatements struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementsMockRecorder
+}
+
+// MockStatementsMockRecorder is the mock recorder for MockStatements.
+type MockStatementsMockRecorder struct {
+	mock *MockStatements
+}
+
+// NewMockStatements creates a new mock instance.
+func NewMockStatements(ctrl *gomock.Controller) *MockStatements {
+	mock := &MockStatements{ctrl: ctrl}
+	mock.recorder = &MockStatementsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatements) EXPECT() *MockStatementsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatements) Create(ctx context.Context, structure entity.Statement) (*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, structure)
+	ret0, _ := ret[0].(*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatementsMockRecorder) Create(ctx, structure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatements)(nil).Create), ctx, structure)
+}
+
+// FindAll mocks base method.
+func (m *MockStatements) FindAll(ctx context.Context, filters filter.StatementCollection) ([]*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
+	ret0, _ := ret[0].([]*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStatementsMockRecorder) FindAll(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStatements)(nil).FindAll), ctx, filters)
+}
+
+// FindByPeriod mocks base method.
+func (m *MockStatements) FindByPeriod(ctx context.Context, account uint, period string) (*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPeriod", ctx, account, period)
+	ret0, _ := ret[0].(*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPeriod indicates an expected call of FindByPeriod.
+func (mr *MockStatementsMockRecorder) FindByPeriod(ctx, account, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPeriod", reflect.TypeOf((*MockStatements)(nil).FindByPeriod), ctx, account, period)
+}
+
+// FindLast mocks base method.
+func (m *MockStatements) FindLast(ctx context.Context, account uint) (*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLast", ctx, account)
+	ret0, _ := ret[0].(*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLast indicates an expected call of FindLast.
+func (mr *MockStatementsMockRecorder) FindLast(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLast", reflect.TypeOf((*MockStatements)(nil).FindLast), ctx, account)
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestStatementRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	closing := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "statement"`)).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow("1"),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "statement_item"`)).
+		WithArgs(1, 7, 1, -1000, closing.AddDate(0, 0, -1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	dbmock.ExpectCommit()
+
+	statementRepository := NewStatement(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	statement, err := statementRepository.Create(ctx, entity.Statement{
+		Account:     1,
+		Period:      "2022-03",
+		PeriodStart: closing.AddDate(0, -1, 0),
+		ClosingDate: closing,
+		DueDate:     closing.AddDate(0, 0, 7),
+		Purchases:   1000,
+		Items: []*entity.StatementItem{
+			{Transaction: 7, Type: 1, Amount: -1000, CreatedAt: closing.AddDate(0, 0, -1)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), statement.ID)
+	assert.Equal(t, uint(1), statement.Items[0].Statement)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStatementRepository_FindLast_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "statement"
+		WHERE account_id = $1
+		ORDER BY closing_date DESC
+		LIMIT 1
+	`)).WithArgs(1).WillReturnError(gorm.ErrRecordNotFound)
+
+	statementRepository := NewStatement(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	statement, err := statementRepository.FindLast(ctx, 1)
+	assert.Nil(t, statement)
+	assert.EqualError(t, err, ErrStatementNotFound.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStatementRepository_FindByPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "statement"
+		WHERE account_id = $1 AND period = $2
+		LIMIT 1
+	`)).WithArgs(1, "2022-03").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "period", "closing_balance"}).AddRow(uint(3), uint(1), "2022-03", 1000),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "statement_item"
+		WHERE "statement_item"."statement_id" = $1
+		ORDER BY created_at, id
+	`)).WithArgs(3).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "statement_id", "transaction_id", "amount"}).AddRow(uint(1), uint(3), uint(7), -1000),
+	)
+
+	statementRepository := NewStatement(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	statement, err := statementRepository.FindByPeriod(ctx, 1, "2022-03")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), statement.ClosingBalance)
+	assert.Len(t, statement.Items, 1)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
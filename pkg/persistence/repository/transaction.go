@@ -1,24 +1,90 @@
 package repository
 
 import (
+	"github.com/jackc/pgconn"
 	"golang.org/x/net/context"
 	"golang.org/x/xerrors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
 	"ms/card/pkg/persistence"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"ms/card/pkg/telemetry/jaeger"
+	"time"
 )
 
 var (
-	ErrTransactionCreate = xerrors.New("failed to create new transaction")
+	ErrTransactionCreate    = domain.Internal("transaction_create_failed", "failed to create new transaction")
+	ErrTransactionNotFound  = domain.NotFound("transaction_not_found", "transaction not found")
+	ErrTransactionFindByID  = domain.Internal("transaction_find_failed", "failed fetch the transaction")
+	ErrTransactionSumParent = domain.Internal("transaction_sum_reversals_failed", "failed to sum the transaction reversals")
+	ErrTransactionSumDebits = domain.Internal("transaction_sum_debits_failed", "failed to sum the account spending")
+	ErrTransactionFindRange = domain.Internal("transaction_find_period_failed", "failed fetch the transactions of the period")
+	ErrTransactionTotals    = domain.Internal("transaction_totals_failed", "failed to aggregate the transactions")
+	ErrTransactionExport    = domain.Internal("transaction_export_failed", "failed to export the transactions")
+
+	ErrTransactionAccountMissing   = domain.Unprocessable("transaction_account_missing", "the account of the transaction does not exist")
+	ErrTransactionOperationMissing = domain.Unprocessable("transaction_operation_missing", "the operation of the transaction does not exist")
+)
+
+const (
+	TransactionAccountForeignKey   = "fk_transaction_account"
+	TransactionOperationForeignKey = "fk_transaction_operation"
 )
 
+// transactionForeignKeys maps the foreign keys of the transaction table to the error answered
+// when the row they reference is missing.
+var transactionForeignKeys = map[string]error{
+	TransactionAccountForeignKey:   ErrTransactionAccountMissing,
+	TransactionOperationForeignKey: ErrTransactionOperationMissing,
+}
+
+// transactionColumns are the columns fetched with a transaction.
+var transactionColumns = []string{
+	"id",
+	"account_id",
+	"operation_id",
+	"amount",
+	"hold_id",
+	"parent_id",
+	"installment_plan_id",
+	"card_id",
+	"currency",
+	"original_amount",
+	"rate",
+	"tax",
+	"created_at",
+	"created_by",
+	"merchant_id",
+	"merchant_name",
+	"mcc",
+	"merchant_city",
+	"merchant_country",
+	"terminal_id",
+	"entry_mode",
+}
+
+// exportColumns are the columns of an exported transaction, the operation description comes
+// from the join in Export.
+var exportColumns = append(append([]string{}, transactionColumns...), "operation_description")
+
+// exportJoin renames the operation columns so the transaction filters and sorts, which name
+// their columns bare, stay unambiguous. Postgres flattens it into a plain join.
+const exportJoin = `LEFT JOIN (SELECT id AS operation_ref, description AS operation_description FROM "operation") AS "operation" ON "operation".operation_ref = "transaction".operation_id`
+
 type (
 	Transactions interface {
 		Create(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error)
+		FindByID(ctx context.Context, id uint) (*entity.Transaction, error)
+		FindByIDForUpdate(ctx context.Context, id uint) (*entity.Transaction, error)
+		SumByParent(ctx context.Context, parent uint) (int64, error)
+		SumByPlan(ctx context.Context, plan uint) (int64, error)
+		SumDebitsSince(ctx context.Context, account uint, card uint, since time.Time) (int64, error)
+		FindByAccountBetween(ctx context.Context, account uint, start, end time.Time) ([]*entity.Transaction, error)
 		FindAll(ctx context.Context, filters filter.TransactionCollection) (*entity.TransactionCollection, error)
+		Export(ctx context.Context, filters filter.TransactionCollection, fn func(row *entity.TransactionExport) error) error
 	}
 
 	Transaction struct {
@@ -38,31 +104,276 @@ func (a *Transaction) Create(ctx context.Context, structure entity.Transaction)
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
-	tx := a.adapter.WithContext(ctx)
+	tx := session(ctx, a.adapter)
 	if result := tx.Create(&structure); result.Error != nil {
 		a.logger.Errorf("tx.Create() failed with %s\n", result.Error)
+		var err *pgconn.PgError
+		if xerrors.As(result.Error, &err) && err.Code == ForeignKeyCodeConstraint {
+			if missing, ok := transactionForeignKeys[err.ConstraintName]; ok {
+				return nil, missing
+			}
+		}
+
 		return nil, ErrTransactionCreate
 	}
 
 	return &structure, nil
 }
 
+func (a *Transaction) FindByID(ctx context.Context, id uint) (*entity.Transaction, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return a.first(session(ctx, a.adapter), id)
+}
+
+// FindByIDForUpdate locks the transaction row until the surrounding unit of work finishes,
+// concurrent reversals of the same transaction are serialized.
+func (a *Transaction) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Transaction, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return a.first(session(ctx, a.adapter).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+// SumDebitsSince returns how much the account, or only the card when it is set, spent since
+// the given time. Each purchase is netted of what was reversed from it, a purchase reversed
+// in full no longer counts.
+func (a *Transaction) SumDebitsSince(ctx context.Context, account uint, card uint, since time.Time) (int64, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var sum int64
+	tx := session(ctx, a.adapter).Model(&entity.Transaction{}).
+		Select(`COALESCE(SUM(-amount - (
+			SELECT COALESCE(SUM(reversal.amount), 0) FROM "transaction" AS reversal WHERE reversal.parent_id = "transaction".id
+		)), 0)`).
+		Where("account_id = ? AND amount < 0 AND created_at >= ?", account, since)
+
+	if card != 0 {
+		tx = tx.Where("card_id = ?", card)
+	}
+
+	if result := tx.Scan(&sum); result.Error != nil {
+		a.logger.Errorf("tx.Scan() failed with %s\n", result.Error)
+		return 0, ErrTransactionSumDebits
+	}
+
+	return sum, nil
+}
+
+// SumByParent returns the total already reversed from the parent transaction.
+func (a *Transaction) SumByParent(ctx context.Context, parent uint) (int64, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var sum int64
+	tx := session(ctx, a.adapter)
+	if result := tx.Model(&entity.Transaction{}).Select("COALESCE(SUM(amount), 0)").Where("parent_id = ?", parent).Scan(&sum); result.Error != nil {
+		a.logger.Errorf("tx.Scan() failed with %s\n", result.Error)
+		return 0, ErrTransactionSumParent
+	}
+
+	return sum, nil
+}
+
+// SumByPlan returns the total already reversed from the installments of the plan.
+func (a *Transaction) SumByPlan(ctx context.Context, plan uint) (int64, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var sum int64
+	tx := session(ctx, a.adapter)
+	posted := `parent_id IN (SELECT id FROM "transaction" WHERE installment_plan_id = ?)`
+	if result := tx.Model(&entity.Transaction{}).Select("COALESCE(SUM(amount), 0)").Where(posted, plan).Scan(&sum); result.Error != nil {
+		a.logger.Errorf("tx.Scan() failed with %s\n", result.Error)
+		return 0, ErrTransactionSumParent
+	}
+
+	return sum, nil
+}
+
+// FindByAccountBetween returns every transaction of the account booked in [start, end), oldest first.
+func (a *Transaction) FindByAccountBetween(ctx context.Context, account uint, start, end time.Time) ([]*entity.Transaction, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	transactions := make([]*entity.Transaction, 0)
+	tx := session(ctx, a.adapter)
+	find := tx.Where("account_id = ? AND created_at >= ? AND created_at < ?", account, start, end).
+		Order("created_at, id").
+		Find(&transactions)
+
+	if find.Error != nil {
+		a.logger.Errorf("tx.Find() failed with %s\n", find.Error)
+		return nil, ErrTransactionFindRange
+	}
+
+	return transactions, nil
+}
+
 func (a *Transaction) FindAll(ctx context.Context, filters filter.TransactionCollection) (*entity.TransactionCollection, error) {
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
 	transactions := make([]*entity.Transaction, 0)
-	tx := a.adapter.WithContext(ctx)
-	find := tx.Scopes(filters.Filter(), persistence.Paginator(filters.Page, filters.Size)).Select([]string{
-		"id",
-		"account_id",
-		"operation_id",
-		"amount",
-		"created_at",
-	}).Find(&transactions)
+	tx := session(ctx, a.adapter)
+	find := tx.Scopes(filters.Filter(), page(filters)).Select(transactionColumns).Find(&transactions)
 
 	collection := &entity.TransactionCollection{Data: transactions}
-	collection.Sum()
+	if find.Error != nil {
+		return collection, find.Error
+	}
+
+	if filters.After != 0 || filters.Before != 0 {
+		a.cursor(collection, filters)
+		return collection, nil
+	}
+
+	collection.TransactionTotals = &entity.TransactionTotals{}
+	collection.Operations = make([]*entity.OperationCount, 0)
+	totals := tx.Model(&entity.Transaction{}).Scopes(filters.Filter()).Select(`
+		COALESCE(SUM(amount), 0) AS balance,
+		COALESCE(SUM(CASE WHEN amount < 0 THEN -amount ELSE 0 END), 0) AS debits,
+		COALESCE(SUM(CASE WHEN amount > 0 THEN amount ELSE 0 END), 0) AS credits,
+		COUNT(*) AS count
+	`).Scan(collection.TransactionTotals)
+
+	if totals.Error != nil {
+		a.logger.Errorf("tx.Scan() failed with %s\n", totals.Error)
+		return collection, ErrTransactionTotals
+	}
+
+	operations := tx.Model(&entity.Transaction{}).Scopes(filters.Filter()).
+		Select("operation_id, COUNT(*) AS count").
+		Group("operation_id").
+		Order("operation_id").
+		Scan(&collection.Operations)
+
+	if operations.Error != nil {
+		a.logger.Errorf("tx.Scan() failed with %s\n", operations.Error)
+		return collection, ErrTransactionTotals
+	}
+
+	collection.Pagination = persistence.NewPagination(filters.Page, filters.Size, collection.Count)
+
+	// a page in id order hands its last id on, the next one is read from it by cursor
+	// instead of skipping every row before it
+	more := int64(collection.Page*collection.Size) < collection.Total
+	if more && filters.Sort.Order() == "id" && len(transactions) > 0 {
+		collection.NextCursor = transactions[len(transactions)-1].ID
+	}
+
+	return collection, nil
+}
+
+// cursor fills a page read by after or before. The totals and the operations are the same
+// on every page of a set, they come with the first one: cursor pages follow it and skip
+// the aggregates over the whole set.
+func (a *Transaction) cursor(collection *entity.TransactionCollection, filters filter.TransactionCollection) {
+	transactions := collection.Data
+	_, size := persistence.Normalize(0, filters.Size)
+	collection.Pagination = persistence.Pagination{Size: size}
+
+	// the page was fetched with one extra row to know whether there is more in the
+	// direction of travel, going backwards it also came in descending order.
+	more := len(transactions) > size
+	if more {
+		transactions = transactions[:size]
+	}
+
+	if filters.Before != 0 {
+		for i, j := 0, len(transactions)-1; i < j; i, j = i+1, j-1 {
+			transactions[i], transactions[j] = transactions[j], transactions[i]
+		}
+	}
+
+	collection.Data = transactions
+	if len(transactions) == 0 {
+		return
+	}
+
+	first, last := transactions[0].ID, transactions[len(transactions)-1].ID
+	if filters.After != 0 {
+		collection.PrevCursor = first
+		if more {
+			collection.NextCursor = last
+		}
+	} else {
+		collection.NextCursor = last
+		if more {
+			collection.PrevCursor = first
+		}
+	}
+}
+
+// Export hands every transaction matching the filters to fn, one row at a time as the
+// database sends them, so the whole set is never held in memory. Pages and cursors are
+// ignored, the sort is kept. An error of fn stops the export and is returned as is.
+func (a *Transaction) Export(ctx context.Context, filters filter.TransactionCollection, fn func(row *entity.TransactionExport) error) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	tx := session(ctx, a.adapter)
+	rows, err := tx.Model(&entity.Transaction{}).
+		Scopes(filters.Filter()).
+		Joins(exportJoin).
+		Select(exportColumns).
+		Order(filters.Sort.Order()).
+		Rows()
+	if err != nil {
+		a.logger.Errorf("tx.Rows() failed with %s\n", err)
+		return ErrTransactionExport
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var row entity.TransactionExport
+		if err := tx.ScanRows(rows, &row); err != nil {
+			a.logger.Errorf("tx.ScanRows() failed with %s\n", err)
+			return ErrTransactionExport
+		}
+
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		a.logger.Errorf("rows.Err() failed with %s\n", err)
+		return ErrTransactionExport
+	}
+
+	return nil
+}
+
+// page scopes the query to the requested page. With a cursor the rows are read by
+// primary key from the given id on, so deep pages cost the same as the first one; the
+// handler only takes a cursor with the default sort. Authorizations are paged the same way.
+func page(filters filter.TransactionCollection) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		_, size := persistence.Normalize(0, filters.Size)
+		switch {
+		case filters.After != 0:
+			return db.Where("id > ?", filters.After).Order("id").Limit(size + 1)
+		case filters.Before != 0:
+			return db.Where("id < ?", filters.Before).Order("id DESC").Limit(size + 1)
+		default:
+			return persistence.Paginator(filters.Page, filters.Size)(db).Order(filters.Sort.Order())
+		}
+	}
+}
+
+func (a *Transaction) first(tx *gorm.DB, id uint) (*entity.Transaction, error) {
+	var transaction entity.Transaction
+	if result := tx.Select(transactionColumns).First(&transaction, id); result.Error != nil {
+		a.logger.Errorf("tx.First() failed with %s\n", result.Error)
+		if xerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrTransactionNotFound
+		}
+
+		return nil, ErrTransactionFindByID
+	}
 
-	return collection, find.Error
+	return &transaction, nil
 }
@@ -8,6 +8,7 @@ import (
 	entity "ms/card/pkg/persistence/entity"
 	filter "ms/card/pkg/persistence/filter"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	context "golang.org/x/net/context"
@@ -51,6 +52,20 @@ func (mr *MockTransactionsMockRecorder) Create(ctx, structure interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactions)(nil).Create), ctx, structure)
 }
 
+// Export mocks base method.
+func (m *MockTransactions) Export(ctx context.Context, filters filter.TransactionCollection, fn func(*entity.TransactionExport) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filters, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockTransactionsMockRecorder) Export(ctx, filters, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockTransactions)(nil).Export), ctx, filters, fn)
+}
+
 // FindAll mocks base method.
 func (m *MockTransactions) FindAll(ctx context.Context, filters filter.TransactionCollection) (*entity.TransactionCollection, error) {
 	m.ctrl.T.Helper()
@@ -65,3 +80,93 @@ func (mr *MockTransactionsMockRecorder) FindAll(ctx, filters interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTransactions)(nil).FindAll), ctx, filters)
 }
+
+// FindByAccountBetween mocks base method.
+func (m *MockTransactions) FindByAccountBetween(ctx context.Context, account uint, start, end time.Time) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAccountBetween", ctx, account, start, end)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAccountBetween indicates an expected call of FindByAccountBetween.
+func (mr *MockTransactionsMockRecorder) FindByAccountBetween(ctx, account, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccountBetween", reflect.TypeOf((*MockTransactions)(nil).FindByAccountBetween), ctx, account, start, end)
+}
+
+// FindByID mocks base method.
+func (m *MockTransactions) FindByID(ctx context.Context, id uint) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTransactionsMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTransactions)(nil).FindByID), ctx, id)
+}
+
+// FindByIDForUpdate mocks base method.
+func (m *MockTransactions) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
+func (mr *MockTransactionsMockRecorder) FindByIDForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockTransactions)(nil).FindByIDForUpdate), ctx, id)
+}
+
+// SumByParent mocks base method.
+func (m *MockTransactions) SumByParent(ctx context.Context, parent uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByParent", ctx, parent)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByParent indicates an expected call of SumByParent.
+func (mr *MockTransactionsMockRecorder) SumByParent(ctx, parent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByParent", reflect.TypeOf((*MockTransactions)(nil).SumByParent), ctx, parent)
+}
+
+// SumByPlan mocks base method.
+func (m *MockTransactions) SumByPlan(ctx context.Context, plan uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByPlan", ctx, plan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByPlan indicates an expected call of SumByPlan.
+func (mr *MockTransactionsMockRecorder) SumByPlan(ctx, plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByPlan", reflect.TypeOf((*MockTransactions)(nil).SumByPlan), ctx, plan)
+}
+
+// SumDebitsSince mocks base method.
+func (m *MockTransactions) SumDebitsSince(ctx context.Context, account, card uint, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumDebitsSince", ctx, account, card, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumDebitsSince indicates an expected call of SumDebitsSince.
+func (mr *MockTransactionsMockRecorder) SumDebitsSince(ctx, account, card, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumDebitsSince", reflect.TypeOf((*MockTransactions)(nil).SumDebitsSince), ctx, account, card, since)
+}
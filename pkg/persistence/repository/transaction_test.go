@@ -3,6 +3,7 @@ package repository
 import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/undefinedlabs/go-mpatch"
 	"golang.org/x/net/context"
@@ -10,6 +11,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"ms/card/pkg/common"
+	"ms/card/pkg/persistence"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/filter"
 	"regexp"
@@ -40,10 +42,10 @@ func TestTransactionRepository_Create(t *testing.T) {
 
 	dbmock.ExpectBegin()
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO "transaction" ("account_id","operation_id","amount","created_at") 
-		VALUES ($1,$2,$3,$4) 
+		INSERT INTO "transaction" ("account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_by","created_at","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode")
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20)
 		RETURNING "id"
-	`)).WithArgs(1, 4, 12345, time.Now()).WillReturnRows(
+	`)).WithArgs(1, 4, 12345, nil, nil, nil, nil, "", 0, "", 0, "", time.Now(), "", "", "", "", "", "", "").WillReturnRows(
 		sqlmock.NewRows([]string{"id"}).AddRow("1"),
 	)
 	dbmock.ExpectCommit()
@@ -111,6 +113,46 @@ func TestTransactionRepository_Create_Persist_Error(t *testing.T) {
 	}
 }
 
+func TestTransactionRepository_Create_ForeignKey_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cases := []struct {
+		constraint string
+		expected   error
+	}{
+		{constraint: TransactionAccountForeignKey, expected: ErrTransactionAccountMissing},
+		{constraint: TransactionOperationForeignKey, expected: ErrTransactionOperationMissing},
+		{constraint: "fk_transaction_card", expected: ErrTransactionCreate},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.constraint, func(t *testing.T) {
+			logger := common.NewMockLogger(ctrl)
+			logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+			mockdb, dbmock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer mockdb.Close()
+
+			gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+			assert.NoError(t, err)
+
+			dbmock.ExpectBegin()
+			dbmock.ExpectQuery("^INSERT INTO \"transaction\"(.+)$").WillReturnError(&pgconn.PgError{
+				Code:           ForeignKeyCodeConstraint,
+				ConstraintName: tt.constraint,
+			})
+			dbmock.ExpectRollback()
+
+			transaction, err := NewTransaction(logger, gormdb).Create(context.Background(), entity.Transaction{Account: 99, Type: 4, Amount: 100})
+			assert.Nil(t, transaction)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
 func TestTransactionRepository_Collection(t *testing.T) {
 	patch, err := mpatch.PatchMethod(time.Now, func() time.Time { return time.Date(2022, time.March, 12, 1, 2, 3, 4, time.UTC) })
 	assert.NoError(t, err)
@@ -132,12 +174,27 @@ func TestTransactionRepository_Collection(t *testing.T) {
 	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
 	assert.NoError(t, err)
 
-	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","account_id","operation_id","amount","created_at" FROM "transaction" LIMIT 10`)).WillReturnRows(
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode" FROM "transaction" ORDER BY id LIMIT 10`)).WillReturnRows(
 		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount", "created_at"}).
 			AddRow(uint(1), uint(1), uint(1), 1000, time.Now()).
 			AddRow(uint(2), uint(2), uint(2), 1000, time.Now()).
 			AddRow(uint(3), uint(3), uint(3), -100, time.Now()),
 	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT
+		COALESCE(SUM(amount), 0) AS balance,
+		COALESCE(SUM(CASE WHEN amount < 0 THEN -amount ELSE 0 END), 0) AS debits,
+		COALESCE(SUM(CASE WHEN amount > 0 THEN amount ELSE 0 END), 0) AS credits,
+		COUNT(*) AS count
+		FROM "transaction"
+	`)).WillReturnRows(
+		sqlmock.NewRows([]string{"balance", "debits", "credits", "count"}).AddRow(int64(4900), int64(100), int64(5000), int64(25)),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT operation_id, COUNT(*) AS count FROM "transaction" GROUP BY "operation_id" ORDER BY operation_id
+	`)).WillReturnRows(
+		sqlmock.NewRows([]string{"operation_id", "count"}).AddRow(uint(1), int64(20)).AddRow(uint(4), int64(5)),
+	)
 
 	transactionRepository := NewTransaction(logger, gormdb)
 
@@ -146,7 +203,264 @@ func TestTransactionRepository_Collection(t *testing.T) {
 	collection, err := transactionRepository.FindAll(ctx, filter.TransactionCollection{})
 	assert.NoError(t, err)
 	assert.Len(t, collection.Data, 3)
-	assert.Equal(t, collection.Balance, int64(1900))
+	assert.Equal(t, &entity.TransactionTotals{Balance: 4900, Debits: 100, Credits: 5000, Count: 25}, collection.TransactionTotals)
+	assert.Equal(t, []*entity.OperationCount{{Operation: 1, Count: 20}, {Operation: 4, Count: 5}}, collection.Operations)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_Collection_Totals_Filtered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode"
+		FROM "transaction" WHERE account_id = $1 ORDER BY id LIMIT 1 OFFSET 1
+	`)).WithArgs(1).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount"}).AddRow(uint(2), uint(1), uint(1), -100),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`FROM "transaction" WHERE account_id = $1`)).WithArgs(1).WillReturnRows(
+		sqlmock.NewRows([]string{"balance", "debits", "credits", "count"}).AddRow(int64(-300), int64(300), int64(0), int64(3)),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`FROM "transaction" WHERE account_id = $1 GROUP BY "operation_id"`)).WithArgs(1).WillReturnRows(
+		sqlmock.NewRows([]string{"operation_id", "count"}).AddRow(uint(1), int64(3)),
+	)
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	collection, err := transactionRepository.FindAll(ctx, filter.TransactionCollection{Page: 2, Size: 1, Accounts: []uint{1}})
+	assert.NoError(t, err)
+	assert.Len(t, collection.Data, 1)
+	assert.Equal(t, int64(-300), collection.Balance)
+	assert.Equal(t, int64(3), collection.Count)
+	assert.Equal(t, persistence.Pagination{Page: 2, Size: 1, Total: 3, NextCursor: 2}, collection.Pagination)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_Collection_Merchant_Filtered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode"
+		FROM "transaction" WHERE mcc = $1 AND merchant_name ILIKE $2 AND merchant_country = $3 ORDER BY id LIMIT 10
+	`)).WithArgs("5411", "%market%", "BR").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount", "merchant_name", "mcc", "merchant_country"}).
+			AddRow(uint(2), uint(1), uint(1), -100, "SUPER MARKET", "5411", "BR"),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`FROM "transaction" WHERE mcc = $1 AND merchant_name ILIKE $2 AND merchant_country = $3`)).
+		WithArgs("5411", "%market%", "BR").
+		WillReturnRows(sqlmock.NewRows([]string{"balance", "debits", "credits", "count"}).AddRow(int64(-100), int64(100), int64(0), int64(1)))
+	dbmock.ExpectQuery(regexp.QuoteMeta(`GROUP BY "operation_id"`)).
+		WithArgs("5411", "%market%", "BR").
+		WillReturnRows(sqlmock.NewRows([]string{"operation_id", "count"}).AddRow(uint(1), int64(1)))
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	collection, err := transactionRepository.FindAll(ctx, filter.TransactionCollection{MCC: "5411", MerchantName: "market", MerchantCountry: "BR"})
+	assert.NoError(t, err)
+	if assert.Len(t, collection.Data, 1) {
+		assert.Equal(t, "SUPER MARKET", collection.Data[0].MerchantName)
+		assert.Equal(t, "5411", collection.Data[0].MCC)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_Collection_Period_Filtered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	from := time.Date(2022, time.March, 1, 3, 0, 0, 0, time.UTC)
+	to := time.Date(2022, time.April, 1, 3, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2022, time.March, 12, 1, 2, 3, 0, time.FixedZone("BRT", -3*60*60))
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode"
+		FROM "transaction" WHERE created_at >= $1 AND created_at < $2 ORDER BY id LIMIT 10
+	`)).WithArgs(from, to).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount", "created_at"}).
+			AddRow(uint(2), uint(1), uint(1), -100, createdAt),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`FROM "transaction" WHERE created_at >= $1 AND created_at < $2`)).
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"balance", "debits", "credits", "count"}).AddRow(int64(-100), int64(100), int64(0), int64(1)))
+	dbmock.ExpectQuery(regexp.QuoteMeta(`GROUP BY "operation_id"`)).
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"operation_id", "count"}).AddRow(uint(1), int64(1)))
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	collection, err := transactionRepository.FindAll(ctx, filter.TransactionCollection{From: from, To: to})
+	assert.NoError(t, err)
+	if assert.Len(t, collection.Data, 1) {
+		assert.Equal(t, time.UTC, collection.Data[0].CreatedAt.Location())
+		assert.True(t, createdAt.Equal(collection.Data[0].CreatedAt))
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_Collection_Amount_Filtered_Sorted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	where := `WHERE account_id IN ($1,$2) AND ABS(amount) >= $3 AND ABS(amount) <= $4 AND amount < 0`
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode"
+		FROM "transaction" `+where+` ORDER BY amount DESC, id DESC LIMIT 10
+	`)).WithArgs(1, 2, 1000, 5000).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount"}).
+			AddRow(uint(3), uint(2), uint(1), -1000).
+			AddRow(uint(2), uint(1), uint(1), -4000),
+	)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`FROM "transaction" `+where)).
+		WithArgs(1, 2, 1000, 5000).
+		WillReturnRows(sqlmock.NewRows([]string{"balance", "debits", "credits", "count"}).AddRow(int64(-5000), int64(5000), int64(0), int64(2)))
+	dbmock.ExpectQuery(regexp.QuoteMeta(`GROUP BY "operation_id"`)).
+		WithArgs(1, 2, 1000, 5000).
+		WillReturnRows(sqlmock.NewRows([]string{"operation_id", "count"}).AddRow(uint(1), int64(2)))
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	debit := true
+	collection, err := transactionRepository.FindAll(ctx, filter.TransactionCollection{
+		Sort:      filter.Sort{Column: "amount", Descending: true},
+		Accounts:  []uint{1, 2},
+		AmountMin: 1000,
+		AmountMax: 5000,
+		Debit:     &debit,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, collection.Data, 2)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_Collection_After(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode"
+		FROM "transaction" WHERE id > $1 ORDER BY id LIMIT 3
+	`)).WithArgs(10).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount"}).
+			AddRow(uint(11), uint(1), uint(1), -100).
+			AddRow(uint(12), uint(1), uint(1), -100).
+			AddRow(uint(13), uint(1), uint(1), -100),
+	)
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	collection, err := transactionRepository.FindAll(ctx, filter.TransactionCollection{Size: 2, After: 10})
+	assert.NoError(t, err)
+	assert.Len(t, collection.Data, 2)
+	assert.Equal(t, persistence.Pagination{Size: 2, NextCursor: 12, PrevCursor: 11}, collection.Pagination)
+	assert.Nil(t, collection.TransactionTotals)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_Collection_Before(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode"
+		FROM "transaction" WHERE id < $1 ORDER BY id DESC LIMIT 3
+	`)).WithArgs(3).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount"}).
+			AddRow(uint(2), uint(1), uint(1), -100).
+			AddRow(uint(1), uint(1), uint(1), -100),
+	)
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	collection, err := transactionRepository.FindAll(ctx, filter.TransactionCollection{Size: 2, Before: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), collection.Data[0].ID)
+	assert.Equal(t, uint(2), collection.Data[1].ID)
+	assert.Equal(t, persistence.Pagination{Size: 2, NextCursor: 2}, collection.Pagination)
 
 	if err := dbmock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -176,8 +490,8 @@ func TestTransactionRepository_Collection_Fetch_Error(t *testing.T) {
 
 	expected := xerrors.New("error fetch collection")
 	dbmock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT "id","account_id","operation_id","amount","created_at" 
-		FROM "transaction" LIMIT 10
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode" 
+		FROM "transaction" ORDER BY id LIMIT 10
 	`)).WillReturnError(expected)
 
 	transactionRepository := NewTransaction(logger, gormdb)
@@ -186,10 +500,351 @@ func TestTransactionRepository_Collection_Fetch_Error(t *testing.T) {
 	defer cancel()
 	collection, err := transactionRepository.FindAll(ctx, filter.TransactionCollection{})
 	assert.Len(t, collection.Data, 0)
-	assert.Equal(t, collection.Balance, int64(0))
+	assert.Nil(t, collection.TransactionTotals)
 	assert.EqualError(t, err, expected.Error())
 
 	if err := dbmock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestTransactionRepository_FindByIDForUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode"
+		FROM "transaction"
+		WHERE "transaction"."id" = $1
+		ORDER BY "transaction"."id"
+		LIMIT 1 FOR UPDATE
+	`)).WithArgs(uint(1)).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount"}).AddRow(uint(1), uint(1), uint(1), -1000),
+	)
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	transaction, err := transactionRepository.FindByIDForUpdate(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1000), transaction.Amount)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_FindByID_RecordNotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery("^SELECT (.+) FROM \"transaction\"(.+)$").WithArgs(uint(1)).WillReturnError(gorm.ErrRecordNotFound)
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	transaction, err := transactionRepository.FindByID(ctx, 1)
+	assert.Nil(t, transaction)
+	assert.EqualError(t, err, ErrTransactionNotFound.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_SumByParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transaction" WHERE parent_id = $1`)).
+		WithArgs(uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(300)))
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	sum, err := transactionRepository.SumByParent(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), sum)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_SumByPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transaction" WHERE parent_id IN (SELECT id FROM "transaction" WHERE installment_plan_id = $1)`)).
+		WithArgs(uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(500)))
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	sum, err := transactionRepository.SumByPlan(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), sum)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_SumDebitsSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	since := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT COALESCE(SUM(-amount - (
+			SELECT COALESCE(SUM(reversal.amount), 0) FROM "transaction" AS reversal WHERE reversal.parent_id = "transaction".id
+		)), 0) FROM "transaction"
+		WHERE (account_id = $1 AND amount < 0 AND created_at >= $2) AND card_id = $3
+	`)).
+		WithArgs(uint(1), since, uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(4500)))
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	sum, err := transactionRepository.SumDebitsSince(ctx, 1, 2, since)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4500), sum)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_SumDebitsSince_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	since := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT COALESCE(SUM(-amount - (
+			SELECT COALESCE(SUM(reversal.amount), 0) FROM "transaction" AS reversal WHERE reversal.parent_id = "transaction".id
+		)), 0) FROM "transaction"
+		WHERE account_id = $1 AND amount < 0 AND created_at >= $2
+	`)).
+		WithArgs(uint(1), since).
+		WillReturnError(xerrors.New("connection reset"))
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	_, err = transactionRepository.SumDebitsSince(ctx, 1, 0, since)
+	assert.EqualError(t, err, ErrTransactionSumDebits.Error())
+}
+
+func TestTransactionRepository_FindByAccountBetween(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	start := time.Date(2022, time.February, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT * FROM "transaction"
+		WHERE account_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at, id
+	`)).WithArgs(1, start, end).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount"}).
+			AddRow(uint(1), uint(1), uint(1), -1000).
+			AddRow(uint(2), uint(1), uint(4), 500),
+	)
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	transactions, err := transactionRepository.FindByAccountBetween(ctx, 1, start, end)
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 2)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	from := time.Date(2022, time.March, 1, 3, 0, 0, 0, time.UTC)
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","account_id","operation_id","amount","hold_id","parent_id","installment_plan_id","card_id","currency","original_amount","rate","tax","created_at","created_by","merchant_id","merchant_name","mcc","merchant_city","merchant_country","terminal_id","entry_mode",operation_description
+		FROM "transaction"
+		LEFT JOIN (SELECT id AS operation_ref, description AS operation_description FROM "operation") AS "operation" ON "operation".operation_ref = "transaction".operation_id
+		WHERE account_id = $1 AND created_at >= $2
+		ORDER BY created_at DESC, id DESC
+	`)).WithArgs(1, from).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "account_id", "operation_id", "amount", "merchant_name", "operation_description"}).
+			AddRow(uint(3), uint(1), uint(4), 5000, nil, "PAGAMENTO").
+			AddRow(uint(2), uint(1), uint(1), -1000, "SUPER MARKET", "COMPRA A VISTA"),
+	)
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	rows := make([]entity.TransactionExport, 0)
+	err = transactionRepository.Export(ctx, filter.TransactionCollection{
+		Page:     3,
+		Sort:     filter.Sort{Column: "created_at", Descending: true},
+		Accounts: []uint{1},
+		From:     from,
+	}, func(row *entity.TransactionExport) error {
+		rows = append(rows, *row)
+		return nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 2) {
+		assert.Equal(t, "PAGAMENTO", rows[0].Operation)
+		assert.Equal(t, int64(5000), rows[0].Amount)
+		assert.Equal(t, "COMPRA A VISTA", rows[1].Operation)
+		assert.Equal(t, "SUPER MARKET", rows[1].MerchantName)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTransactionRepository_Export_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`FROM "transaction"`)).WillReturnError(xerrors.New("connection reset"))
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	err = transactionRepository.Export(ctx, filter.TransactionCollection{}, func(row *entity.TransactionExport) error {
+		return nil
+	})
+	assert.Equal(t, ErrTransactionExport, err)
+}
+
+func TestTransactionRepository_Export_Stopped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`FROM "transaction"`)).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "amount"}).AddRow(uint(1), -100).AddRow(uint(2), -200),
+	)
+
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	stop := xerrors.New("client gone")
+	calls := 0
+	err = transactionRepository.Export(ctx, filter.TransactionCollection{}, func(row *entity.TransactionExport) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
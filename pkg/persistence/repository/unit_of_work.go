@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"golang.org/x/net/context"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+type (
+	UnitOfWork interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+
+	Unit struct {
+		logger  common.Logger
+		adapter *gorm.DB
+	}
+
+	unitKey struct{}
+)
+
+func NewUnitOfWork(logger common.Logger, adapter *gorm.DB) *Unit {
+	return &Unit{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+// Transaction runs fn inside a single database transaction. Repositories called with the
+// context received by fn share that transaction, nested calls reuse the outer one.
+func (u *Unit) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if _, ok := ctx.Value(unitKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return u.adapter.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, unitKey{}, tx))
+	})
+}
+
+func session(ctx context.Context, adapter *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(unitKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return adapter.WithContext(ctx)
+}
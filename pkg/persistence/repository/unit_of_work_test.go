@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestUnitOfWork_Transaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT "id","document_number","document_type","credit_limit","limit","currency","status","created_by"
+		FROM "account"
+		WHERE "account"."id" = $1 AND "account"."deleted_at" IS NULL
+		ORDER BY "account"."id"
+		LIMIT 1 FOR UPDATE
+	`)).WithArgs(uint(1)).WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "credit_limit", "limit"}).AddRow(uint(1), "64715245019", int64(2000), int64(2000)))
+	dbmock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE "account" SET "credit_limit"=$1,"limit"=$2 WHERE "account"."deleted_at" IS NULL AND "id" = $3`,
+	)).WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectCommit()
+
+	unitOfWork := NewUnitOfWork(logger, gormdb)
+	accountRepository := NewAccount(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	err = unitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		account, err := accountRepository.FindByIDForUpdate(ctx, 1)
+		if err != nil {
+			return err
+		}
+
+		account.AvailableLimit -= 1000
+		return accountRepository.UpdateLimit(ctx, account)
+	})
+	assert.NoError(t, err)
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUnitOfWork_Transaction_Rollback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE "account" SET "credit_limit"=$1,"limit"=$2 WHERE "account"."deleted_at" IS NULL AND "id" = $3`,
+	)).WillReturnResult(sqlmock.NewResult(1, 1))
+	dbmock.ExpectQuery("^INSERT INTO \"transaction\"(.+)$").WillReturnError(ErrTransactionCreate)
+	dbmock.ExpectRollback()
+
+	unitOfWork := NewUnitOfWork(logger, gormdb)
+	accountRepository := NewAccount(logger, gormdb)
+	transactionRepository := NewTransaction(logger, gormdb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	err = unitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		if err := accountRepository.UpdateLimit(ctx, &entity.Account{ID: 1, Document: "64715245019", CreditLimit: 1000, AvailableLimit: 1000}); err != nil {
+			return err
+		}
+
+		_, err := transactionRepository.Create(ctx, entity.Transaction{Account: 1, Type: 1, Amount: -1000})
+		return err
+	})
+	assert.EqualError(t, err, ErrTransactionCreate.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUnitOfWork_Transaction_Nested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+
+	mockdb, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockdb.Close()
+
+	gormdb, err := gorm.Open(postgres.New(postgres.Config{Conn: mockdb}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectRollback()
+
+	unitOfWork := NewUnitOfWork(logger, gormdb)
+
+	expected := xerrors.New("nested failed")
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	err = unitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		return unitOfWork.Transaction(ctx, func(ctx context.Context) error {
+			return expected
+		})
+	})
+	assert.EqualError(t, err, expected.Error())
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
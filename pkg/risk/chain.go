@@ -0,0 +1,107 @@
+package risk
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/common"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+const (
+	ChainName = "chain"
+
+	// ReasonUnavailable is reported, prefixed by the scorer name, when a scorer fails or
+	// times out.
+	ReasonUnavailable = "unavailable"
+)
+
+var (
+	ErrScorerTimeout = xerrors.New("risk scorer timed out")
+)
+
+type (
+	ChainOpts struct {
+		Logger  common.Logger
+		Scorers []Scorer
+		// Timeout bounds each scorer, zero means no bound.
+		Timeout time.Duration
+		// FailOpen approves when a scorer fails or times out, otherwise the purchase is declined.
+		FailOpen bool
+	}
+
+	// Chain runs its scorers in order and keeps the most severe outcome and the highest
+	// score. It stops at the first decline.
+	Chain struct {
+		ChainOpts
+	}
+
+	result struct {
+		assessment *Assessment
+		err        error
+	}
+)
+
+func NewChain(opts ChainOpts) *Chain {
+	return &Chain{opts}
+}
+
+func (c *Chain) Name() string {
+	return ChainName
+}
+
+func (c *Chain) Score(ctx context.Context, input *Input) (*Assessment, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	combined := &Assessment{Outcome: OutcomeApprove, Reasons: []string{}}
+	for _, scorer := range c.Scorers {
+		assessment, err := c.run(ctx, scorer, input)
+		if err != nil {
+			c.Logger.Errorf("risk scorer %s failed with %s\n", scorer.Name(), err)
+			assessment = &Assessment{Outcome: OutcomeDecline, Score: 100}
+			if c.FailOpen {
+				assessment = &Assessment{Outcome: OutcomeApprove}
+			}
+
+			assessment.Reasons = []string{scorer.Name() + ":" + ReasonUnavailable}
+		}
+
+		if severity(assessment.Outcome) > severity(combined.Outcome) {
+			combined.Outcome = assessment.Outcome
+		}
+
+		if assessment.Score > combined.Score {
+			combined.Score = assessment.Score
+		}
+
+		combined.Reasons = append(combined.Reasons, assessment.Reasons...)
+		if combined.Outcome == OutcomeDecline {
+			break
+		}
+	}
+
+	return combined, nil
+}
+
+// run gives up on the scorer when the timeout expires, even if the scorer ignores the context.
+func (c *Chain) run(ctx context.Context, scorer Scorer, input *Input) (*Assessment, error) {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		assessment, err := scorer.Score(ctx, input)
+		done <- result{assessment: assessment, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.assessment, r.err
+	case <-ctx.Done():
+		return nil, ErrScorerTimeout
+	}
+}
@@ -0,0 +1,107 @@
+package risk
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/common"
+	"testing"
+	"time"
+)
+
+func TestChain_Score(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := NewMockScorer(ctrl)
+	first.EXPECT().Score(gomock.Any(), gomock.Any()).Return(&Assessment{Outcome: OutcomeReview, Score: 60, Reasons: []string{"a:velocity"}}, nil)
+
+	second := NewMockScorer(ctrl)
+	second.EXPECT().Score(gomock.Any(), gomock.Any()).Return(&Assessment{Outcome: OutcomeApprove, Score: 10, Reasons: []string{"b:device"}}, nil)
+
+	chain := NewChain(ChainOpts{Scorers: []Scorer{first, second}})
+
+	assessment, err := chain.Score(context.Background(), &Input{})
+	assert.NoError(t, err)
+	assert.Equal(t, &Assessment{Outcome: OutcomeReview, Score: 60, Reasons: []string{"a:velocity", "b:device"}}, assessment)
+}
+
+func TestChain_Score_Empty(t *testing.T) {
+	assessment, err := NewChain(ChainOpts{}).Score(context.Background(), &Input{})
+	assert.NoError(t, err)
+	assert.Equal(t, OutcomeApprove, assessment.Outcome)
+}
+
+func TestChain_Score_StopsAtDecline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := NewMockScorer(ctrl)
+	first.EXPECT().Score(gomock.Any(), gomock.Any()).Return(&Assessment{Outcome: OutcomeDecline, Score: 90, Reasons: []string{"a:spike"}}, nil)
+
+	second := NewMockScorer(ctrl)
+
+	chain := NewChain(ChainOpts{Scorers: []Scorer{first, second}})
+
+	assessment, err := chain.Score(context.Background(), &Input{})
+	assert.NoError(t, err)
+	assert.Equal(t, OutcomeDecline, assessment.Outcome)
+}
+
+func TestChain_Score_Failure(t *testing.T) {
+	cases := []struct {
+		description string
+		failOpen    bool
+		expected    string
+	}{
+		{description: "fail open", failOpen: true, expected: OutcomeApprove},
+		{description: "fail closed", failOpen: false, expected: OutcomeDecline},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := common.NewMockLogger(ctrl)
+			logger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+			scorer := NewMockScorer(ctrl)
+			scorer.EXPECT().Name().Return("remote").AnyTimes()
+			scorer.EXPECT().Score(gomock.Any(), gomock.Any()).Return(nil, xerrors.New("connection refused"))
+
+			chain := NewChain(ChainOpts{Logger: logger, Scorers: []Scorer{scorer}, FailOpen: tt.failOpen})
+
+			assessment, err := chain.Score(context.Background(), &Input{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, assessment.Outcome)
+			assert.Equal(t, []string{"remote:unavailable"}, assessment.Reasons)
+		})
+	}
+}
+
+func TestChain_Score_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := common.NewMockLogger(ctrl)
+	logger.EXPECT().Errorf(gomock.Any(), "remote", ErrScorerTimeout)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	scorer := NewMockScorer(ctrl)
+	scorer.EXPECT().Name().Return("remote").AnyTimes()
+	scorer.EXPECT().Score(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *Input) (*Assessment, error) {
+		<-release
+		return &Assessment{Outcome: OutcomeApprove}, nil
+	})
+
+	chain := NewChain(ChainOpts{Logger: logger, Scorers: []Scorer{scorer}, Timeout: 10 * time.Millisecond})
+
+	assessment, err := chain.Score(context.Background(), &Input{})
+	assert.NoError(t, err)
+	assert.Equal(t, OutcomeDecline, assessment.Outcome)
+}
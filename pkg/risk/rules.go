@@ -0,0 +1,115 @@
+package risk
+
+import (
+	"golang.org/x/net/context"
+	"time"
+)
+
+const (
+	RulesName = "rules"
+
+	ReasonVelocity    = "velocity"
+	ReasonAmountSpike = "amount_spike"
+	ReasonNewCountry  = "new_country"
+)
+
+type (
+	// RulesOpts weights add up to the score of a purchase, ReviewScore and DeclineScore turn
+	// it into an outcome.
+	RulesOpts struct {
+		// VelocityCount purchases within VelocityWindow, the current one included, are suspicious.
+		VelocityWindow time.Duration
+		VelocityCount  int
+		VelocityWeight int
+
+		// SpikeFactor times the average purchase of the history is suspicious, once the history
+		// has SpikeMinHistory purchases.
+		SpikeFactor     int64
+		SpikeMinHistory int
+		SpikeWeight     int
+
+		// NewCountryWeight applies to the first purchase in a country the history never saw.
+		NewCountryWeight int
+
+		ReviewScore  int
+		DeclineScore int
+	}
+
+	Rules struct {
+		RulesOpts
+	}
+)
+
+// DefaultRulesOpts flag a velocity burst alone for review, and decline it when it comes with
+// an amount spike or a new country.
+var DefaultRulesOpts = RulesOpts{
+	VelocityWindow:   10 * time.Minute,
+	VelocityCount:    5,
+	VelocityWeight:   50,
+	SpikeFactor:      5,
+	SpikeMinHistory:  3,
+	SpikeWeight:      40,
+	NewCountryWeight: 30,
+	ReviewScore:      50,
+	DeclineScore:     80,
+}
+
+func NewRules(opts RulesOpts) *Rules {
+	return &Rules{opts}
+}
+
+func (r *Rules) Name() string {
+	return RulesName
+}
+
+func (r *Rules) Score(ctx context.Context, input *Input) (*Assessment, error) {
+	assessment := &Assessment{Outcome: OutcomeApprove, Reasons: []string{}}
+	flag := func(weight int, reason string) {
+		assessment.Score += weight
+		assessment.Reasons = append(assessment.Reasons, RulesName+":"+reason)
+	}
+
+	since := time.Now().Add(-r.VelocityWindow)
+	recent, purchases, total := 1, 0, int64(0)
+	seen := false
+	for _, transaction := range input.History {
+		if transaction.Amount >= 0 {
+			continue
+		}
+
+		purchases++
+		total += -transaction.Amount
+		if !transaction.CreatedAt.Before(since) {
+			recent++
+		}
+
+		if transaction.MerchantCountry == input.Request.MerchantCountry {
+			seen = true
+		}
+	}
+
+	if r.VelocityCount > 0 && recent >= r.VelocityCount {
+		flag(r.VelocityWeight, ReasonVelocity)
+	}
+
+	if purchases > 0 && purchases >= r.SpikeMinHistory && input.Amount > r.SpikeFactor*total/int64(purchases) {
+		flag(r.SpikeWeight, ReasonAmountSpike)
+	}
+
+	if input.Request.MerchantCountry != "" && purchases > 0 && !seen {
+		flag(r.NewCountryWeight, ReasonNewCountry)
+	}
+
+	if assessment.Score > 100 {
+		assessment.Score = 100
+	}
+
+	switch {
+	case assessment.Score >= r.DeclineScore:
+		assessment.Outcome = OutcomeDecline
+	case assessment.Score >= r.ReviewScore:
+		assessment.Outcome = OutcomeReview
+	}
+
+	return assessment, nil
+}
@@ -0,0 +1,93 @@
+package risk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"testing"
+	"time"
+)
+
+func TestRules_Score(t *testing.T) {
+	now := time.Now()
+	purchase := func(amount int64, age time.Duration, country string) *entity.Transaction {
+		return &entity.Transaction{Amount: -amount, CreatedAt: now.Add(-age), Merchant: entity.Merchant{MerchantCountry: country}}
+	}
+
+	usual := []*entity.Transaction{
+		purchase(1000, 72*time.Hour, "BR"),
+		purchase(1000, 48*time.Hour, "BR"),
+		purchase(1000, 24*time.Hour, "BR"),
+		{Amount: 5000, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	burst := append([]*entity.Transaction{}, usual...)
+	for i := 0; i < 4; i++ {
+		burst = append(burst, purchase(1000, time.Minute, "BR"))
+	}
+
+	cases := []struct {
+		description string
+		country     string
+		amount      int64
+		history     []*entity.Transaction
+		expected    *Assessment
+	}{
+		{
+			description: "usual purchase",
+			country:     "BR",
+			amount:      1200,
+			history:     usual,
+			expected:    &Assessment{Outcome: OutcomeApprove, Reasons: []string{}},
+		},
+		{
+			description: "no history",
+			country:     "US",
+			amount:      100000,
+			expected:    &Assessment{Outcome: OutcomeApprove, Reasons: []string{}},
+		},
+		{
+			description: "new country",
+			country:     "US",
+			amount:      1200,
+			history:     usual,
+			expected:    &Assessment{Outcome: OutcomeApprove, Score: 30, Reasons: []string{"rules:new_country"}},
+		},
+		{
+			description: "velocity",
+			country:     "BR",
+			amount:      1000,
+			history:     burst,
+			expected:    &Assessment{Outcome: OutcomeReview, Score: 50, Reasons: []string{"rules:velocity"}},
+		},
+		{
+			description: "amount spike in a new country",
+			country:     "US",
+			amount:      6000,
+			history:     usual,
+			expected:    &Assessment{Outcome: OutcomeReview, Score: 70, Reasons: []string{"rules:amount_spike", "rules:new_country"}},
+		},
+		{
+			description: "velocity with an amount spike",
+			country:     "BR",
+			amount:      6000,
+			history:     burst,
+			expected:    &Assessment{Outcome: OutcomeDecline, Score: 90, Reasons: []string{"rules:velocity", "rules:amount_spike"}},
+		},
+	}
+
+	rules := NewRules(DefaultRulesOpts)
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			assessment, err := rules.Score(context.Background(), &Input{
+				Request: &contract.TransactionRequest{MerchantCountry: tt.country},
+				Amount:  tt.amount,
+				History: tt.history,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, assessment)
+		})
+	}
+}
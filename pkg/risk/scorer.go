@@ -0,0 +1,50 @@
+package risk
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+)
+
+const (
+	OutcomeApprove = "approve"
+	OutcomeReview  = "review"
+	OutcomeDecline = "decline"
+)
+
+type (
+	// Scorer assesses a purchase before the limit is touched. Scorers must honour the context
+	// deadline, the Chain gives up on them when it expires.
+	Scorer interface {
+		Name() string
+		Score(ctx context.Context, input *Input) (*Assessment, error)
+	}
+
+	// Input is what a Scorer sees of the purchase. Amount is in the account currency, History
+	// holds the recent transactions of the account, oldest first.
+	Input struct {
+		Request *contract.TransactionRequest
+		Account *entity.Account
+		Amount  int64
+		History []*entity.Transaction
+	}
+
+	// Assessment scores go from 0, no risk, to 100.
+	Assessment struct {
+		Outcome string
+		Score   int
+		Reasons []string
+	}
+)
+
+// severity orders the outcomes, the most severe one wins when assessments are combined.
+func severity(outcome string) int {
+	switch outcome {
+	case OutcomeDecline:
+		return 2
+	case OutcomeReview:
+		return 1
+	default:
+		return 0
+	}
+}
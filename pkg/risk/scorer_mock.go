@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/risk/scorer.go
+
+// Package risk is a generated GoMock package.
+package risk
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockScorer is a mock of Scorer interface.
+type MockScorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockScorerMockRecorder
+}
+
+// MockScorerMockRecorder is the mock recorder for MockScorer.
+type MockScorerMockRecorder struct {
+	mock *MockScorer
+}
+
+// NewMockScorer creates a new mock instance.
+func NewMockScorer(ctrl *gomock.Controller) *MockScorer {
+	mock := &MockScorer{ctrl: ctrl}
+	mock.recorder = &MockScorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScorer) EXPECT() *MockScorerMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockScorer) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockScorerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockScorer)(nil).Name))
+}
+
+// Score mocks base method.
+func (m *MockScorer) Score(ctx context.Context, input *Input) (*Assessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Score", ctx, input)
+	ret0, _ := ret[0].(*Assessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Score indicates an expected call of Score.
+func (mr *MockScorerMockRecorder) Score(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Score", reflect.TypeOf((*MockScorer)(nil).Score), ctx, input)
+}
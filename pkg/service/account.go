@@ -1,26 +1,47 @@
 package service
 
 import (
-	"errors"
 	"golang.org/x/net/context"
+	"ms/card/pkg/auth"
 	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/domain"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/repository"
 	"ms/card/pkg/telemetry/jaeger"
+	"strconv"
+	"time"
 )
 
 var (
-	ErrLimitExceeded = errors.New("account limit exceeded, operation not allowed")
+	ErrLimitExceeded      = domain.Unprocessable("limit_exceeded", "account limit exceeded, operation not allowed")
+	ErrLimitBelowUsed     = domain.Unprocessable("limit_below_used", "the limit can't be lower than the used amount")
+	ErrAccountBlocked     = domain.Unprocessable("account_blocked", "account is blocked")
+	ErrAccountClosed      = domain.Unprocessable("account_closed", "account is closed")
+	ErrAccountTransition  = domain.Conflict("account_status_transition", "account status change not allowed")
+	ErrAccountNotClosed   = domain.Conflict("account_not_closed", "only closed accounts can be deleted")
+	ErrAccountOutstanding = domain.Conflict("account_outstanding_balance", "the account still has a used balance")
+	ErrOverpayment        = domain.Unprocessable("overpayment_not_allowed", "the payment exceeds the used amount")
 )
 
 type (
 	Accounts interface {
+		Create(ctx context.Context, request *contract.AccountRequest) (*entity.Account, error)
 		UpdateLimit(ctx context.Context, account *entity.Account, amount int64, negative bool) error
+		ReleaseLimit(ctx context.Context, account *entity.Account, amount int64) error
+		Update(ctx context.Context, id uint, request *contract.AccountUpdateRequest) (*entity.Account, error)
+		Delete(ctx context.Context, id uint) error
 	}
 
 	AccountOpts struct {
-		Logger            common.Logger
-		AccountRepository repository.Accounts
+		Logger                  common.Logger
+		UnitOfWork              repository.UnitOfWork
+		AccountRepository       repository.Accounts
+		AccountChangeRepository repository.AccountChanges
+		Ledger                  Ledgers
+		// CreditBalance lets payments raise the available limit above the credit limit, the
+		// excess is kept as a credit balance. Overpayments are refused otherwise.
+		CreditBalance bool
 	}
 
 	Account struct {
@@ -32,20 +53,262 @@ func NewAccount(opts AccountOpts) *Account {
 	return &Account{opts}
 }
 
+// Create opens the account, billed in BRL unless another currency is requested, and books
+// the granted limit in the ledger. The document is stored with digits only.
+func (a *Account) Create(ctx context.Context, request *contract.AccountRequest) (*entity.Account, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if err := request.Validate(); err != nil {
+		a.Logger.Errorf("request.Validate() failed with %s\n", err)
+		return nil, err
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = entity.AccountCurrencyDefault
+	}
+
+	document := common.NormalizeDocument(request.Document)
+	var account *entity.Account
+	err := a.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		account, err = a.AccountRepository.Create(ctx, entity.Account{
+			Document:       document,
+			DocumentType:   common.DocumentType(document),
+			CreditLimit:    request.Limit,
+			AvailableLimit: request.Limit,
+			Currency:       currency,
+			Status:         entity.AccountStatusActive,
+			CreatedBy:      auth.Client(ctx),
+		})
+
+		if err != nil {
+			a.Logger.Errorf("a.AccountRepository.Create failed with %s\n", err)
+			return err
+		}
+
+		return a.post(ctx, account.ID, "credit line granted", entity.LedgerCreditLine, entity.LedgerAvailable, account.CreditLimit)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// UpdateLimit is the only way the available limit changes with purchases and payments, every
+// change is mirrored by a journal entry moving the amount between the available and used
+// ledgers. Payments stop at the credit limit unless credit balances are allowed.
 func (a *Account) UpdateLimit(ctx context.Context, account *entity.Account, amount int64, negative bool) error {
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
 	amount = common.Abs(amount)
 	if negative {
-		if amount > account.Limit {
+		if amount > account.AvailableLimit {
 			return ErrLimitExceeded
 		}
 
-		account.Limit -= amount
-		return a.AccountRepository.UpdateLimit(ctx, account)
+		account.AvailableLimit -= amount
+		if err := a.AccountRepository.UpdateLimit(ctx, account); err != nil {
+			return err
+		}
+
+		return a.post(ctx, account.ID, "limit consumed", entity.LedgerAvailable, entity.LedgerUsed, amount)
+	}
+
+	if !a.CreditBalance && amount > account.Used() {
+		return ErrOverpayment
+	}
+
+	return a.release(ctx, account, amount)
+}
+
+// ReleaseLimit gives back limit the account consumed before, on voided and expired holds,
+// reversals and cancelled installments. It is not a payment, a bill paid in the meantime
+// leaves the released amount as a credit balance.
+func (a *Account) ReleaseLimit(ctx context.Context, account *entity.Account, amount int64) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return a.release(ctx, account, common.Abs(amount))
+}
+
+func (a *Account) release(ctx context.Context, account *entity.Account, amount int64) error {
+	account.AvailableLimit += amount
+	if err := a.AccountRepository.UpdateLimit(ctx, account); err != nil {
+		return err
+	}
+
+	return a.post(ctx, account.ID, "limit released", entity.LedgerUsed, entity.LedgerAvailable, amount)
+}
+
+// Update sets the credit limit granted to the account and moves it between statuses, every
+// change is kept in the audit trail. The credit limit can't drop below what is already used,
+// the available limit follows the difference.
+func (a *Account) Update(ctx context.Context, id uint, request *contract.AccountUpdateRequest) (*entity.Account, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if err := request.Validate(); err != nil {
+		a.Logger.Errorf("request.Validate() failed with %s\n", err)
+		return nil, err
+	}
+
+	var account *entity.Account
+	err := a.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		account, err = a.AccountRepository.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			a.Logger.Errorf("a.AccountRepository.FindByIDForUpdate failed with %s\n", err)
+			return err
+		}
+
+		if request.Limit != nil {
+			if err := a.updateLimit(ctx, account, *request.Limit, request.Reason); err != nil {
+				return err
+			}
+		}
+
+		if request.Status != "" && request.Status != account.Status {
+			if !account.CanMoveTo(request.Status) {
+				return ErrAccountTransition
+			}
+
+			from := account.Status
+			account.Status = request.Status
+			if err := a.AccountRepository.UpdateStatus(ctx, account); err != nil {
+				a.Logger.Errorf("a.AccountRepository.UpdateStatus failed with %s\n", err)
+				return err
+			}
+
+			return a.audit(ctx, account.ID, entity.AccountChangeStatus, from, account.Status, request.Reason)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (a *Account) updateLimit(ctx context.Context, account *entity.Account, limit int64, reason string) error {
+	if limit < account.Used() {
+		return ErrLimitBelowUsed
+	}
+
+	granted := account.CreditLimit
+	if limit == granted {
+		return nil
+	}
+
+	account.CreditLimit = limit
+	account.AvailableLimit += limit - granted
+	if err := a.AccountRepository.UpdateLimit(ctx, account); err != nil {
+		a.Logger.Errorf("a.AccountRepository.UpdateLimit failed with %s\n", err)
+		return err
+	}
+
+	var err error
+	if limit > granted {
+		err = a.post(ctx, account.ID, "credit line raised", entity.LedgerCreditLine, entity.LedgerAvailable, limit-granted)
+	} else {
+		err = a.post(ctx, account.ID, "credit line lowered", entity.LedgerAvailable, entity.LedgerCreditLine, granted-limit)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return a.audit(ctx, account.ID, entity.AccountChangeLimit, strconv.FormatInt(granted, 10), strconv.FormatInt(limit, 10), reason)
+}
+
+// Delete soft deletes a closed account once nothing is owed anymore.
+func (a *Account) Delete(ctx context.Context, id uint) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	return a.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		account, err := a.AccountRepository.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			a.Logger.Errorf("a.AccountRepository.FindByIDForUpdate failed with %s\n", err)
+			return err
+		}
+
+		if account.Status != entity.AccountStatusClosed {
+			return ErrAccountNotClosed
+		}
+
+		if account.Used() > 0 {
+			return ErrAccountOutstanding
+		}
+
+		if err := a.AccountRepository.Delete(ctx, account.ID); err != nil {
+			a.Logger.Errorf("a.AccountRepository.Delete failed with %s\n", err)
+			return err
+		}
+
+		return a.audit(ctx, account.ID, entity.AccountChangeDeleted, "", "", "")
+	})
+}
+
+func (a *Account) audit(ctx context.Context, account uint, field, from, to, reason string) error {
+	_, err := a.AccountChangeRepository.Create(ctx, entity.AccountChange{
+		Account:   account,
+		Field:     field,
+		From:      from,
+		To:        to,
+		Reason:    reason,
+		CreatedBy: auth.Client(ctx),
+		CreatedAt: time.Now(),
+	})
+
+	if err != nil {
+		a.Logger.Errorf("a.AccountChangeRepository.Create failed with %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// usable refuses debits on blocked and closed accounts, credits are still accepted so what
+// is owed can be paid back.
+func usable(account *entity.Account, debit bool) error {
+	if !debit {
+		return nil
+	}
+
+	switch account.Status {
+	case entity.AccountStatusBlocked:
+		return ErrAccountBlocked
+	case entity.AccountStatusClosed:
+		return ErrAccountClosed
+	default:
+		return nil
+	}
+}
+
+func (a *Account) post(ctx context.Context, account uint, description, from, to string, amount int64) error {
+	if amount == 0 {
+		return nil
+	}
+
+	entry := entity.JournalEntry{
+		Account:     account,
+		Description: description,
+		CreatedAt:   time.Now(),
+	}
+
+	entry.Transfer(from, to, amount)
+	if _, err := a.Ledger.Post(ctx, entry); err != nil {
+		a.Logger.Errorf("a.Ledger.Post failed with %s\n", err)
+		return err
 	}
 
-	account.Limit += amount
-	return a.AccountRepository.UpdateLimit(ctx, account)
+	return nil
 }
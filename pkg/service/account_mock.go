@@ -5,6 +5,7 @@
 package service
 
 import (
+	contract "ms/card/pkg/contract"
 	entity "ms/card/pkg/persistence/entity"
 	reflect "reflect"
 
@@ -35,6 +36,64 @@ func (m *MockAccounts) EXPECT() *MockAccountsMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockAccounts) Create(ctx context.Context, request *contract.AccountRequest) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAccountsMockRecorder) Create(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccounts)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockAccounts) Delete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAccountsMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAccounts)(nil).Delete), ctx, id)
+}
+
+// ReleaseLimit mocks base method.
+func (m *MockAccounts) ReleaseLimit(ctx context.Context, account *entity.Account, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLimit", ctx, account, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLimit indicates an expected call of ReleaseLimit.
+func (mr *MockAccountsMockRecorder) ReleaseLimit(ctx, account, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLimit", reflect.TypeOf((*MockAccounts)(nil).ReleaseLimit), ctx, account, amount)
+}
+
+// Update mocks base method.
+func (m *MockAccounts) Update(ctx context.Context, id uint, request *contract.AccountUpdateRequest) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, request)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAccountsMockRecorder) Update(ctx, id, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAccounts)(nil).Update), ctx, id, request)
+}
+
 // UpdateLimit mocks base method.
 func (m *MockAccounts) UpdateLimit(ctx context.Context, account *entity.Account, amount int64, negative bool) error {
 	m.ctrl.T.Helper()
@@ -4,6 +4,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/repository"
 	"testing"
@@ -19,22 +22,30 @@ func TestAccount_UpdateLimit(t *testing.T) {
 		negative bool
 		amount   int64
 		expected int64
+		from     string
+		to       string
 	}{
 		{
 			input: &entity.Account{
-				Limit: 2000,
+				CreditLimit:    2000,
+				AvailableLimit: 2000,
 			},
 			negative: true,
 			amount:   int64(100),
 			expected: int64(1900),
+			from:     entity.LedgerAvailable,
+			to:       entity.LedgerUsed,
 		},
 		{
 			input: &entity.Account{
-				Limit: 2000,
+				CreditLimit:    3000,
+				AvailableLimit: 2000,
 			},
 			negative: false,
 			amount:   int64(100),
 			expected: int64(2100),
+			from:     entity.LedgerUsed,
+			to:       entity.LedgerAvailable,
 		},
 	}
 
@@ -44,15 +55,23 @@ func TestAccount_UpdateLimit(t *testing.T) {
 			mockAccountRepository := repository.NewMockAccounts(ctrl)
 			mockAccountRepository.EXPECT().UpdateLimit(gomock.Any(), tt.input).Return(nil)
 
+			mockLedger := NewMockLedgers(ctrl)
+			mockLedger.EXPECT().Post(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entry entity.JournalEntry) (*entity.JournalEntry, error) {
+				assert.Equal(t, &entity.Posting{Ledger: tt.from, Amount: -tt.amount}, entry.Postings[0])
+				assert.Equal(t, &entity.Posting{Ledger: tt.to, Amount: tt.amount}, entry.Postings[1])
+				return &entry, nil
+			})
+
 			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 			defer cancel()
 
 			accountService := NewAccount(AccountOpts{
 				AccountRepository: mockAccountRepository,
+				Ledger:            mockLedger,
 			})
 			err := accountService.UpdateLimit(ctx, tt.input, tt.amount, tt.negative)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.expected, tt.input.Limit)
+			assert.Equal(t, tt.expected, tt.input.AvailableLimit)
 		})
 	}
 }
@@ -62,9 +81,331 @@ func TestAccount_UpdateLimit_Exceeded_Error(t *testing.T) {
 	defer cancel()
 
 	mockAccountEntity := &entity.Account{
-		Limit: 50,
+		CreditLimit:    50,
+		AvailableLimit: 50,
 	}
 	accountService := NewAccount(AccountOpts{})
 	err := accountService.UpdateLimit(ctx, mockAccountEntity, 100, true)
 	assert.EqualError(t, err, ErrLimitExceeded.Error())
 }
+
+func TestAccount_UpdateLimit_Overpayment_Error(t *testing.T) {
+	mockAccountEntity := &entity.Account{
+		CreditLimit:    2000,
+		AvailableLimit: 1900,
+	}
+	accountService := NewAccount(AccountOpts{})
+	err := accountService.UpdateLimit(context.Background(), mockAccountEntity, 150, false)
+	assert.Equal(t, ErrOverpayment, err)
+	assert.Equal(t, int64(1900), mockAccountEntity.AvailableLimit)
+}
+
+func TestAccount_UpdateLimit_CreditBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountEntity := &entity.Account{
+		CreditLimit:    2000,
+		AvailableLimit: 1900,
+	}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity).Return(nil)
+
+	mockLedger := NewMockLedgers(ctrl)
+	mockLedger.EXPECT().Post(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+
+	accountService := NewAccount(AccountOpts{
+		AccountRepository: mockAccountRepository,
+		Ledger:            mockLedger,
+		CreditBalance:     true,
+	})
+	err := accountService.UpdateLimit(context.Background(), mockAccountEntity, 150, false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2050), mockAccountEntity.AvailableLimit)
+	assert.Equal(t, int64(-50), mockAccountEntity.Used())
+}
+
+func TestAccount_ReleaseLimit_Paid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the bill was paid in full, nothing is used anymore
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity).Return(nil)
+
+	mockLedger := NewMockLedgers(ctrl)
+	mockLedger.EXPECT().Post(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+
+	accountService := NewAccount(AccountOpts{
+		AccountRepository: mockAccountRepository,
+		Ledger:            mockLedger,
+	})
+	err := accountService.ReleaseLimit(context.Background(), mockAccountEntity, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2300), mockAccountEntity.AvailableLimit)
+}
+
+func TestAccount_UpdateLimit_Ledger_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity).Return(nil)
+
+	mockLedger := NewMockLedgers(ctrl)
+	mockLedger.EXPECT().Post(gomock.Any(), gomock.Any()).Return(nil, repository.ErrJournalEntryCreate)
+
+	accountService := NewAccount(AccountOpts{
+		Logger:            mockLogger,
+		AccountRepository: mockAccountRepository,
+		Ledger:            mockLedger,
+	})
+	err := accountService.UpdateLimit(context.Background(), mockAccountEntity, 100, true)
+	assert.EqualError(t, err, repository.ErrJournalEntryCreate.Error())
+}
+
+func TestAccount_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().Create(gomock.Any(), entity.Account{Document: "56077053074", DocumentType: "cpf", CreditLimit: 5000, AvailableLimit: 5000, Currency: "BRL", Status: "active"}).Return(&entity.Account{
+		ID:             1,
+		Document:       "56077053074",
+		CreditLimit:    5000,
+		AvailableLimit: 5000,
+		Currency:       "BRL",
+	}, nil)
+
+	mockLedger := NewMockLedgers(ctrl)
+	mockLedger.EXPECT().Post(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entry entity.JournalEntry) (*entity.JournalEntry, error) {
+		assert.Equal(t, uint(1), entry.Account)
+		assert.Equal(t, &entity.Posting{Account: 1, Ledger: entity.LedgerCreditLine, Amount: -5000}, entry.Postings[0])
+		assert.Equal(t, &entity.Posting{Account: 1, Ledger: entity.LedgerAvailable, Amount: 5000}, entry.Postings[1])
+		return &entry, nil
+	})
+
+	accountService := NewAccount(AccountOpts{
+		UnitOfWork:        mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository: mockAccountRepository,
+		Ledger:            mockLedger,
+	})
+
+	account, err := accountService.Create(context.Background(), &contract.AccountRequest{Document: "560.770.530-74", Limit: 5000})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), account.ID)
+}
+
+func TestAccount_Create_CreatedBy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().Create(gomock.Any(), entity.Account{Document: "56077053074", DocumentType: "cpf", CreditLimit: 5000, AvailableLimit: 5000, Currency: "BRL", Status: "active", CreatedBy: "backoffice"}).Return(&entity.Account{
+		ID:             1,
+		Document:       "56077053074",
+		CreditLimit:    5000,
+		AvailableLimit: 5000,
+		Currency:       "BRL",
+		CreatedBy:      "backoffice",
+	}, nil)
+
+	mockLedger := NewMockLedgers(ctrl)
+	mockLedger.EXPECT().Post(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entry entity.JournalEntry) (*entity.JournalEntry, error) {
+		return &entry, nil
+	})
+
+	accountService := NewAccount(AccountOpts{
+		UnitOfWork:        mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository: mockAccountRepository,
+		Ledger:            mockLedger,
+	})
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Client: "backoffice"})
+	account, err := accountService.Create(ctx, &contract.AccountRequest{Document: "56077053074", Limit: 5000})
+	assert.NoError(t, err)
+	assert.Equal(t, "backoffice", account.CreatedBy)
+}
+
+func TestAccount_Create_Persist_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrAccountCreateAlreadyExists)
+
+	accountService := NewAccount(AccountOpts{
+		Logger:            mockLogger,
+		UnitOfWork:        mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository: mockAccountRepository,
+	})
+
+	account, err := accountService.Create(context.Background(), &contract.AccountRequest{Document: "56077053074", Limit: 5000})
+	assert.Nil(t, account)
+	assert.EqualError(t, err, repository.ErrAccountCreateAlreadyExists.Error())
+}
+
+func TestAccount_Update_Limit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account := &entity.Account{ID: 1, CreditLimit: 5000, AvailableLimit: 3000, Status: entity.AccountStatusActive}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(account, nil)
+	mockAccountRepository.EXPECT().UpdateLimit(gomock.Any(), account).Return(nil)
+
+	mockLedger := NewMockLedgers(ctrl)
+	mockLedger.EXPECT().Post(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entry entity.JournalEntry) (*entity.JournalEntry, error) {
+		assert.Equal(t, &entity.Posting{Account: 1, Ledger: entity.LedgerCreditLine, Amount: -3000}, entry.Postings[0])
+		assert.Equal(t, &entity.Posting{Account: 1, Ledger: entity.LedgerAvailable, Amount: 3000}, entry.Postings[1])
+		return &entry, nil
+	})
+
+	mockAccountChangeRepository := repository.NewMockAccountChanges(ctrl)
+	mockAccountChangeRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, change entity.AccountChange) (*entity.AccountChange, error) {
+		assert.Equal(t, entity.AccountChangeLimit, change.Field)
+		assert.Equal(t, "5000", change.From)
+		assert.Equal(t, "8000", change.To)
+		assert.Equal(t, "income review", change.Reason)
+		return &change, nil
+	})
+
+	accountService := NewAccount(AccountOpts{
+		UnitOfWork:              mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:       mockAccountRepository,
+		AccountChangeRepository: mockAccountChangeRepository,
+		Ledger:                  mockLedger,
+	})
+
+	limit := int64(8000)
+	updated, err := accountService.Update(context.Background(), 1, &contract.AccountUpdateRequest{Limit: &limit, Reason: "income review"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8000), updated.CreditLimit)
+	assert.Equal(t, int64(6000), updated.AvailableLimit)
+	assert.Equal(t, int64(2000), updated.Used())
+}
+
+func TestAccount_Update_Limit_BelowUsed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 5000, AvailableLimit: 3000}, nil)
+
+	accountService := NewAccount(AccountOpts{
+		UnitOfWork:        mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository: mockAccountRepository,
+	})
+
+	limit := int64(1500)
+	account, err := accountService.Update(context.Background(), 1, &contract.AccountUpdateRequest{Limit: &limit})
+	assert.Nil(t, account)
+	assert.Equal(t, ErrLimitBelowUsed, err)
+}
+
+func TestAccount_Update_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account := &entity.Account{ID: 1, Status: entity.AccountStatusActive}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(account, nil)
+	mockAccountRepository.EXPECT().UpdateStatus(gomock.Any(), account).Return(nil)
+
+	mockAccountChangeRepository := repository.NewMockAccountChanges(ctrl)
+	mockAccountChangeRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, change entity.AccountChange) (*entity.AccountChange, error) {
+		assert.Equal(t, entity.AccountChangeStatus, change.Field)
+		assert.Equal(t, entity.AccountStatusActive, change.From)
+		assert.Equal(t, entity.AccountStatusBlocked, change.To)
+		assert.Equal(t, "backoffice", change.CreatedBy)
+		return &change, nil
+	})
+
+	accountService := NewAccount(AccountOpts{
+		UnitOfWork:              mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:       mockAccountRepository,
+		AccountChangeRepository: mockAccountChangeRepository,
+	})
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Client: "backoffice"})
+	updated, err := accountService.Update(ctx, 1, &contract.AccountUpdateRequest{Status: entity.AccountStatusBlocked})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AccountStatusBlocked, updated.Status)
+}
+
+func TestAccount_Update_Status_Transition_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, Status: entity.AccountStatusClosed}, nil)
+
+	accountService := NewAccount(AccountOpts{
+		UnitOfWork:        mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository: mockAccountRepository,
+	})
+
+	account, err := accountService.Update(context.Background(), 1, &contract.AccountUpdateRequest{Status: entity.AccountStatusActive})
+	assert.Nil(t, account)
+	assert.Equal(t, ErrAccountTransition, err)
+}
+
+func TestAccount_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, Status: entity.AccountStatusClosed}, nil)
+	mockAccountRepository.EXPECT().Delete(gomock.Any(), uint(1)).Return(nil)
+
+	mockAccountChangeRepository := repository.NewMockAccountChanges(ctrl)
+	mockAccountChangeRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, change entity.AccountChange) (*entity.AccountChange, error) {
+		assert.Equal(t, entity.AccountChangeDeleted, change.Field)
+		return &change, nil
+	})
+
+	accountService := NewAccount(AccountOpts{
+		UnitOfWork:              mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:       mockAccountRepository,
+		AccountChangeRepository: mockAccountChangeRepository,
+	})
+
+	assert.NoError(t, accountService.Delete(context.Background(), 1))
+}
+
+func TestAccount_Delete_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cases := []struct {
+		status   string
+		used     int64
+		expected error
+	}{
+		{status: entity.AccountStatusActive, expected: ErrAccountNotClosed},
+		{status: entity.AccountStatusClosed, used: 100, expected: ErrAccountOutstanding},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.expected.Error(), func(t *testing.T) {
+			mockAccountRepository := repository.NewMockAccounts(ctrl)
+			mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, Status: tt.status, CreditLimit: 1000, AvailableLimit: 1000 - tt.used}, nil)
+
+			accountService := NewAccount(AccountOpts{
+				UnitOfWork:        mockUnitOfWorkPassthrough(ctrl),
+				AccountRepository: mockAccountRepository,
+			})
+
+			assert.Equal(t, tt.expected, accountService.Delete(context.Background(), 1))
+		})
+	}
+}
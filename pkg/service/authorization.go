@@ -0,0 +1,74 @@
+package service
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/context"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+const (
+	// RecordTimeout bounds the recording of an attempt, which no longer runs under the
+	// deadline of its request.
+	RecordTimeout = 2 * time.Second
+)
+
+type (
+	Authorizations interface {
+		Record(ctx context.Context, authorization entity.Authorization, cause error) error
+	}
+
+	AuthorizationOpts struct {
+		Logger                  common.Logger
+		AuthorizationRepository repository.Authorizations
+	}
+
+	Authorization struct {
+		AuthorizationOpts
+	}
+)
+
+func NewAuthorization(opts AuthorizationOpts) *Authorization {
+	return &Authorization{opts}
+}
+
+// Record stores the attempt, declined when cause is set. It must be called once the unit of
+// work of the attempt is over, a declined one rolls back everything written inside it. The
+// attempt is stored even when the request ran out of time, that is one of its causes.
+func (a *Authorization) Record(ctx context.Context, authorization entity.Authorization, cause error) error {
+	ctx, cancel := detach(ctx)
+	defer cancel()
+
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	authorization.Outcome = entity.AuthorizationOutcomeApproved
+	if cause != nil {
+		authorization.Outcome = entity.AuthorizationOutcomeDeclined
+		authorization.Code = DeclineCode(cause)
+		authorization.Reason = cause.Error()
+	}
+
+	authorization.CreatedAt = time.Now()
+	if _, err := a.AuthorizationRepository.Create(ctx, authorization); err != nil {
+		a.Logger.Errorf("a.AuthorizationRepository.Create failed with %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// detach carries the span and the principal of ctx over to a context free of its deadline
+// and cancellation, bounded by RecordTimeout instead.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+	if principal, ok := auth.FromContext(ctx); ok {
+		detached = auth.WithPrincipal(detached, principal)
+	}
+
+	return context.WithTimeout(detached, RecordTimeout)
+}
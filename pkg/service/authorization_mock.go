@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/service/authorization.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockAuthorizations is a mock of Authorizations interface.
+type MockAuthorizations struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationsMockRecorder
+}
+
+// MockAuthorizationsMockRecorder is the mock recorder for MockAuthorizations.
+type MockAuthorizationsMockRecorder struct {
+	mock *MockAuthorizations
+}
+
+// NewMockAuthorizations creates a new mock instance.
+func NewMockAuthorizations(ctrl *gomock.Controller) *MockAuthorizations {
+	mock := &MockAuthorizations{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizations) EXPECT() *MockAuthorizationsMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuthorizations) Record(ctx context.Context, authorization entity.Authorization, cause error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, authorization, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuthorizationsMockRecorder) Record(ctx, authorization, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuthorizations)(nil).Record), ctx, authorization, cause)
+}
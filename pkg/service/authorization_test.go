@@ -0,0 +1,137 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"testing"
+)
+
+func mockAuthorizationRecorded(ctrl *gomock.Controller) *MockAuthorizations {
+	mockAuthorizationService := NewMockAuthorizations(ctrl)
+	mockAuthorizationService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	return mockAuthorizationService
+}
+
+// mockAuthorizationDeclined expects the attempt to be recorded with an error mapped to code.
+func mockAuthorizationDeclined(t *testing.T, ctrl *gomock.Controller, code string) *MockAuthorizations {
+	mockAuthorizationService := NewMockAuthorizations(ctrl)
+	mockAuthorizationService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, authorization entity.Authorization, cause error) error {
+		assert.Nil(t, authorization.Transaction)
+		assert.Equal(t, code, DeclineCode(cause))
+		return nil
+	})
+
+	return mockAuthorizationService
+}
+
+func TestServiceAuthorization_Record_Approved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	id := uint(7)
+	mockAuthorizationRepository := repository.NewMockAuthorizations(ctrl)
+	mockAuthorizationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Authorization) (*entity.Authorization, error) {
+		assert.Equal(t, entity.AuthorizationOutcomeApproved, structure.Outcome)
+		assert.Empty(t, structure.Code)
+		assert.Equal(t, &id, structure.Transaction)
+		assert.False(t, structure.CreatedAt.IsZero())
+		return &structure, nil
+	})
+
+	service := NewAuthorization(AuthorizationOpts{
+		AuthorizationRepository: mockAuthorizationRepository,
+	})
+
+	assert.NoError(t, service.Record(context.Background(), entity.Authorization{Account: 1, Type: 1, Amount: 1000, Transaction: &id}, nil))
+}
+
+func TestServiceAuthorization_Record_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorizationRepository := repository.NewMockAuthorizations(ctrl)
+	mockAuthorizationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Authorization) (*entity.Authorization, error) {
+		assert.NoError(t, ctx.Err())
+		assert.Equal(t, "checkout", auth.Client(ctx))
+		assert.Equal(t, DeclineCodeError, structure.Code)
+		return &structure, nil
+	})
+
+	service := NewAuthorization(AuthorizationOpts{
+		AuthorizationRepository: mockAuthorizationRepository,
+	})
+
+	// the request ran out of time, its attempt is still recorded
+	ctx, cancel := context.WithTimeout(auth.WithPrincipal(context.Background(), &auth.Principal{Client: "checkout"}), 0)
+	defer cancel()
+	<-ctx.Done()
+
+	assert.NoError(t, service.Record(ctx, entity.Authorization{Account: 1, Type: 1, Amount: 1000}, ctx.Err()))
+}
+
+func TestServiceAuthorization_Record_Declined(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorizationRepository := repository.NewMockAuthorizations(ctrl)
+	mockAuthorizationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Authorization) (*entity.Authorization, error) {
+		assert.Equal(t, entity.AuthorizationOutcomeDeclined, structure.Outcome)
+		assert.Equal(t, DeclineCodeLimit, structure.Code)
+		assert.Equal(t, ErrLimitExceeded.Error(), structure.Reason)
+		return &structure, nil
+	})
+
+	service := NewAuthorization(AuthorizationOpts{
+		AuthorizationRepository: mockAuthorizationRepository,
+	})
+
+	assert.NoError(t, service.Record(context.Background(), entity.Authorization{Account: 1, Type: 1, Amount: 1000}, ErrLimitExceeded))
+}
+
+func TestServiceAuthorization_Record_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf("a.AuthorizationRepository.Create failed with %s\n", repository.ErrAuthorizationCreate)
+
+	mockAuthorizationRepository := repository.NewMockAuthorizations(ctrl)
+	mockAuthorizationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrAuthorizationCreate)
+
+	service := NewAuthorization(AuthorizationOpts{
+		Logger:                  mockLogger,
+		AuthorizationRepository: mockAuthorizationRepository,
+	})
+
+	assert.ErrorIs(t, service.Record(context.Background(), entity.Authorization{Account: 1}, nil), repository.ErrAuthorizationCreate)
+}
+
+func TestDeclineCode(t *testing.T) {
+	cases := map[error]string{
+		ErrDeclineDailyLimit:                         DeclineCodeDailyLimit,
+		xerrors.Errorf("wrapped: %w", ErrDeclineMCC): DeclineCodeMCC,
+		ErrLimitExceeded:                             DeclineCodeLimit,
+		repository.ErrAccountCreateNotFound:          DeclineCodeAccountNotFound,
+		repository.ErrOperationCreateNotFound:        DeclineCodeOperationNotFound,
+		ErrCardAccountMismatch:                       DeclineCodeCardNotFound,
+		ErrCardExpired:                               DeclineCodeCardExpired,
+		ErrHoldExpired:                               "hold_expired",
+		ErrOverpayment:                               "overpayment_not_allowed",
+		repository.ErrTransactionCreate:              DeclineCodeError,
+	}
+
+	for err, code := range cases {
+		assert.Equal(t, code, DeclineCode(err), err.Error())
+	}
+
+	// validation errors are a map, they can't be a key of the cases
+	assert.Equal(t, DeclineCodeInvalid, DeclineCode(contract.TransactionRequest{}.Validate()))
+}
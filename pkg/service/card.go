@@ -0,0 +1,162 @@
+package service
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+const (
+	// CardValidityPhysical and CardValidityVirtual are in years, the card expires at the end
+	// of the month.
+	CardValidityPhysical = 5
+	CardValidityVirtual  = 1
+)
+
+var (
+	ErrCardAccountMismatch = domain.Unprocessable("card_account_mismatch", "card does not belong to the account")
+	ErrCardTransition      = domain.Conflict("card_status_transition", "card status change not allowed")
+	ErrCardNotActive       = domain.Unprocessable("card_not_active", "card is not active")
+	ErrCardExpired         = domain.Unprocessable("card_expired", "card expired")
+)
+
+type (
+	Cards interface {
+		Issue(ctx context.Context, account uint, request *contract.CardRequest) (*entity.Card, error)
+		UpdateStatus(ctx context.Context, account uint, id uint, status string) (*entity.Card, error)
+		Usable(ctx context.Context, account uint, id uint) (*entity.Card, error)
+	}
+
+	CardOpts struct {
+		Logger            common.Logger
+		UnitOfWork        repository.UnitOfWork
+		AccountRepository repository.Accounts
+		CardRepository    repository.Cards
+		BIN               string
+		TokenKey          []byte
+	}
+
+	Card struct {
+		CardOpts
+	}
+)
+
+func NewCard(opts CardOpts) *Card {
+	return &Card{opts}
+}
+
+// Issue creates an active card for the account. The PAN is only returned here, the card
+// keeps its token and last four digits.
+func (c *Card) Issue(ctx context.Context, account uint, request *contract.CardRequest) (*entity.Card, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if err := request.Validate(); err != nil {
+		c.Logger.Errorf("request.Validate() failed with %s\n", err)
+		return nil, err
+	}
+
+	if _, err := c.AccountRepository.FindByID(ctx, account); err != nil {
+		c.Logger.Errorf("c.AccountRepository.FindByID failed with %s\n", err)
+		return nil, err
+	}
+
+	pan, err := common.GeneratePAN(c.BIN)
+	if err != nil {
+		c.Logger.Errorf("common.GeneratePAN failed with %s\n", err)
+		return nil, err
+	}
+
+	validity := CardValidityPhysical
+	if request.Type == entity.CardTypeVirtual {
+		validity = CardValidityVirtual
+	}
+
+	now := time.Now()
+	card, err := c.CardRepository.Create(ctx, entity.Card{
+		Account:   account,
+		Token:     common.TokenizePAN(c.TokenKey, pan),
+		LastFour:  pan[len(pan)-4:],
+		PAN:       pan,
+		Type:      request.Type,
+		Status:    entity.CardStatusActive,
+		ExpiresAt: time.Date(now.Year()+validity, now.Month()+1, 1, 0, 0, 0, 0, now.Location()),
+		CreatedBy: auth.Client(ctx),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+
+	if err != nil {
+		c.Logger.Errorf("c.CardRepository.Create failed with %s\n", err)
+		return nil, err
+	}
+
+	return card, nil
+}
+
+// UpdateStatus blocks, unblocks, cancels or reports the card as lost, cancelled and lost
+// cards can't change anymore.
+func (c *Card) UpdateStatus(ctx context.Context, account uint, id uint, status string) (*entity.Card, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var card *entity.Card
+	err := c.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		card, err = c.CardRepository.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			c.Logger.Errorf("c.CardRepository.FindByIDForUpdate failed with %s\n", err)
+			return err
+		}
+
+		if card.Account != account {
+			return ErrCardAccountMismatch
+		}
+
+		if !card.CanMoveTo(status) {
+			return ErrCardTransition
+		}
+
+		card.Status = status
+		card.UpdatedAt = time.Now()
+		return c.CardRepository.Update(ctx, card)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+// Usable returns the card when it belongs to the account, is active and not expired.
+func (c *Card) Usable(ctx context.Context, account uint, id uint) (*entity.Card, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	card, err := c.CardRepository.FindByID(ctx, id)
+	if err != nil {
+		c.Logger.Errorf("c.CardRepository.FindByID failed with %s\n", err)
+		return nil, err
+	}
+
+	if card.Account != account {
+		return nil, ErrCardAccountMismatch
+	}
+
+	if card.Status != entity.CardStatusActive {
+		return nil, ErrCardNotActive
+	}
+
+	if card.Expired(time.Now()) {
+		return nil, ErrCardExpired
+	}
+
+	return card, nil
+}
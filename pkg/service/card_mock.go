@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/service/card.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	contract "ms/card/pkg/contract"
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockCards is a mock of Cards interface.
+type MockCards struct {
+	ctrl     *gomock.Controller
+	recorder *MockCardsMockRecorder
+}
+
+// MockCardsMockRecorder is the mock recorder for MockCards.
+type MockCardsMockRecorder struct {
+	mock *MockCards
+}
+
+// NewMockCards creates a new mock instance.
+func NewMockCards(ctrl *gomock.Controller) *MockCards {
+	mock := &MockCards{ctrl: ctrl}
+	mock.recorder = &MockCardsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCards) EXPECT() *MockCardsMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockCards) Issue(ctx context.Context, account uint, request *contract.CardRequest) (*entity.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, account, request)
+	ret0, _ := ret[0].(*entity.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockCardsMockRecorder) Issue(ctx, account, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockCards)(nil).Issue), ctx, account, request)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockCards) UpdateStatus(ctx context.Context, account, id uint, status string) (*entity.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, account, id, status)
+	ret0, _ := ret[0].(*entity.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockCardsMockRecorder) UpdateStatus(ctx, account, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCards)(nil).UpdateStatus), ctx, account, id, status)
+}
+
+// Usable mocks base method.
+func (m *MockCards) Usable(ctx context.Context, account, id uint) (*entity.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usable", ctx, account, id)
+	ret0, _ := ret[0].(*entity.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usable indicates an expected call of Usable.
+func (mr *MockCardsMockRecorder) Usable(ctx, account, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usable", reflect.TypeOf((*MockCards)(nil).Usable), ctx, account, id)
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceCard_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1}, nil)
+
+	mockCardRepository := repository.NewMockCards(ctrl)
+	mockCardRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Card) (*entity.Card, error) {
+		assert.Equal(t, uint(1), structure.Account)
+		assert.True(t, strings.HasPrefix(structure.PAN, "550209"))
+		assert.Equal(t, structure.PAN[12:], structure.LastFour)
+		assert.Equal(t, common.TokenizePAN([]byte("key"), structure.PAN), structure.Token)
+		assert.Equal(t, entity.CardStatusActive, structure.Status)
+		assert.Equal(t, 1, structure.ExpiresAt.Day())
+		assert.Equal(t, structure.CreatedAt.Year()+CardValidityVirtual, structure.ExpiresAt.AddDate(0, -1, 0).Year())
+		structure.ID = 1
+		return &structure, nil
+	})
+
+	cardService := NewCard(CardOpts{
+		AccountRepository: mockAccountRepository,
+		CardRepository:    mockCardRepository,
+		BIN:               "550209",
+		TokenKey:          []byte("key"),
+	})
+
+	card, err := cardService.Issue(context.Background(), 1, &contract.CardRequest{Type: entity.CardTypeVirtual})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), card.ID)
+	assert.Len(t, card.PAN, common.PANLength)
+}
+
+func TestServiceCard_Issue_AccountNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf("c.AccountRepository.FindByID failed with %s\n", repository.ErrAccountCreateNotFound)
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(nil, repository.ErrAccountCreateNotFound)
+
+	cardService := NewCard(CardOpts{
+		Logger:            mockLogger,
+		AccountRepository: mockAccountRepository,
+	})
+
+	card, err := cardService.Issue(context.Background(), 1, &contract.CardRequest{Type: entity.CardTypePhysical})
+	assert.Nil(t, card)
+	assert.ErrorIs(t, err, repository.ErrAccountCreateNotFound)
+}
+
+func TestServiceCard_UpdateStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCardRepository := repository.NewMockCards(ctrl)
+	mockCardRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(2)).Return(&entity.Card{ID: 2, Account: 1, Status: entity.CardStatusActive}, nil)
+	mockCardRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	cardService := NewCard(CardOpts{
+		UnitOfWork:     mockUnitOfWorkPassthrough(ctrl),
+		CardRepository: mockCardRepository,
+	})
+
+	card, err := cardService.UpdateStatus(context.Background(), 1, 2, entity.CardStatusBlocked)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CardStatusBlocked, card.Status)
+}
+
+func TestServiceCard_UpdateStatus_Error(t *testing.T) {
+	cases := []struct {
+		description string
+		card        *entity.Card
+		status      string
+		expected    error
+	}{
+		{
+			description: "other account",
+			card:        &entity.Card{ID: 2, Account: 9, Status: entity.CardStatusActive},
+			status:      entity.CardStatusBlocked,
+			expected:    ErrCardAccountMismatch,
+		},
+		{
+			description: "cancelled card",
+			card:        &entity.Card{ID: 2, Account: 1, Status: entity.CardStatusCancelled},
+			status:      entity.CardStatusActive,
+			expected:    ErrCardTransition,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCardRepository := repository.NewMockCards(ctrl)
+			mockCardRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(2)).Return(tt.card, nil)
+
+			cardService := NewCard(CardOpts{
+				UnitOfWork:     mockUnitOfWorkPassthrough(ctrl),
+				CardRepository: mockCardRepository,
+			})
+
+			card, err := cardService.UpdateStatus(context.Background(), 1, 2, tt.status)
+			assert.Nil(t, card)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestServiceCard_Usable(t *testing.T) {
+	future := time.Now().AddDate(1, 0, 0)
+	cases := []struct {
+		description string
+		card        *entity.Card
+		expected    error
+	}{
+		{
+			description: "active",
+			card:        &entity.Card{ID: 2, Account: 1, Status: entity.CardStatusActive, ExpiresAt: future},
+		},
+		{
+			description: "other account",
+			card:        &entity.Card{ID: 2, Account: 9, Status: entity.CardStatusActive, ExpiresAt: future},
+			expected:    ErrCardAccountMismatch,
+		},
+		{
+			description: "blocked",
+			card:        &entity.Card{ID: 2, Account: 1, Status: entity.CardStatusBlocked, ExpiresAt: future},
+			expected:    ErrCardNotActive,
+		},
+		{
+			description: "expired",
+			card:        &entity.Card{ID: 2, Account: 1, Status: entity.CardStatusActive, ExpiresAt: time.Now().AddDate(0, -1, 0)},
+			expected:    ErrCardExpired,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCardRepository := repository.NewMockCards(ctrl)
+			mockCardRepository.EXPECT().FindByID(gomock.Any(), uint(2)).Return(tt.card, nil)
+
+			cardService := NewCard(CardOpts{CardRepository: mockCardRepository})
+
+			card, err := cardService.Usable(context.Background(), 1, 2)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.card, card)
+				return
+			}
+
+			assert.Nil(t, card)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
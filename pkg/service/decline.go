@@ -0,0 +1,72 @@
+package service
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/domain"
+)
+
+const (
+	DeclineCodeMaxAmount     = "max_amount_exceeded"
+	DeclineCodeDailyLimit    = "daily_limit_exceeded"
+	DeclineCodeMonthlyLimit  = "monthly_limit_exceeded"
+	DeclineCodeMCC           = "mcc_blocked"
+	DeclineCodeEcommerce     = "ecommerce_blocked"
+	DeclineCodeInternational = "international_blocked"
+	DeclineCodeRisk          = "risk_declined"
+
+	// codes of the failures that are not a Decline, only kept with the authorization attempt.
+	// Any other typed error is kept with its own code.
+	DeclineCodeInvalid           = "invalid_request"
+	DeclineCodeLimit             = "limit_exceeded"
+	DeclineCodeAccountNotFound   = "account_not_found"
+	DeclineCodeAccountBlocked    = "account_blocked"
+	DeclineCodeAccountClosed     = "account_closed"
+	DeclineCodeOperationNotFound = "operation_not_found"
+	DeclineCodeCardNotFound      = "card_not_found"
+	DeclineCodeCardNotActive     = "card_not_active"
+	DeclineCodeCardExpired       = "card_expired"
+	DeclineCodeError             = "processing_error"
+)
+
+var (
+	ErrDeclineMaxAmount     = &Decline{Code: DeclineCodeMaxAmount, Reason: "amount exceeds the per transaction maximum"}
+	ErrDeclineDailyLimit    = &Decline{Code: DeclineCodeDailyLimit, Reason: "daily spending limit exceeded"}
+	ErrDeclineMonthlyLimit  = &Decline{Code: DeclineCodeMonthlyLimit, Reason: "monthly spending limit exceeded"}
+	ErrDeclineMCC           = &Decline{Code: DeclineCodeMCC, Reason: "merchant category blocked"}
+	ErrDeclineEcommerce     = &Decline{Code: DeclineCodeEcommerce, Reason: "e-commerce purchases blocked"}
+	ErrDeclineInternational = &Decline{Code: DeclineCodeInternational, Reason: "international purchases blocked"}
+	ErrDeclineRisk          = &Decline{Code: DeclineCodeRisk, Reason: "declined by the risk assessment"}
+)
+
+// Decline is a purchase refused by a rule rather than by a failure, Code is stable so
+// clients can branch on it.
+type Decline struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+func (d *Decline) Error() string {
+	return d.Reason
+}
+
+// DeclineCode maps the error that ended an authorization attempt to its reason code. Typed
+// errors keep their own code, except a card of another account that reads as not found and
+// internal failures that, as any untyped error, are processing errors.
+func DeclineCode(err error) string {
+	var decline *Decline
+	var invalid validation.Errors
+	var typed *domain.Error
+	switch {
+	case xerrors.As(err, &decline):
+		return decline.Code
+	case xerrors.As(err, &invalid):
+		return DeclineCodeInvalid
+	case xerrors.Is(err, ErrCardAccountMismatch):
+		return DeclineCodeCardNotFound
+	case xerrors.As(err, &typed) && typed.Kind != domain.KindInternal:
+		return typed.Code
+	default:
+		return DeclineCodeError
+	}
+}
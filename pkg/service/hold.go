@@ -0,0 +1,336 @@
+package service
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/risk"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+const (
+	HoldExpireBatchSize = 100
+)
+
+var (
+	ErrHoldCreditOperation = domain.Unprocessable("hold_credit_operation", "holds are only allowed for debit operations")
+	ErrHoldNotOpen         = domain.Unprocessable("hold_not_open", "hold is no longer open")
+	ErrHoldExpired         = domain.Unprocessable("hold_expired", "hold expired")
+	ErrHoldCaptureExceeded = domain.Unprocessable("hold_capture_exceeded", "capture amount exceeds the remaining hold amount")
+)
+
+type (
+	Holds interface {
+		Authorize(ctx context.Context, request *contract.HoldRequest) (*entity.Hold, error)
+		Capture(ctx context.Context, id uint, request *contract.CaptureRequest) (*entity.Hold, error)
+		Void(ctx context.Context, id uint) (*entity.Hold, error)
+		Expire(ctx context.Context) (int, error)
+	}
+
+	HoldOpts struct {
+		Logger                 common.Logger
+		UnitOfWork             repository.UnitOfWork
+		AccountService         Accounts
+		AccountRepository      repository.Accounts
+		Operation              repository.Operations
+		HoldRepository         repository.Holds
+		TransactionRepository  repository.Transactions
+		AuthorizationService   Authorizations
+		CardService            Cards
+		SpendingControlService SpendingControls
+		RiskService            Risks
+		TTL                    time.Duration
+	}
+
+	Hold struct {
+		HoldOpts
+	}
+)
+
+func NewHold(opts HoldOpts) *Hold {
+	return &Hold{opts}
+}
+
+// Authorize reserves the amount against the account limit without posting a transaction. The
+// hold is checked as the purchase it reserves: the card, the spending controls and the risk
+// assessment decline it as they would decline the transaction.
+func (h *Hold) Authorize(ctx context.Context, request *contract.HoldRequest) (*entity.Hold, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	authorization := entity.Authorization{
+		Account:   request.Account,
+		Type:      request.Operation,
+		Amount:    request.Amount,
+		CreatedBy: auth.Client(ctx),
+	}
+
+	if request.Card != 0 {
+		authorization.Card = &request.Card
+	}
+
+	if err := request.Validate(); err != nil {
+		h.Logger.Errorf("request.Validate() failed with %s\n", err)
+		if err := h.AuthorizationService.Record(ctx, authorization, err); err != nil {
+			h.Logger.Errorf("h.AuthorizationService.Record failed with %s\n", err)
+		}
+
+		return nil, err
+	}
+
+	purchase := request.Purchase()
+
+	var hold *entity.Hold
+	var decision *entity.RiskDecision
+	err := h.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		account, err := h.AccountRepository.FindByIDForUpdate(ctx, request.Account)
+		if err != nil {
+			h.Logger.Errorf("h.AccountRepository.FindByIDForUpdate failed with %s\n", err)
+			return err
+		}
+
+		if request.Card != 0 {
+			if _, err := h.CardService.Usable(ctx, request.Account, request.Card); err != nil {
+				h.Logger.Errorf("h.CardService.Usable failed with %s\n", err)
+				return err
+			}
+		}
+
+		operation, err := h.Operation.FindByID(ctx, request.Operation)
+		if err != nil {
+			h.Logger.Errorf("h.Operation.FindByID failed with %s\n", err)
+			return err
+		}
+
+		if !operation.Debit {
+			return ErrHoldCreditOperation
+		}
+
+		if err := usable(account, true); err != nil {
+			return err
+		}
+
+		if err := h.SpendingControlService.Evaluate(ctx, purchase, request.Amount); err != nil {
+			h.Logger.Errorf("h.SpendingControlService.Evaluate failed with %s\n", err)
+			return err
+		}
+
+		decision, err = h.RiskService.Assess(ctx, purchase, account, request.Amount)
+		if err != nil {
+			h.Logger.Errorf("h.RiskService.Assess failed with %s\n", err)
+			return err
+		}
+
+		if decision.Outcome == risk.OutcomeDecline {
+			return ErrDeclineRisk
+		}
+
+		if err := h.AccountService.UpdateLimit(ctx, account, request.Amount, true); err != nil {
+			h.Logger.Errorf("h.AccountService.UpdateLimit failed with %s\n", err)
+			return err
+		}
+
+		now := time.Now()
+		structure := entity.Hold{
+			Account:   request.Account,
+			Type:      request.Operation,
+			Amount:    request.Amount,
+			Status:    entity.HoldStatusAuthorized,
+			ExpiresAt: now.Add(h.TTL),
+			CreatedBy: auth.Client(ctx),
+			CreatedAt: now,
+			UpdatedAt: now,
+			Merchant:  merchant(purchase),
+		}
+
+		if request.Card != 0 {
+			structure.Card = &request.Card
+		}
+
+		hold, err = h.HoldRepository.Create(ctx, structure)
+		return err
+	})
+
+	if decision != nil {
+		if err := h.RiskService.Record(ctx, decision); err != nil {
+			h.Logger.Errorf("h.RiskService.Record failed with %s\n", err)
+		}
+	}
+
+	authorization.Merchant = merchant(purchase)
+	if err == nil {
+		authorization.Hold = &hold.ID
+	}
+
+	if err := h.AuthorizationService.Record(ctx, authorization, err); err != nil {
+		h.Logger.Errorf("h.AuthorizationService.Record failed with %s\n", err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// Capture posts part or all of the reserved amount as a transaction. The limit was already
+// debited by Authorize, the remaining reservation stays in place until captured, voided or expired.
+func (h *Hold) Capture(ctx context.Context, id uint, request *contract.CaptureRequest) (*entity.Hold, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if err := request.Validate(); err != nil {
+		h.Logger.Errorf("request.Validate() failed with %s\n", err)
+		return nil, err
+	}
+
+	var hold *entity.Hold
+	err := h.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		hold, err = h.open(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		amount := request.Amount
+		if amount == 0 {
+			amount = hold.Remaining()
+		}
+
+		if amount > hold.Remaining() {
+			return ErrHoldCaptureExceeded
+		}
+
+		now := time.Now()
+		if _, err := h.TransactionRepository.Create(ctx, entity.Transaction{
+			Account:   hold.Account,
+			Type:      hold.Type,
+			Amount:    -amount,
+			Hold:      &hold.ID,
+			Card:      hold.Card,
+			CreatedBy: auth.Client(ctx),
+			CreatedAt: now,
+			Merchant:  hold.Merchant,
+		}); err != nil {
+			h.Logger.Errorf("h.TransactionRepository.Create failed with %s\n", err)
+			return err
+		}
+
+		hold.Captured += amount
+		hold.Status = entity.HoldStatusPartiallyCaptured
+		if hold.Remaining() == 0 {
+			hold.Status = entity.HoldStatusCaptured
+		}
+
+		hold.UpdatedAt = now
+		return h.HoldRepository.Update(ctx, hold)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// Void releases whatever is still reserved by the hold back to the account limit.
+func (h *Hold) Void(ctx context.Context, id uint) (*entity.Hold, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	var hold *entity.Hold
+	err := h.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		hold, err = h.open(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return h.release(ctx, hold, entity.HoldStatusVoided)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// Expire releases a batch of stale holds, each one in its own unit of work so a failure
+// does not roll back the others. It returns how many holds were expired.
+func (h *Hold) Expire(ctx context.Context) (int, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	ids, err := h.HoldRepository.FindExpired(ctx, time.Now(), HoldExpireBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		err := h.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+			hold, err := h.HoldRepository.FindByIDForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			if !hold.Open() || !hold.Expired(time.Now()) {
+				return nil
+			}
+
+			if err := h.release(ctx, hold, entity.HoldStatusExpired); err != nil {
+				return err
+			}
+
+			expired++
+			return nil
+		})
+
+		if err != nil {
+			h.Logger.Errorf("hold %d expiration failed with %s\n", id, err)
+		}
+	}
+
+	return expired, nil
+}
+
+func (h *Hold) open(ctx context.Context, id uint) (*entity.Hold, error) {
+	hold, err := h.HoldRepository.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		h.Logger.Errorf("h.HoldRepository.FindByIDForUpdate failed with %s\n", err)
+		return nil, err
+	}
+
+	if !hold.Open() {
+		return nil, ErrHoldNotOpen
+	}
+
+	if hold.Expired(time.Now()) {
+		return nil, ErrHoldExpired
+	}
+
+	return hold, nil
+}
+
+func (h *Hold) release(ctx context.Context, hold *entity.Hold, status string) error {
+	account, err := h.AccountRepository.FindByIDForUpdate(ctx, hold.Account)
+	if err != nil {
+		h.Logger.Errorf("h.AccountRepository.FindByIDForUpdate failed with %s\n", err)
+		return err
+	}
+
+	if err := h.AccountService.ReleaseLimit(ctx, account, hold.Remaining()); err != nil {
+		h.Logger.Errorf("h.AccountService.ReleaseLimit failed with %s\n", err)
+		return err
+	}
+
+	hold.Status = status
+	hold.UpdatedAt = time.Now()
+	return h.HoldRepository.Update(ctx, hold)
+}
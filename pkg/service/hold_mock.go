@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/service/hold.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	contract "ms/card/pkg/contract"
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockHolds is a mock of Holds interface.
+type MockHolds struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldsMockRecorder
+}
+
+// MockHoldsMockRecorder is the mock recorder for MockHolds.
+type MockHoldsMockRecorder struct {
+	mock *MockHolds
+}
+
+// NewMockHolds creates a new mock instance.
+func NewMockHolds(ctrl *gomock.Controller) *MockHolds {
+	mock := &MockHolds{ctrl: ctrl}
+	mock.recorder = &MockHoldsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHolds) EXPECT() *MockHoldsMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockHolds) Authorize(ctx context.Context, request *contract.HoldRequest) (*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, request)
+	ret0, _ := ret[0].(*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockHoldsMockRecorder) Authorize(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockHolds)(nil).Authorize), ctx, request)
+}
+
+// Capture mocks base method.
+func (m *MockHolds) Capture(ctx context.Context, id uint, request *contract.CaptureRequest) (*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, id, request)
+	ret0, _ := ret[0].(*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockHoldsMockRecorder) Capture(ctx, id, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockHolds)(nil).Capture), ctx, id, request)
+}
+
+// Expire mocks base method.
+func (m *MockHolds) Expire(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockHoldsMockRecorder) Expire(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockHolds)(nil).Expire), ctx)
+}
+
+// Void mocks base method.
+func (m *MockHolds) Void(ctx context.Context, id uint) (*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Void", ctx, id)
+	ret0, _ := ret[0].(*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Void indicates an expected call of Void.
+func (mr *MockHoldsMockRecorder) Void(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Void", reflect.TypeOf((*MockHolds)(nil).Void), ctx, id)
+}
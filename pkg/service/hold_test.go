@@ -0,0 +1,467 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/risk"
+	"testing"
+	"time"
+)
+
+func mockUnitOfWorkPassthrough(ctrl *gomock.Controller) *repository.MockUnitOfWork {
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	return mockUnitOfWork
+}
+
+func TestServiceHold_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	mockCardService := NewMockCards(ctrl)
+	mockCardService.EXPECT().Usable(gomock.Any(), uint(1), uint(3)).Return(&entity.Card{ID: 3, Account: 1}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1000), true).Return(nil)
+
+	purchase := &contract.TransactionRequest{Account: 1, Card: 3, Operation: 1, Amount: 1000, MCC: "5411", MerchantCountry: "BR"}
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), purchase, int64(1000)).Return(nil)
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Hold) (*entity.Hold, error) {
+		assert.Equal(t, entity.HoldStatusAuthorized, structure.Status)
+		assert.Equal(t, time.Hour, structure.ExpiresAt.Sub(structure.CreatedAt))
+		assert.Equal(t, uint(3), *structure.Card)
+		assert.Equal(t, entity.Merchant{MCC: "5411", MerchantCountry: "BR"}, structure.Merchant)
+		structure.ID = 1
+		return &structure, nil
+	})
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountService:         accountServiceMock,
+		AccountRepository:      mockAccountRepository,
+		Operation:              mockOperationRepository,
+		HoldRepository:         mockHoldRepository,
+		TTL:                    time.Hour,
+		AuthorizationService:   mockAuthorizationRecorded(ctrl),
+		CardService:            mockCardService,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            mockRiskApprove(ctrl),
+	})
+
+	hold, err := holdService.Authorize(context.Background(), &contract.HoldRequest{
+		Account:         1,
+		Card:            3,
+		Operation:       1,
+		Amount:          1000,
+		MCC:             "5411",
+		MerchantCountry: "BR",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), hold.ID)
+	assert.Equal(t, int64(1000), hold.Remaining())
+}
+
+func TestServiceHold_Authorize_Card_Blocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf("h.CardService.Usable failed with %s\n", ErrCardNotActive)
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}, nil)
+
+	mockCardService := NewMockCards(ctrl)
+	mockCardService.EXPECT().Usable(gomock.Any(), uint(1), uint(3)).Return(nil, ErrCardNotActive)
+
+	holdService := NewHold(HoldOpts{
+		Logger:               mockLogger,
+		UnitOfWork:           mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:    mockAccountRepository,
+		CardService:          mockCardService,
+		AuthorizationService: mockAuthorizationDeclined(t, ctrl, ErrCardNotActive.Code),
+	})
+
+	hold, err := holdService.Authorize(context.Background(), &contract.HoldRequest{Account: 1, Card: 3, Operation: 1, Amount: 1000})
+	assert.Nil(t, hold)
+	assert.Equal(t, ErrCardNotActive, err)
+}
+
+func TestServiceHold_Authorize_SpendingControl_Declined(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf("h.SpendingControlService.Evaluate failed with %s\n", ErrDeclineMCC)
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), int64(1000)).Return(ErrDeclineMCC)
+
+	holdService := NewHold(HoldOpts{
+		Logger:                 mockLogger,
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:      mockAccountRepository,
+		Operation:              mockOperationRepository,
+		SpendingControlService: spendingControlServiceMock,
+		AuthorizationService:   mockAuthorizationDeclined(t, ctrl, DeclineCodeMCC),
+	})
+
+	hold, err := holdService.Authorize(context.Background(), &contract.HoldRequest{Account: 1, Operation: 1, Amount: 1000, MCC: "7995"})
+	assert.Nil(t, hold)
+
+	var decline *Decline
+	if assert.ErrorAs(t, err, &decline) {
+		assert.Equal(t, DeclineCodeMCC, decline.Code)
+	}
+}
+
+func TestServiceHold_Authorize_Risk_Declined(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	decision := &entity.RiskDecision{Account: 1, Outcome: risk.OutcomeDecline, Score: 90}
+	riskServiceMock := NewMockRisks(ctrl)
+	riskServiceMock.EXPECT().Assess(gomock.Any(), gomock.Any(), gomock.Any(), int64(1000)).Return(decision, nil)
+	riskServiceMock.EXPECT().Record(gomock.Any(), decision).Return(nil)
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:      mockAccountRepository,
+		Operation:              mockOperationRepository,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            riskServiceMock,
+		AuthorizationService:   mockAuthorizationDeclined(t, ctrl, DeclineCodeRisk),
+	})
+
+	hold, err := holdService.Authorize(context.Background(), &contract.HoldRequest{Account: 1, Operation: 1, Amount: 1000})
+	assert.Nil(t, hold)
+	assert.Equal(t, ErrDeclineRisk, err)
+}
+
+func TestServiceHold_Authorize_Validate_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	holdService := NewHold(HoldOpts{
+		Logger:               mockLogger,
+		AuthorizationService: mockAuthorizationDeclined(t, ctrl, DeclineCodeInvalid),
+	})
+
+	hold, err := holdService.Authorize(context.Background(), &contract.HoldRequest{Account: 1, Operation: 1, Amount: -1000})
+	assert.Nil(t, hold)
+	assert.EqualError(t, err, "amount: must be no less than 1.")
+}
+
+func TestServiceHold_Authorize_Credit_Operation_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(4)).Return(&entity.Operation{ID: 4, Debit: false}, nil)
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:           mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:    mockAccountRepository,
+		Operation:            mockOperationRepository,
+		AuthorizationService: mockAuthorizationRecorded(ctrl),
+	})
+
+	hold, err := holdService.Authorize(context.Background(), &contract.HoldRequest{
+		Account:   1,
+		Operation: 4,
+		Amount:    1000,
+	})
+	assert.Nil(t, hold)
+	assert.EqualError(t, err, ErrHoldCreditOperation.Error())
+}
+
+func TestServiceHold_Capture_Partial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	card := uint(3)
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Hold{
+		ID:        1,
+		Account:   1,
+		Type:      1,
+		Card:      &card,
+		Amount:    1000,
+		Status:    entity.HoldStatusAuthorized,
+		ExpiresAt: time.Now().Add(time.Hour),
+		Merchant:  entity.Merchant{MerchantName: "HOTEL", MCC: "7011"},
+	}, nil)
+	mockHoldRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, int64(-400), structure.Amount)
+		assert.Equal(t, uint(1), *structure.Hold)
+		assert.Equal(t, uint(3), *structure.Card)
+		assert.Equal(t, entity.Merchant{MerchantName: "HOTEL", MCC: "7011"}, structure.Merchant)
+		return &structure, nil
+	})
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		HoldRepository:        mockHoldRepository,
+		TransactionRepository: mockTransactionRepository,
+	})
+
+	hold, err := holdService.Capture(context.Background(), 1, &contract.CaptureRequest{Amount: 400})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.HoldStatusPartiallyCaptured, hold.Status)
+	assert.Equal(t, int64(600), hold.Remaining())
+}
+
+func TestServiceHold_Capture_Remaining(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Hold{
+		ID:        1,
+		Account:   1,
+		Type:      1,
+		Amount:    1000,
+		Captured:  400,
+		Status:    entity.HoldStatusPartiallyCaptured,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	mockHoldRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, int64(-600), structure.Amount)
+		return &structure, nil
+	})
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		HoldRepository:        mockHoldRepository,
+		TransactionRepository: mockTransactionRepository,
+	})
+
+	hold, err := holdService.Capture(context.Background(), 1, &contract.CaptureRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.HoldStatusCaptured, hold.Status)
+}
+
+func TestServiceHold_Capture_Exceeded_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Hold{
+		ID:        1,
+		Amount:    1000,
+		Captured:  800,
+		Status:    entity.HoldStatusPartiallyCaptured,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:     mockUnitOfWorkPassthrough(ctrl),
+		HoldRepository: mockHoldRepository,
+	})
+
+	hold, err := holdService.Capture(context.Background(), 1, &contract.CaptureRequest{Amount: 300})
+	assert.Nil(t, hold)
+	assert.EqualError(t, err, ErrHoldCaptureExceeded.Error())
+}
+
+func TestServiceHold_Capture_Expired_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Hold{
+		ID:        1,
+		Amount:    1000,
+		Status:    entity.HoldStatusAuthorized,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}, nil)
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:     mockUnitOfWorkPassthrough(ctrl),
+		HoldRepository: mockHoldRepository,
+	})
+
+	hold, err := holdService.Capture(context.Background(), 1, &contract.CaptureRequest{})
+	assert.Nil(t, hold)
+	assert.EqualError(t, err, ErrHoldExpired.Error())
+}
+
+func TestServiceHold_Void(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Hold{
+		ID:        1,
+		Account:   1,
+		Amount:    1000,
+		Captured:  400,
+		Status:    entity.HoldStatusPartiallyCaptured,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	mockHoldRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 1000, AvailableLimit: 1000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().ReleaseLimit(gomock.Any(), mockAccountEntity, int64(600)).Return(nil)
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:        mockUnitOfWorkPassthrough(ctrl),
+		AccountService:    accountServiceMock,
+		AccountRepository: mockAccountRepository,
+		HoldRepository:    mockHoldRepository,
+	})
+
+	hold, err := holdService.Void(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.HoldStatusVoided, hold.Status)
+}
+
+func TestServiceHold_Void_Paid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Hold{
+		ID:        1,
+		Account:   1,
+		Amount:    1000,
+		Status:    entity.HoldStatusAuthorized,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	mockHoldRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	// the payment after the hold was authorized covered the used amount, the held 1000 included
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+	mockAccountRepository.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity).Return(nil)
+
+	mockLedger := NewMockLedgers(ctrl)
+	mockLedger.EXPECT().Post(gomock.Any(), gomock.Any()).Return(&entity.JournalEntry{}, nil)
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork: mockUnitOfWorkPassthrough(ctrl),
+		AccountService: NewAccount(AccountOpts{
+			AccountRepository: mockAccountRepository,
+			Ledger:            mockLedger,
+		}),
+		AccountRepository: mockAccountRepository,
+		HoldRepository:    mockHoldRepository,
+	})
+
+	hold, err := holdService.Void(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.HoldStatusVoided, hold.Status)
+	assert.Equal(t, int64(3000), mockAccountEntity.AvailableLimit)
+}
+
+func TestServiceHold_Void_NotOpen_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Hold{
+		ID:     1,
+		Status: entity.HoldStatusCaptured,
+	}, nil)
+
+	holdService := NewHold(HoldOpts{
+		UnitOfWork:     mockUnitOfWorkPassthrough(ctrl),
+		HoldRepository: mockHoldRepository,
+	})
+
+	hold, err := holdService.Void(context.Background(), 1)
+	assert.Nil(t, hold)
+	assert.EqualError(t, err, ErrHoldNotOpen.Error())
+}
+
+func TestServiceHold_Expire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockHoldRepository := repository.NewMockHolds(ctrl)
+	mockHoldRepository.EXPECT().FindExpired(gomock.Any(), gomock.Any(), HoldExpireBatchSize).Return([]uint{1, 2}, nil)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Hold{
+		ID:        1,
+		Account:   1,
+		Amount:    1000,
+		Status:    entity.HoldStatusAuthorized,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}, nil)
+	mockHoldRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(2)).Return(nil, repository.ErrHoldFindByID)
+	mockHoldRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure *entity.Hold) error {
+		assert.Equal(t, entity.HoldStatusExpired, structure.Status)
+		return nil
+	})
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 1000, AvailableLimit: 1000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().ReleaseLimit(gomock.Any(), mockAccountEntity, int64(1000)).Return(nil)
+
+	holdService := NewHold(HoldOpts{
+		Logger:            mockLogger,
+		UnitOfWork:        mockUnitOfWorkPassthrough(ctrl),
+		AccountService:    accountServiceMock,
+		AccountRepository: mockAccountRepository,
+		HoldRepository:    mockHoldRepository,
+	})
+
+	expired, err := holdService.Expire(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+}
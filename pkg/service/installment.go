@@ -0,0 +1,217 @@
+package service
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/auth"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+const (
+	InstallmentPostBatchSize = 100
+)
+
+var (
+	ErrInstallmentsCreditOperation = domain.Unprocessable("installments_credit_operation", "installments are only allowed for debit operations")
+	ErrInstallmentsAmount          = domain.Unprocessable("installments_amount_too_small", "amount is too small to be split into the requested installments")
+	ErrInstallmentsForeignCurrency = domain.Unprocessable("installments_foreign_currency", "installments are only allowed in the account billing currency")
+)
+
+type (
+	Installments interface {
+		Purchase(ctx context.Context, request *contract.TransactionRequest) (*entity.Transaction, error)
+		Post(ctx context.Context) (int, error)
+		Cancel(ctx context.Context, plan uint) (int64, int64, error)
+	}
+
+	InstallmentOpts struct {
+		Logger                common.Logger
+		UnitOfWork            repository.UnitOfWork
+		InstallmentRepository repository.Installments
+		TransactionRepository repository.Transactions
+	}
+
+	Installment struct {
+		InstallmentOpts
+	}
+)
+
+func NewInstallment(opts InstallmentOpts) *Installment {
+	return &Installment{opts}
+}
+
+// Purchase books the installment plan of a purchase whose full amount was already reserved
+// against the account limit. The first installment posts immediately, the others monthly.
+func (i *Installment) Purchase(ctx context.Context, request *contract.TransactionRequest) (*entity.Transaction, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	amount := common.Abs(request.Amount)
+	count := int(request.Installments)
+	if amount < int64(count) {
+		return nil, ErrInstallmentsAmount
+	}
+
+	var transaction *entity.Transaction
+	err := i.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		plan := entity.InstallmentPlan{
+			Account:   request.Account,
+			Type:      request.Operation,
+			Amount:    amount,
+			Count:     count,
+			CreatedBy: auth.Client(ctx),
+			CreatedAt: now,
+			Merchant:  merchant(request),
+		}
+
+		if request.Card != 0 {
+			plan.Card = &request.Card
+		}
+
+		for number, value := range common.Split(amount, count) {
+			plan.Installments = append(plan.Installments, &entity.Installment{
+				Number:  number + 1,
+				Amount:  value,
+				DueDate: dueDate(now, number),
+			})
+		}
+
+		created, err := i.InstallmentRepository.CreatePlan(ctx, plan)
+		if err != nil {
+			i.Logger.Errorf("i.InstallmentRepository.CreatePlan failed with %s\n", err)
+			return err
+		}
+
+		transaction, err = i.post(ctx, created, created.Installments[0], now)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// Post books a batch of due installments, each one in its own unit of work so a failure
+// does not roll back the others. The limit is not touched, it was reserved at purchase.
+// It returns how many installments were posted.
+func (i *Installment) Post(ctx context.Context) (int, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	ids, err := i.InstallmentRepository.FindDue(ctx, time.Now(), InstallmentPostBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for _, id := range ids {
+		err := i.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+			installment, err := i.InstallmentRepository.FindByIDForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			if installment.Posted() || installment.Cancelled() {
+				return nil
+			}
+
+			plan, err := i.InstallmentRepository.FindPlanByID(ctx, installment.Plan)
+			if err != nil {
+				return err
+			}
+
+			if _, err := i.post(ctx, plan, installment, time.Now()); err != nil {
+				return err
+			}
+
+			posted++
+			return nil
+		})
+
+		if err != nil {
+			i.Logger.Errorf("installment %d posting failed with %s\n", id, err)
+		}
+	}
+
+	return posted, nil
+}
+
+// Cancel cancels the installments of the plan not posted yet so they are never posted, it
+// must run in the unit of work of the reversal. It returns the amount already posted and
+// the amount cancelled.
+func (i *Installment) Cancel(ctx context.Context, plan uint) (int64, int64, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	installments, err := i.InstallmentRepository.FindByPlanForUpdate(ctx, plan)
+	if err != nil {
+		i.Logger.Errorf("i.InstallmentRepository.FindByPlanForUpdate failed with %s\n", err)
+		return 0, 0, err
+	}
+
+	now := time.Now()
+	var posted, cancelled int64
+	for _, installment := range installments {
+		switch {
+		case installment.Posted():
+			posted += installment.Amount
+		case !installment.Cancelled():
+			installment.CancelledAt = &now
+			if err := i.InstallmentRepository.Update(ctx, installment); err != nil {
+				i.Logger.Errorf("i.InstallmentRepository.Update failed with %s\n", err)
+				return 0, 0, err
+			}
+
+			cancelled += installment.Amount
+		}
+	}
+
+	return posted, cancelled, nil
+}
+
+// dueDate is the same day the given months after the purchase, or the last day of that month
+// when it is shorter: a purchase on January 31 is due on February 28, not March 3.
+func dueDate(purchase time.Time, months int) time.Time {
+	year, month, day := purchase.Date()
+	last := time.Date(year, month+time.Month(months)+1, 0, 0, 0, 0, 0, purchase.Location()).Day()
+	if day > last {
+		day = last
+	}
+
+	return time.Date(year, month+time.Month(months), day, purchase.Hour(), purchase.Minute(), purchase.Second(), purchase.Nanosecond(), purchase.Location())
+}
+
+func (i *Installment) post(ctx context.Context, plan *entity.InstallmentPlan, installment *entity.Installment, now time.Time) (*entity.Transaction, error) {
+	transaction, err := i.TransactionRepository.Create(ctx, entity.Transaction{
+		Account:   plan.Account,
+		Type:      plan.Type,
+		Amount:    -installment.Amount,
+		Plan:      &plan.ID,
+		Card:      plan.Card,
+		CreatedBy: plan.CreatedBy,
+		CreatedAt: now,
+		Merchant:  plan.Merchant,
+	})
+
+	if err != nil {
+		i.Logger.Errorf("i.TransactionRepository.Create failed with %s\n", err)
+		return nil, err
+	}
+
+	installment.Transaction = &transaction.ID
+	installment.PostedAt = &now
+	if err := i.InstallmentRepository.Update(ctx, installment); err != nil {
+		i.Logger.Errorf("i.InstallmentRepository.Update failed with %s\n", err)
+		return nil, err
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/service/installment.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	contract "ms/card/pkg/contract"
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockInstallments is a mock of Installments interface.
+type MockInstallments struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstallmentsMockRecorder
+}
+
+// MockInstallmentsMockRecorder is the mock recorder for MockInstallments.
+type MockInstallmentsMockRecorder struct {
+	mock *MockInstallments
+}
+
+// NewMockInstallments creates a new mock instance.
+func NewMockInstallments(ctrl *gomock.Controller) *MockInstallments {
+	mock := &MockInstallments{ctrl: ctrl}
+	mock.recorder = &MockInstallmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstallments) EXPECT() *MockInstallmentsMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockInstallments) Cancel(ctx context.Context, plan uint) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, plan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockInstallmentsMockRecorder) Cancel(ctx, plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockInstallments)(nil).Cancel), ctx, plan)
+}
+
+// Post mocks base method.
+func (m *MockInstallments) Post(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockInstallmentsMockRecorder) Post(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockInstallments)(nil).Post), ctx)
+}
+
+// Purchase mocks base method.
+func (m *MockInstallments) Purchase(ctx context.Context, request *contract.TransactionRequest) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purchase", ctx, request)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purchase indicates an expected call of Purchase.
+func (mr *MockInstallmentsMockRecorder) Purchase(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purchase", reflect.TypeOf((*MockInstallments)(nil).Purchase), ctx, request)
+}
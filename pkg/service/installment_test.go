@@ -0,0 +1,165 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestServiceInstallment_Purchase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInstallmentRepository := repository.NewMockInstallments(ctrl)
+	mockInstallmentRepository.EXPECT().CreatePlan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.InstallmentPlan) (*entity.InstallmentPlan, error) {
+		assert.Equal(t, int64(1000), structure.Amount)
+		assert.Len(t, structure.Installments, 3)
+		assert.Equal(t, int64(334), structure.Installments[0].Amount)
+		assert.Equal(t, int64(333), structure.Installments[2].Amount)
+		assert.Equal(t, dueDate(structure.CreatedAt, 2), structure.Installments[2].DueDate)
+
+		structure.ID = 1
+		for i, installment := range structure.Installments {
+			installment.ID = uint(i + 1)
+			installment.Plan = 1
+		}
+
+		return &structure, nil
+	})
+	mockInstallmentRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure *entity.Installment) error {
+		assert.Equal(t, 1, structure.Number)
+		assert.True(t, structure.Posted())
+		return nil
+	})
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, int64(-334), structure.Amount)
+		assert.Equal(t, uint(1), *structure.Plan)
+		structure.ID = 10
+		return &structure, nil
+	})
+
+	installmentService := NewInstallment(InstallmentOpts{
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		InstallmentRepository: mockInstallmentRepository,
+		TransactionRepository: mockTransactionRepository,
+	})
+
+	transaction, err := installmentService.Purchase(context.Background(), &contract.TransactionRequest{
+		Account:      1,
+		Operation:    2,
+		Amount:       1000,
+		Installments: 3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(10), transaction.ID)
+}
+
+func TestServiceInstallment_Purchase_Amount_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	installmentService := NewInstallment(InstallmentOpts{})
+
+	transaction, err := installmentService.Purchase(context.Background(), &contract.TransactionRequest{
+		Account:      1,
+		Operation:    2,
+		Amount:       2,
+		Installments: 3,
+	})
+	assert.Nil(t, transaction)
+	assert.EqualError(t, err, ErrInstallmentsAmount.Error())
+}
+
+func TestServiceInstallment_Post(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	transactionID := uint(9)
+	mockInstallmentRepository := repository.NewMockInstallments(ctrl)
+	mockInstallmentRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), InstallmentPostBatchSize).Return([]uint{2, 3, 4}, nil)
+	mockInstallmentRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(2)).Return(&entity.Installment{ID: 2, Plan: 1, Number: 2, Amount: 500}, nil)
+	mockInstallmentRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(3)).Return(&entity.Installment{ID: 3, Plan: 1, Number: 3, Amount: 500, Transaction: &transactionID}, nil)
+	mockInstallmentRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(4)).Return(nil, repository.ErrInstallmentFindByID)
+	mockInstallmentRepository.EXPECT().FindPlanByID(gomock.Any(), uint(1)).Return(&entity.InstallmentPlan{ID: 1, Account: 1, Type: 2}, nil)
+	mockInstallmentRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, int64(-500), structure.Amount)
+		structure.ID = 11
+		return &structure, nil
+	})
+
+	installmentService := NewInstallment(InstallmentOpts{
+		Logger:                mockLogger,
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		InstallmentRepository: mockInstallmentRepository,
+		TransactionRepository: mockTransactionRepository,
+	})
+
+	posted, err := installmentService.Post(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, posted)
+}
+
+func TestServiceInstallment_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transactionID := uint(9)
+	cancelledAt := time.Now()
+	mockInstallmentRepository := repository.NewMockInstallments(ctrl)
+	mockInstallmentRepository.EXPECT().FindByPlanForUpdate(gomock.Any(), uint(1)).Return([]*entity.Installment{
+		{ID: 1, Plan: 1, Number: 1, Amount: 334, Transaction: &transactionID},
+		{ID: 2, Plan: 1, Number: 2, Amount: 333, CancelledAt: &cancelledAt},
+		{ID: 3, Plan: 1, Number: 3, Amount: 333},
+	}, nil)
+	mockInstallmentRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure *entity.Installment) error {
+		assert.Equal(t, uint(3), structure.ID)
+		assert.True(t, structure.Cancelled())
+		return nil
+	})
+
+	installmentService := NewInstallment(InstallmentOpts{
+		InstallmentRepository: mockInstallmentRepository,
+	})
+
+	posted, cancelled, err := installmentService.Cancel(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(334), posted)
+	assert.Equal(t, int64(333), cancelled)
+}
+
+func TestDueDate(t *testing.T) {
+	cases := []struct {
+		purchase time.Time
+		months   int
+		expected time.Time
+	}{
+		{purchase: time.Date(2022, time.March, 12, 10, 0, 0, 0, time.UTC), months: 1, expected: time.Date(2022, time.April, 12, 10, 0, 0, 0, time.UTC)},
+		{purchase: time.Date(2022, time.January, 31, 10, 0, 0, 0, time.UTC), months: 1, expected: time.Date(2022, time.February, 28, 10, 0, 0, 0, time.UTC)},
+		{purchase: time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC), months: 1, expected: time.Date(2024, time.February, 29, 10, 0, 0, 0, time.UTC)},
+		{purchase: time.Date(2022, time.January, 31, 10, 0, 0, 0, time.UTC), months: 2, expected: time.Date(2022, time.March, 31, 10, 0, 0, 0, time.UTC)},
+		{purchase: time.Date(2022, time.March, 31, 10, 0, 0, 0, time.UTC), months: 1, expected: time.Date(2022, time.April, 30, 10, 0, 0, 0, time.UTC)},
+		{purchase: time.Date(2022, time.December, 31, 10, 0, 0, 0, time.UTC), months: 2, expected: time.Date(2023, time.February, 28, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.purchase.Format("2006-01-02")+"+"+strconv.Itoa(tt.months), func(t *testing.T) {
+			assert.Equal(t, tt.expected, dueDate(tt.purchase, tt.months))
+		})
+	}
+}
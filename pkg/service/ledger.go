@@ -0,0 +1,65 @@
+package service
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+)
+
+var (
+	ErrLedgerUnbalanced = domain.Internal("ledger_unbalanced", "journal entry postings must sum to zero")
+)
+
+type (
+	Ledgers interface {
+		Post(ctx context.Context, entry entity.JournalEntry) (*entity.JournalEntry, error)
+		Reconcile(ctx context.Context) ([]*entity.LedgerDrift, error)
+	}
+
+	LedgerOpts struct {
+		Logger           common.Logger
+		LedgerRepository repository.Ledgers
+	}
+
+	Ledger struct {
+		LedgerOpts
+	}
+)
+
+func NewLedger(opts LedgerOpts) *Ledger {
+	return &Ledger{opts}
+}
+
+// Post appends a journal entry, rejecting it unless its postings balance.
+func (l *Ledger) Post(ctx context.Context, entry entity.JournalEntry) (*entity.JournalEntry, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if !entry.Balanced() {
+		l.Logger.Errorf("journal entry of account %d is unbalanced\n", entry.Account)
+		return nil, ErrLedgerUnbalanced
+	}
+
+	return l.LedgerRepository.Create(ctx, entry)
+}
+
+// Reconcile compares every account limit with its available ledger balance and reports
+// the accounts that drifted apart.
+func (l *Ledger) Reconcile(ctx context.Context) ([]*entity.LedgerDrift, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	drifts, err := l.LedgerRepository.FindDrifts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, drift := range drifts {
+		l.Logger.Errorf("account %d limit %d drifted from ledger balance %d\n", drift.Account, drift.Limit, drift.Ledger)
+	}
+
+	return drifts, nil
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/service/ledger.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockLedgers is a mock of Ledgers interface.
+type MockLedgers struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgersMockRecorder
+}
+
+// MockLedgersMockRecorder is the mock recorder for MockLedgers.
+type MockLedgersMockRecorder struct {
+	mock *MockLedgers
+}
+
+// NewMockLedgers creates a new mock instance.
+func NewMockLedgers(ctrl *gomock.Controller) *MockLedgers {
+	mock := &MockLedgers{ctrl: ctrl}
+	mock.recorder = &MockLedgersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgers) EXPECT() *MockLedgersMockRecorder {
+	return m.recorder
+}
+
+// Post mocks base method.
+func (m *MockLedgers) Post(ctx context.Context, entry entity.JournalEntry) (*entity.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, entry)
+	ret0, _ := ret[0].(*entity.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockLedgersMockRecorder) Post(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockLedgers)(nil).Post), ctx, entry)
+}
+
+// Reconcile mocks base method.
+func (m *MockLedgers) Reconcile(ctx context.Context) ([]*entity.LedgerDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx)
+	ret0, _ := ret[0].([]*entity.LedgerDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockLedgersMockRecorder) Reconcile(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockLedgers)(nil).Reconcile), ctx)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"testing"
+)
+
+func TestServiceLedger_Post(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	entry := entity.JournalEntry{Account: 1}
+	entry.Transfer(entity.LedgerAvailable, entity.LedgerUsed, 1000)
+
+	mockLedgerRepository := repository.NewMockLedgers(ctrl)
+	mockLedgerRepository.EXPECT().Create(gomock.Any(), entry).Return(&entry, nil)
+
+	ledgerService := NewLedger(LedgerOpts{
+		LedgerRepository: mockLedgerRepository,
+	})
+
+	created, err := ledgerService.Post(context.Background(), entry)
+	assert.NoError(t, err)
+	assert.NotNil(t, created)
+}
+
+func TestServiceLedger_Post_Unbalanced_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	ledgerService := NewLedger(LedgerOpts{
+		Logger: mockLogger,
+	})
+
+	created, err := ledgerService.Post(context.Background(), entity.JournalEntry{
+		Account:  1,
+		Postings: []*entity.Posting{{Account: 1, Ledger: entity.LedgerAvailable, Amount: -1000}},
+	})
+	assert.Nil(t, created)
+	assert.EqualError(t, err, ErrLedgerUnbalanced.Error())
+}
+
+func TestServiceLedger_Reconcile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), uint(3), int64(900), int64(1000))
+
+	mockLedgerRepository := repository.NewMockLedgers(ctrl)
+	mockLedgerRepository.EXPECT().FindDrifts(gomock.Any()).Return([]*entity.LedgerDrift{{Account: 3, Limit: 900, Ledger: 1000}}, nil)
+
+	ledgerService := NewLedger(LedgerOpts{
+		Logger:           mockLogger,
+		LedgerRepository: mockLedgerRepository,
+	})
+
+	drifts, err := ledgerService.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, drifts, 1)
+}
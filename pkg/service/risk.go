@@ -0,0 +1,98 @@
+package service
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/risk"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+const (
+	// RiskHistoryWindow is how far back the transactions handed to the scorers go.
+	RiskHistoryWindow = 30 * 24 * time.Hour
+)
+
+type (
+	Risks interface {
+		Assess(ctx context.Context, request *contract.TransactionRequest, account *entity.Account, amount int64) (*entity.RiskDecision, error)
+		Record(ctx context.Context, decision *entity.RiskDecision) error
+	}
+
+	RiskOpts struct {
+		Logger                 common.Logger
+		Scorer                 risk.Scorer
+		TransactionRepository  repository.Transactions
+		RiskDecisionRepository repository.RiskDecisions
+	}
+
+	Risk struct {
+		RiskOpts
+	}
+)
+
+func NewRisk(opts RiskOpts) *Risk {
+	return &Risk{opts}
+}
+
+// Assess scores a purchase of amount, already in the account currency, against the recent
+// history of the account. The decision is not stored, see Record.
+func (r *Risk) Assess(ctx context.Context, request *contract.TransactionRequest, account *entity.Account, amount int64) (*entity.RiskDecision, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	now := time.Now()
+	history, err := r.TransactionRepository.FindByAccountBetween(ctx, account.ID, now.Add(-RiskHistoryWindow), now)
+	if err != nil {
+		r.Logger.Errorf("r.TransactionRepository.FindByAccountBetween failed with %s\n", err)
+		return nil, err
+	}
+
+	assessment, err := r.Scorer.Score(ctx, &risk.Input{
+		Request: request,
+		Account: account,
+		Amount:  amount,
+		History: history,
+	})
+
+	if err != nil {
+		r.Logger.Errorf("r.Scorer.Score failed with %s\n", err)
+		return nil, err
+	}
+
+	decision := &entity.RiskDecision{
+		Account:   account.ID,
+		Amount:    amount,
+		Outcome:   assessment.Outcome,
+		Score:     assessment.Score,
+		Reasons:   assessment.Reasons,
+		CreatedAt: now,
+	}
+
+	if request.Card != 0 {
+		decision.Card = &request.Card
+	}
+
+	return decision, nil
+}
+
+// Record stores the decision for audit. It must be called outside of the unit of work of the
+// purchase, so declined purchases that roll back keep their decision, and is not bound by
+// the deadline of the purchase.
+func (r *Risk) Record(ctx context.Context, decision *entity.RiskDecision) error {
+	ctx, cancel := detach(ctx)
+	defer cancel()
+
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if _, err := r.RiskDecisionRepository.Create(ctx, *decision); err != nil {
+		r.Logger.Errorf("r.RiskDecisionRepository.Create failed with %s\n", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/service/risk.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	contract "ms/card/pkg/contract"
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockRisks is a mock of Risks interface.
+type MockRisks struct {
+	ctrl     *gomock.Controller
+	recorder *MockRisksMockRecorder
+}
+
+// MockRisksMockRecorder is the mock recorder for MockRisks.
+type MockRisksMockRecorder struct {
+	mock *MockRisks
+}
+
+// NewMockRisks creates a new mock instance.
+func NewMockRisks(ctrl *gomock.Controller) *MockRisks {
+	mock := &MockRisks{ctrl: ctrl}
+	mock.recorder = &MockRisksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRisks) EXPECT() *MockRisksMockRecorder {
+	return m.recorder
+}
+
+// Assess mocks base method.
+func (m *MockRisks) Assess(ctx context.Context, request *contract.TransactionRequest, account *entity.Account, amount int64) (*entity.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assess", ctx, request, account, amount)
+	ret0, _ := ret[0].(*entity.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assess indicates an expected call of Assess.
+func (mr *MockRisksMockRecorder) Assess(ctx, request, account, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assess", reflect.TypeOf((*MockRisks)(nil).Assess), ctx, request, account, amount)
+}
+
+// Record mocks base method.
+func (m *MockRisks) Record(ctx context.Context, decision *entity.RiskDecision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, decision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRisksMockRecorder) Record(ctx, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRisks)(nil).Record), ctx, decision)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/risk"
+	"testing"
+)
+
+func mockRiskApprove(ctrl *gomock.Controller) *MockRisks {
+	mockRiskService := NewMockRisks(ctrl)
+	mockRiskService.EXPECT().Assess(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.RiskDecision{Account: 1, Outcome: risk.OutcomeApprove}, nil)
+	mockRiskService.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil)
+
+	return mockRiskService
+}
+
+func TestServiceRisk_Assess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	history := []*entity.Transaction{{ID: 1, Account: 1, Amount: -500}}
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByAccountBetween(gomock.Any(), uint(1), gomock.Any(), gomock.Any()).Return(history, nil)
+
+	account := &entity.Account{ID: 1}
+	request := &contract.TransactionRequest{Account: 1, Card: 3, Operation: 1, Amount: 1000}
+
+	mockScorer := risk.NewMockScorer(ctrl)
+	mockScorer.EXPECT().Score(gomock.Any(), &risk.Input{Request: request, Account: account, Amount: 1000, History: history}).Return(&risk.Assessment{
+		Outcome: risk.OutcomeReview,
+		Score:   60,
+		Reasons: []string{"rules:velocity"},
+	}, nil)
+
+	service := NewRisk(RiskOpts{
+		Scorer:                mockScorer,
+		TransactionRepository: mockTransactionRepository,
+	})
+
+	decision, err := service.Assess(context.Background(), request, account, 1000)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(1), decision.Account)
+		assert.Equal(t, uint(3), *decision.Card)
+		assert.Nil(t, decision.Transaction)
+		assert.Equal(t, risk.OutcomeReview, decision.Outcome)
+		assert.Equal(t, 60, decision.Score)
+		assert.Equal(t, entity.List{"rules:velocity"}, decision.Reasons)
+	}
+}
+
+func TestServiceRisk_Assess_Score_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	err := xerrors.New("scorer down")
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf("r.Scorer.Score failed with %s\n", err)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByAccountBetween(gomock.Any(), uint(1), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	mockScorer := risk.NewMockScorer(ctrl)
+	mockScorer.EXPECT().Score(gomock.Any(), gomock.Any()).Return(nil, err)
+
+	service := NewRisk(RiskOpts{
+		Logger:                mockLogger,
+		Scorer:                mockScorer,
+		TransactionRepository: mockTransactionRepository,
+	})
+
+	decision, got := service.Assess(context.Background(), &contract.TransactionRequest{Account: 1}, &entity.Account{ID: 1}, 1000)
+	assert.Nil(t, decision)
+	assert.Equal(t, err, got)
+}
+
+func TestServiceRisk_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRiskDecisionRepository := repository.NewMockRiskDecisions(ctrl)
+	mockRiskDecisionRepository.EXPECT().Create(gomock.Any(), entity.RiskDecision{Account: 1, Outcome: risk.OutcomeDecline}).Return(&entity.RiskDecision{ID: 1}, nil)
+
+	service := NewRisk(RiskOpts{
+		RiskDecisionRepository: mockRiskDecisionRepository,
+	})
+
+	assert.NoError(t, service.Record(context.Background(), &entity.RiskDecision{Account: 1, Outcome: risk.OutcomeDecline}))
+}
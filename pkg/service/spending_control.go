@@ -0,0 +1,150 @@
+package service
+
+import (
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+type (
+	SpendingControls interface {
+		Configure(ctx context.Context, account uint, card uint, request *contract.SpendingControlRequest) (*entity.SpendingControl, error)
+		Evaluate(ctx context.Context, request *contract.TransactionRequest, amount int64) error
+	}
+
+	SpendingControlOpts struct {
+		Logger                    common.Logger
+		AccountRepository         repository.Accounts
+		CardRepository            repository.Cards
+		TransactionRepository     repository.Transactions
+		SpendingControlRepository repository.SpendingControls
+		HomeCountry               string
+	}
+
+	SpendingControl struct {
+		SpendingControlOpts
+	}
+)
+
+func NewSpendingControl(opts SpendingControlOpts) *SpendingControl {
+	return &SpendingControl{opts}
+}
+
+// Configure replaces the spending control of the account, or of one of its cards when card
+// is not zero.
+func (s *SpendingControl) Configure(ctx context.Context, account uint, card uint, request *contract.SpendingControlRequest) (*entity.SpendingControl, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if err := request.Validate(); err != nil {
+		s.Logger.Errorf("request.Validate() failed with %s\n", err)
+		return nil, err
+	}
+
+	if _, err := s.AccountRepository.FindByID(ctx, account); err != nil {
+		s.Logger.Errorf("s.AccountRepository.FindByID failed with %s\n", err)
+		return nil, err
+	}
+
+	if card != 0 {
+		found, err := s.CardRepository.FindByID(ctx, card)
+		if err != nil {
+			s.Logger.Errorf("s.CardRepository.FindByID failed with %s\n", err)
+			return nil, err
+		}
+
+		if found.Account != account {
+			return nil, ErrCardAccountMismatch
+		}
+	}
+
+	control := &entity.SpendingControl{
+		Account:            account,
+		Card:               card,
+		MaxAmount:          request.MaxAmount,
+		DailyLimit:         request.DailyLimit,
+		MonthlyLimit:       request.MonthlyLimit,
+		BlockedMCCs:        entity.List{},
+		BlockEcommerce:     request.BlockEcommerce,
+		BlockInternational: request.BlockInternational,
+		UpdatedAt:          time.Now(),
+	}
+
+	if len(request.BlockedMCCs) > 0 {
+		control.BlockedMCCs = request.BlockedMCCs
+	}
+
+	if err := s.SpendingControlRepository.Save(ctx, control); err != nil {
+		s.Logger.Errorf("s.SpendingControlRepository.Save failed with %s\n", err)
+		return nil, err
+	}
+
+	return control, nil
+}
+
+// Evaluate checks a purchase of amount, already in the account currency, against the
+// controls of the account and of the card. It returns a *Decline for the first rule broken.
+func (s *SpendingControl) Evaluate(ctx context.Context, request *contract.TransactionRequest, amount int64) error {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	controls, err := s.SpendingControlRepository.FindApplicable(ctx, request.Account, request.Card)
+	if err != nil {
+		s.Logger.Errorf("s.SpendingControlRepository.FindApplicable failed with %s\n", err)
+		return err
+	}
+
+	now := time.Now()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	for _, control := range controls {
+		if control.MaxAmount > 0 && amount > control.MaxAmount {
+			return ErrDeclineMaxAmount
+		}
+
+		if request.MCC != "" && control.BlockedMCCs.Contains(request.MCC) {
+			return ErrDeclineMCC
+		}
+
+		if control.BlockEcommerce && request.EntryMode == contract.EntryModeEcommerce {
+			return ErrDeclineEcommerce
+		}
+
+		if control.BlockInternational && request.MerchantCountry != "" && request.MerchantCountry != s.HomeCountry {
+			return ErrDeclineInternational
+		}
+
+		if err := s.velocity(ctx, control, control.DailyLimit, day, amount, ErrDeclineDailyLimit); err != nil {
+			return err
+		}
+
+		if err := s.velocity(ctx, control, control.MonthlyLimit, month, amount, ErrDeclineMonthlyLimit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// velocity declines when the spending since the start of the window plus amount goes over limit.
+func (s *SpendingControl) velocity(ctx context.Context, control *entity.SpendingControl, limit int64, since time.Time, amount int64, decline *Decline) error {
+	if limit == 0 {
+		return nil
+	}
+
+	spent, err := s.TransactionRepository.SumDebitsSince(ctx, control.Account, control.Card, since)
+	if err != nil {
+		s.Logger.Errorf("s.TransactionRepository.SumDebitsSince failed with %s\n", err)
+		return err
+	}
+
+	if spent+amount > limit {
+		return decline
+	}
+
+	return nil
+}
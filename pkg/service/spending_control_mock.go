@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/service/spending_control.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	contract "ms/card/pkg/contract"
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockSpendingControls is a mock of SpendingControls interface.
+type MockSpendingControls struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpendingControlsMockRecorder
+}
+
+// MockSpendingControlsMockRecorder is the mock recorder for MockSpendingControls.
+type MockSpendingControlsMockRecorder struct {
+	mock *MockSpendingControls
+}
+
+// NewMockSpendingControls creates a new mock instance.
+func NewMockSpendingControls(ctrl *gomock.Controller) *MockSpendingControls {
+	mock := &MockSpendingControls{ctrl: ctrl}
+	mock.recorder = &MockSpendingControlsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpendingControls) EXPECT() *MockSpendingControlsMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockSpendingControls) Configure(ctx context.Context, account, card uint, request *contract.SpendingControlRequest) (*entity.SpendingControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Configure", ctx, account, card, request)
+	ret0, _ := ret[0].(*entity.SpendingControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockSpendingControlsMockRecorder) Configure(ctx, account, card, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockSpendingControls)(nil).Configure), ctx, account, card, request)
+}
+
+// Evaluate mocks base method.
+func (m *MockSpendingControls) Evaluate(ctx context.Context, request *contract.TransactionRequest, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, request, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockSpendingControlsMockRecorder) Evaluate(ctx, request, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockSpendingControls)(nil).Evaluate), ctx, request, amount)
+}
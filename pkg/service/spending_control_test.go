@@ -0,0 +1,148 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"testing"
+)
+
+func TestServiceSpendingControl_Configure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1}, nil)
+
+	mockCardRepository := repository.NewMockCards(ctrl)
+	mockCardRepository.EXPECT().FindByID(gomock.Any(), uint(2)).Return(&entity.Card{ID: 2, Account: 1}, nil)
+
+	mockSpendingControlRepository := repository.NewMockSpendingControls(ctrl)
+	mockSpendingControlRepository.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure *entity.SpendingControl) error {
+		assert.Equal(t, uint(1), structure.Account)
+		assert.Equal(t, uint(2), structure.Card)
+		assert.Equal(t, entity.List{"7995"}, structure.BlockedMCCs)
+		return nil
+	})
+
+	spendingControlService := NewSpendingControl(SpendingControlOpts{
+		AccountRepository:         mockAccountRepository,
+		CardRepository:            mockCardRepository,
+		SpendingControlRepository: mockSpendingControlRepository,
+	})
+
+	control, err := spendingControlService.Configure(context.Background(), 1, 2, &contract.SpendingControlRequest{
+		MaxAmount:      5000,
+		BlockedMCCs:    []string{"7995"},
+		BlockEcommerce: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5000), control.MaxAmount)
+	assert.True(t, control.BlockEcommerce)
+}
+
+func TestServiceSpendingControl_Configure_CardAccountMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1}, nil)
+
+	mockCardRepository := repository.NewMockCards(ctrl)
+	mockCardRepository.EXPECT().FindByID(gomock.Any(), uint(2)).Return(&entity.Card{ID: 2, Account: 9}, nil)
+
+	spendingControlService := NewSpendingControl(SpendingControlOpts{
+		AccountRepository: mockAccountRepository,
+		CardRepository:    mockCardRepository,
+	})
+
+	control, err := spendingControlService.Configure(context.Background(), 1, 2, &contract.SpendingControlRequest{})
+	assert.Nil(t, control)
+	assert.ErrorIs(t, err, ErrCardAccountMismatch)
+}
+
+func TestServiceSpendingControl_Evaluate(t *testing.T) {
+	cases := []struct {
+		description string
+		control     entity.SpendingControl
+		request     contract.TransactionRequest
+		spent       int64
+		expected    error
+	}{
+		{
+			description: "no rule broken",
+			control:     entity.SpendingControl{MaxAmount: 5000, DailyLimit: 10000, BlockedMCCs: entity.List{"7995"}, BlockEcommerce: true, BlockInternational: true},
+			request:     contract.TransactionRequest{MCC: "5411", MerchantCountry: "BR", EntryMode: contract.EntryModeChip},
+			spent:       8000,
+		},
+		{
+			description: "over the per transaction maximum",
+			control:     entity.SpendingControl{MaxAmount: 500},
+			expected:    ErrDeclineMaxAmount,
+		},
+		{
+			description: "blocked merchant category",
+			control:     entity.SpendingControl{BlockedMCCs: entity.List{"7995"}},
+			request:     contract.TransactionRequest{MCC: "7995"},
+			expected:    ErrDeclineMCC,
+		},
+		{
+			description: "e-commerce switched off",
+			control:     entity.SpendingControl{BlockEcommerce: true},
+			request:     contract.TransactionRequest{EntryMode: contract.EntryModeEcommerce},
+			expected:    ErrDeclineEcommerce,
+		},
+		{
+			description: "international switched off",
+			control:     entity.SpendingControl{BlockInternational: true},
+			request:     contract.TransactionRequest{MerchantCountry: "US"},
+			expected:    ErrDeclineInternational,
+		},
+		{
+			description: "over the daily limit",
+			control:     entity.SpendingControl{DailyLimit: 10000},
+			spent:       9500,
+			expected:    ErrDeclineDailyLimit,
+		},
+		{
+			description: "over the monthly limit",
+			control:     entity.SpendingControl{MonthlyLimit: 10000},
+			spent:       9500,
+			expected:    ErrDeclineMonthlyLimit,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tt.request.Account = 1
+			tt.control.Account = 1
+
+			mockSpendingControlRepository := repository.NewMockSpendingControls(ctrl)
+			mockSpendingControlRepository.EXPECT().FindApplicable(gomock.Any(), uint(1), uint(0)).Return([]*entity.SpendingControl{&tt.control}, nil)
+
+			mockTransactionRepository := repository.NewMockTransactions(ctrl)
+			mockTransactionRepository.EXPECT().SumDebitsSince(gomock.Any(), uint(1), uint(0), gomock.Any()).Return(tt.spent, nil).AnyTimes()
+
+			spendingControlService := NewSpendingControl(SpendingControlOpts{
+				TransactionRepository:     mockTransactionRepository,
+				SpendingControlRepository: mockSpendingControlRepository,
+				HomeCountry:               "BR",
+			})
+
+			err := spendingControlService.Evaluate(context.Background(), &tt.request, 1000)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/telemetry/jaeger"
+	"time"
+)
+
+const (
+	StatementCloseBatchSize = 100
+	// StatementMinimumPaymentRate is the percentage of the closing balance due as minimum payment.
+	StatementMinimumPaymentRate = 15
+)
+
+type (
+	Statements interface {
+		Configure(ctx context.Context, account uint, request *contract.BillingCycleRequest) (*entity.BillingCycle, error)
+		Close(ctx context.Context) (int, error)
+	}
+
+	StatementOpts struct {
+		Logger                 common.Logger
+		UnitOfWork             repository.UnitOfWork
+		AccountRepository      repository.Accounts
+		Operation              repository.Operations
+		TransactionRepository  repository.Transactions
+		BillingCycleRepository repository.BillingCycles
+		StatementRepository    repository.Statements
+	}
+
+	Statement struct {
+		StatementOpts
+	}
+)
+
+func NewStatement(opts StatementOpts) *Statement {
+	return &Statement{opts}
+}
+
+// Configure sets the billing cycle of the account, the next closing date is the first
+// occurrence of the closing day from now on.
+func (s *Statement) Configure(ctx context.Context, account uint, request *contract.BillingCycleRequest) (*entity.BillingCycle, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if err := request.Validate(); err != nil {
+		s.Logger.Errorf("request.Validate() failed with %s\n", err)
+		return nil, err
+	}
+
+	if _, err := s.AccountRepository.FindByID(ctx, account); err != nil {
+		s.Logger.Errorf("s.AccountRepository.FindByID failed with %s\n", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	cycle := &entity.BillingCycle{
+		Account:    account,
+		ClosingDay: request.ClosingDay,
+		DueDays:    request.DueDays,
+		UpdatedAt:  now,
+	}
+
+	cycle.NextClosingAt = cycle.Next(now)
+	if err := s.BillingCycleRepository.Save(ctx, cycle); err != nil {
+		s.Logger.Errorf("s.BillingCycleRepository.Save failed with %s\n", err)
+		return nil, err
+	}
+
+	return cycle, nil
+}
+
+// Close closes a batch of billing cycles that reached their closing date, each one in its
+// own unit of work so a failure does not roll back the others. It returns how many
+// statements were generated.
+func (s *Statement) Close(ctx context.Context) (int, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	accounts, err := s.BillingCycleRepository.FindDue(ctx, time.Now(), StatementCloseBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, account := range accounts {
+		err := s.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+			cycle, err := s.BillingCycleRepository.FindByAccountForUpdate(ctx, account)
+			if err != nil {
+				return err
+			}
+
+			if !cycle.Due(time.Now()) {
+				return nil
+			}
+
+			if err := s.close(ctx, cycle); err != nil {
+				return err
+			}
+
+			closed++
+			return nil
+		})
+
+		if err != nil {
+			s.Logger.Errorf("account %d statement closing failed with %s\n", account, err)
+		}
+	}
+
+	return closed, nil
+}
+
+// close snapshots the transactions booked since the previous closing into a statement and
+// moves the cycle to the next closing date.
+func (s *Statement) close(ctx context.Context, cycle *entity.BillingCycle) error {
+	closing := cycle.NextClosingAt
+	statement := entity.Statement{
+		Account:     cycle.Account,
+		Period:      closing.Format(entity.StatementPeriodLayout),
+		PeriodStart: closing.AddDate(0, -1, 0),
+		ClosingDate: closing,
+		DueDate:     closing.AddDate(0, 0, cycle.DueDays),
+		CreatedAt:   time.Now(),
+	}
+
+	previous, err := s.StatementRepository.FindLast(ctx, cycle.Account)
+	if err != nil && !xerrors.Is(err, repository.ErrStatementNotFound) {
+		s.Logger.Errorf("s.StatementRepository.FindLast failed with %s\n", err)
+		return err
+	}
+
+	if previous != nil {
+		statement.PeriodStart = previous.ClosingDate
+		statement.OpeningBalance = previous.ClosingBalance
+	}
+
+	transactions, err := s.TransactionRepository.FindByAccountBetween(ctx, cycle.Account, statement.PeriodStart, closing)
+	if err != nil {
+		s.Logger.Errorf("s.TransactionRepository.FindByAccountBetween failed with %s\n", err)
+		return err
+	}
+
+	fees := make(map[uint]bool)
+	for _, transaction := range transactions {
+		fee, ok := fees[transaction.Type]
+		if !ok {
+			operation, err := s.Operation.FindByID(ctx, transaction.Type)
+			if err != nil {
+				s.Logger.Errorf("s.Operation.FindByID failed with %s\n", err)
+				return err
+			}
+
+			fee = operation.Fee
+			fees[transaction.Type] = fee
+		}
+
+		statement.Add(transaction, fee)
+	}
+
+	statement.Close(StatementMinimumPaymentRate)
+	if _, err := s.StatementRepository.Create(ctx, statement); err != nil {
+		s.Logger.Errorf("s.StatementRepository.Create failed with %s\n", err)
+		return err
+	}
+
+	cycle.NextClosingAt = cycle.Next(closing)
+	cycle.UpdatedAt = time.Now()
+	return s.BillingCycleRepository.Save(ctx, cycle)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/service/statement.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	contract "ms/card/pkg/contract"
+	entity "ms/card/pkg/persistence/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockStatements is a mock of Statements interface.
+type MockStatements struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementsMockRecorder
+}
+
+// MockStatementsMockRecorder is the mock recorder for MockStatements.
+type MockStatementsMockRecorder struct {
+	mock *MockStatements
+}
+
+// NewMockStatements creates a new mock instance.
+func NewMockStatements(ctrl *gomock.Controller) *MockStatements {
+	mock := &MockStatements{ctrl: ctrl}
+	mock.recorder = &MockStatementsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatements) EXPECT() *MockStatementsMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockStatements) Close(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockStatementsMockRecorder) Close(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStatements)(nil).Close), ctx)
+}
+
+// Configure mocks base method.
+func (m *MockStatements) Configure(ctx context.Context, account uint, request *contract.BillingCycleRequest) (*entity.BillingCycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Configure", ctx, account, request)
+	ret0, _ := ret[0].(*entity.BillingCycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockStatementsMockRecorder) Configure(ctx, account, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockStatements)(nil).Configure), ctx, account, request)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"ms/card/pkg/common"
+	"ms/card/pkg/contract"
+	"ms/card/pkg/persistence/entity"
+	"ms/card/pkg/persistence/repository"
+	"testing"
+	"time"
+)
+
+func TestServiceStatement_Configure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1}, nil)
+
+	mockBillingCycleRepository := repository.NewMockBillingCycles(ctrl)
+	mockBillingCycleRepository.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure *entity.BillingCycle) error {
+		assert.Equal(t, 10, structure.NextClosingAt.Day())
+		assert.True(t, structure.NextClosingAt.After(structure.UpdatedAt))
+		return nil
+	})
+
+	statementService := NewStatement(StatementOpts{
+		AccountRepository:      mockAccountRepository,
+		BillingCycleRepository: mockBillingCycleRepository,
+	})
+
+	cycle, err := statementService.Configure(context.Background(), 1, &contract.BillingCycleRequest{ClosingDay: 10, DueDays: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), cycle.Account)
+}
+
+func TestServiceStatement_Configure_Account_NotFound_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(nil, repository.ErrAccountCreateNotFound)
+
+	statementService := NewStatement(StatementOpts{
+		Logger:            mockLogger,
+		AccountRepository: mockAccountRepository,
+	})
+
+	cycle, err := statementService.Configure(context.Background(), 1, &contract.BillingCycleRequest{ClosingDay: 10, DueDays: 7})
+	assert.Nil(t, cycle)
+	assert.EqualError(t, err, repository.ErrAccountCreateNotFound.Error())
+}
+
+func TestServiceStatement_Close(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	closing := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+	previous := time.Date(2022, time.February, 10, 0, 0, 0, 0, time.UTC)
+
+	mockBillingCycleRepository := repository.NewMockBillingCycles(ctrl)
+	mockBillingCycleRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), StatementCloseBatchSize).Return([]uint{1, 2}, nil)
+	mockBillingCycleRepository.EXPECT().FindByAccountForUpdate(gomock.Any(), uint(1)).Return(&entity.BillingCycle{
+		Account:       1,
+		ClosingDay:    10,
+		DueDays:       7,
+		NextClosingAt: closing,
+	}, nil)
+	mockBillingCycleRepository.EXPECT().FindByAccountForUpdate(gomock.Any(), uint(2)).Return(nil, repository.ErrBillingCycleFind)
+	mockBillingCycleRepository.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure *entity.BillingCycle) error {
+		assert.Equal(t, time.Date(2022, time.April, 10, 0, 0, 0, 0, time.UTC), structure.NextClosingAt)
+		return nil
+	})
+
+	mockStatementRepository := repository.NewMockStatements(ctrl)
+	mockStatementRepository.EXPECT().FindLast(gomock.Any(), uint(1)).Return(&entity.Statement{
+		ClosingDate:    previous,
+		ClosingBalance: 500,
+	}, nil)
+	mockStatementRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Statement) (*entity.Statement, error) {
+		assert.Equal(t, "2022-03", structure.Period)
+		assert.Equal(t, previous, structure.PeriodStart)
+		assert.Equal(t, closing.AddDate(0, 0, 7), structure.DueDate)
+		assert.Equal(t, int64(500), structure.OpeningBalance)
+		assert.Equal(t, int64(1000), structure.Purchases)
+		assert.Equal(t, int64(50), structure.Fees)
+		assert.Equal(t, int64(300), structure.Payments)
+		assert.Equal(t, int64(1250), structure.ClosingBalance)
+		assert.Len(t, structure.Items, 4)
+		structure.ID = 1
+		return &structure, nil
+	})
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByAccountBetween(gomock.Any(), uint(1), previous, closing).Return([]*entity.Transaction{
+		{ID: 1, Account: 1, Type: 1, Amount: -600},
+		{ID: 2, Account: 1, Type: 1, Amount: -400},
+		{ID: 3, Account: 1, Type: 5, Amount: -50},
+		{ID: 4, Account: 1, Type: 4, Amount: 300},
+	}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(5)).Return(&entity.Operation{ID: 5, Debit: true, Fee: true}, nil)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(4)).Return(&entity.Operation{ID: 4}, nil)
+
+	statementService := NewStatement(StatementOpts{
+		Logger:                 mockLogger,
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		Operation:              mockOperationRepository,
+		TransactionRepository:  mockTransactionRepository,
+		BillingCycleRepository: mockBillingCycleRepository,
+		StatementRepository:    mockStatementRepository,
+	})
+
+	closed, err := statementService.Close(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, closed)
+}
+
+func TestServiceStatement_Close_First_Statement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	closing := time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	mockBillingCycleRepository := repository.NewMockBillingCycles(ctrl)
+	mockBillingCycleRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), StatementCloseBatchSize).Return([]uint{1}, nil)
+	mockBillingCycleRepository.EXPECT().FindByAccountForUpdate(gomock.Any(), uint(1)).Return(&entity.BillingCycle{
+		Account:       1,
+		ClosingDay:    10,
+		DueDays:       7,
+		NextClosingAt: closing,
+	}, nil)
+	mockBillingCycleRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	mockStatementRepository := repository.NewMockStatements(ctrl)
+	mockStatementRepository.EXPECT().FindLast(gomock.Any(), uint(1)).Return(nil, repository.ErrStatementNotFound)
+	mockStatementRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Statement) (*entity.Statement, error) {
+		assert.Equal(t, closing.AddDate(0, -1, 0), structure.PeriodStart)
+		assert.Equal(t, int64(0), structure.ClosingBalance)
+		return &structure, nil
+	})
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByAccountBetween(gomock.Any(), uint(1), closing.AddDate(0, -1, 0), closing).Return([]*entity.Transaction{}, nil)
+
+	statementService := NewStatement(StatementOpts{
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		TransactionRepository:  mockTransactionRepository,
+		BillingCycleRepository: mockBillingCycleRepository,
+		StatementRepository:    mockStatementRepository,
+	})
+
+	closed, err := statementService.Close(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, closed)
+}
@@ -2,25 +2,47 @@ package service
 
 import (
 	"golang.org/x/net/context"
+	"golang.org/x/xerrors"
+	"ms/card/pkg/auth"
 	"ms/card/pkg/common"
 	"ms/card/pkg/contract"
+	"ms/card/pkg/domain"
+	"ms/card/pkg/fx"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/risk"
 	"ms/card/pkg/telemetry/jaeger"
 	"time"
 )
 
+var (
+	ErrIdempotencyKeyMismatch = domain.Conflict("idempotency_key_mismatch", "idempotency key already used with a different payload")
+	ErrReversalNotDebit       = domain.Unprocessable("reversal_not_debit", "only debit transactions can be reversed")
+	ErrReversalExceeded       = domain.Unprocessable("reversal_exceeded", "reversal amount exceeds the original transaction amount")
+	ErrReversalPlanPartial    = domain.Unprocessable("reversal_installments_partial", "purchases in installments can only be reversed in full")
+)
+
 type (
 	Transactions interface {
 		Create(ctx context.Context, request *contract.TransactionRequest) (*entity.Transaction, error)
+		Reverse(ctx context.Context, id uint, request *contract.ReversalRequest) (*entity.Transaction, error)
 	}
 
 	TransactionOpts struct {
-		Logger                common.Logger
-		AccountService        Accounts
-		TransactionRepository repository.Transactions
-		AccountRepository     repository.Accounts
-		Operation             repository.Operations
+		Logger                 common.Logger
+		UnitOfWork             repository.UnitOfWork
+		AccountService         Accounts
+		TransactionRepository  repository.Transactions
+		AccountRepository      repository.Accounts
+		Operation              repository.Operations
+		IdempotencyKey         repository.IdempotencyKeys
+		IdempotencyRetention   time.Duration
+		InstallmentService     Installments
+		Converter              fx.Converters
+		CardService            Cards
+		SpendingControlService SpendingControls
+		RiskService            Risks
+		AuthorizationService   Authorizations
 	}
 
 	Transaction struct {
@@ -36,48 +58,372 @@ func (t *Transaction) Create(ctx context.Context, request *contract.TransactionR
 	ctx, span := jaeger.Span(ctx)
 	defer span.End()
 
+	// invalid requests are attempts too, they keep the identifiers only as the free form fields
+	// may not fit their columns
+	authorization := entity.Authorization{
+		Account:   request.Account,
+		Type:      request.Operation,
+		Amount:    request.Amount,
+		CreatedBy: auth.Client(ctx),
+	}
+
+	if request.Card != 0 {
+		authorization.Card = &request.Card
+	}
+
 	if err := request.Validate(); err != nil {
 		t.Logger.Errorf("request.Validate() failed with %s\n", err)
+		if err := t.AuthorizationService.Record(ctx, authorization, err); err != nil {
+			t.Logger.Errorf("t.AuthorizationService.Record failed with %s\n", err)
+		}
+
 		return nil, err
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel()
 
-	account, err := t.AccountRepository.FindByID(ctx, request.Account)
+	var transaction *entity.Transaction
+	var decision *entity.RiskDecision
+	var replayed bool
+	err := t.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		account, err := t.AccountRepository.FindByIDForUpdate(ctx, request.Account)
+		if err != nil {
+			t.Logger.Errorf("t.AccountRepository.FindByIDForUpdate failed with %s\n", err)
+			return err
+		}
+
+		if request.IdempotencyKey != "" {
+			replay, err := t.replay(ctx, request)
+			if err != nil {
+				return err
+			}
+
+			if replay != nil {
+				transaction, replayed = replay, true
+				return nil
+			}
+		}
+
+		if request.Card != 0 {
+			if _, err := t.CardService.Usable(ctx, request.Account, request.Card); err != nil {
+				t.Logger.Errorf("t.CardService.Usable failed with %s\n", err)
+				return err
+			}
+		}
+
+		operation, err := t.Operation.FindByID(ctx, request.Operation)
+		if err != nil {
+			t.Logger.Errorf("t.OperationType.FindByID failed with %s\n", err)
+			return err
+		}
+
+		if err := usable(account, operation.Debit); err != nil {
+			return err
+		}
+
+		if request.Installments > 1 && !operation.Debit {
+			return ErrInstallmentsCreditOperation
+		}
+
+		foreign := request.Currency != "" && request.Currency != account.Currency
+		if request.Installments > 1 && foreign {
+			return ErrInstallmentsForeignCurrency
+		}
+
+		amount := common.Abs(request.Amount)
+		var conversion *fx.Conversion
+		if foreign {
+			conversion, err = t.Converter.Convert(ctx, amount, request.Currency, account.Currency, operation.Debit)
+			if err != nil {
+				t.Logger.Errorf("t.Converter.Convert failed with %s\n", err)
+				return err
+			}
+
+			amount = conversion.Amount
+		}
+
+		if operation.Debit {
+			if err := t.SpendingControlService.Evaluate(ctx, request, amount); err != nil {
+				t.Logger.Errorf("t.SpendingControlService.Evaluate failed with %s\n", err)
+				return err
+			}
+
+			decision, err = t.RiskService.Assess(ctx, request, account, amount)
+			if err != nil {
+				t.Logger.Errorf("t.RiskService.Assess failed with %s\n", err)
+				return err
+			}
+
+			if decision.Outcome == risk.OutcomeDecline {
+				return ErrDeclineRisk
+			}
+		}
+
+		if err := t.AccountService.UpdateLimit(ctx, account, amount, operation.Debit); err != nil {
+			t.Logger.Errorf("t.AccountService.UpdateLimit failed with %s\n", err)
+			return err
+		}
+
+		if request.Installments > 1 {
+			transaction, err = t.InstallmentService.Purchase(ctx, request)
+			if err != nil {
+				t.Logger.Errorf("t.InstallmentService.Purchase failed with %s\n", err)
+				return err
+			}
+		} else {
+			structure := entity.Transaction{
+				Account:   request.Account,
+				Type:      request.Operation,
+				Amount:    amount,
+				CreatedBy: auth.Client(ctx),
+				CreatedAt: time.Now(),
+			}
+
+			if request.Card != 0 {
+				structure.Card = &request.Card
+			}
+
+			structure.Merchant = merchant(request)
+
+			if foreign {
+				structure.Currency = request.Currency
+				structure.OriginalAmount = conversion.Original
+				structure.Rate = conversion.Rate
+				structure.Tax = conversion.Tax
+			}
+
+			if operation.Debit {
+				structure.Amount = -structure.Amount
+				structure.OriginalAmount = -structure.OriginalAmount
+			}
+
+			transaction, err = t.TransactionRepository.Create(ctx, structure)
+
+			if err != nil {
+				t.Logger.Errorf("t.TransactionRepository.Create failed with %s\n", err)
+				return err
+			}
+		}
+
+		if request.IdempotencyKey != "" {
+			if _, err := t.IdempotencyKey.Create(ctx, entity.IdempotencyKey{
+				Client:      auth.Client(ctx),
+				Key:         request.IdempotencyKey,
+				Hash:        request.Hash(),
+				Transaction: transaction.ID,
+				CreatedAt:   transaction.CreatedAt,
+				ExpiresAt:   transaction.CreatedAt.Add(t.IdempotencyRetention),
+			}); err != nil {
+				t.Logger.Errorf("t.IdempotencyKey.Create failed with %s\n", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if decision != nil {
+		if err == nil {
+			decision.Transaction = &transaction.ID
+		}
+
+		if err := t.RiskService.Record(ctx, decision); err != nil {
+			t.Logger.Errorf("t.RiskService.Record failed with %s\n", err)
+		}
+	}
+
+	if !replayed {
+		authorization.Currency = request.Currency
+		authorization.Merchant = merchant(request)
+
+		if err == nil {
+			authorization.Transaction = &transaction.ID
+		}
+
+		if err := t.AuthorizationService.Record(ctx, authorization, err); err != nil {
+			t.Logger.Errorf("t.AuthorizationService.Record failed with %s\n", err)
+		}
+	}
+
 	if err != nil {
-		t.Logger.Errorf("t.AccountRepository.FindByID failed with %s\n", err)
 		return nil, err
 	}
 
-	operation, err := t.Operation.FindByID(ctx, request.Operation)
+	return transaction, nil
+}
+
+// Reverse refunds part or all of a debit transaction, restoring the account limit and booking
+// a credit under the reversal operation linked to the original through parent_id.
+func (t *Transaction) Reverse(ctx context.Context, id uint, request *contract.ReversalRequest) (*entity.Transaction, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	if err := request.Validate(); err != nil {
+		t.Logger.Errorf("request.Validate() failed with %s\n", err)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var reversal *entity.Transaction
+	err := t.UnitOfWork.Transaction(ctx, func(ctx context.Context) error {
+		original, err := t.TransactionRepository.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			t.Logger.Errorf("t.TransactionRepository.FindByIDForUpdate failed with %s\n", err)
+			return err
+		}
+
+		if original.Amount >= 0 {
+			return ErrReversalNotDebit
+		}
+
+		if original.Plan != nil {
+			reversal, err = t.reversePlan(ctx, original, request.Amount)
+			return err
+		}
+
+		reversed, err := t.TransactionRepository.SumByParent(ctx, original.ID)
+		if err != nil {
+			t.Logger.Errorf("t.TransactionRepository.SumByParent failed with %s\n", err)
+			return err
+		}
+
+		remaining := common.Abs(original.Amount) - reversed
+		amount := request.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+
+		if amount == 0 || amount > remaining {
+			return ErrReversalExceeded
+		}
+
+		account, err := t.AccountRepository.FindByIDForUpdate(ctx, original.Account)
+		if err != nil {
+			t.Logger.Errorf("t.AccountRepository.FindByIDForUpdate failed with %s\n", err)
+			return err
+		}
+
+		if err := t.AccountService.ReleaseLimit(ctx, account, amount); err != nil {
+			t.Logger.Errorf("t.AccountService.ReleaseLimit failed with %s\n", err)
+			return err
+		}
+
+		reversal, err = t.credit(ctx, original, amount)
+		return err
+	})
+
 	if err != nil {
-		t.Logger.Errorf("t.OperationType.FindByID failed with %s\n", err)
 		return nil, err
 	}
 
-	if err := t.AccountService.UpdateLimit(ctx, account, request.Amount, operation.Debit); err != nil {
-		t.Logger.Errorf("t.AccountService.UpdateLimit failed with %s\n", err)
+	return reversal, nil
+}
+
+// reversePlan reverses a purchase in installments as a whole, from any of its installments:
+// the installments not posted yet are cancelled, the posted ones are credited back and the
+// limit reserved at purchase is released for both. Only the full amount left is accepted.
+func (t *Transaction) reversePlan(ctx context.Context, original *entity.Transaction, amount int64) (*entity.Transaction, error) {
+	reversed, err := t.TransactionRepository.SumByPlan(ctx, *original.Plan)
+	if err != nil {
+		t.Logger.Errorf("t.TransactionRepository.SumByPlan failed with %s\n", err)
 		return nil, err
 	}
 
-	amount := common.Abs(request.Amount)
-	if operation.Debit {
-		amount = -amount
+	posted, cancelled, err := t.InstallmentService.Cancel(ctx, *original.Plan)
+	if err != nil {
+		t.Logger.Errorf("t.InstallmentService.Cancel failed with %s\n", err)
+		return nil, err
 	}
 
-	transaction, err := t.TransactionRepository.Create(ctx, entity.Transaction{
-		Account:   request.Account,
-		Type:      request.Operation,
+	refund := posted - reversed
+	if refund <= 0 {
+		return nil, ErrReversalExceeded
+	}
+
+	if amount != 0 && amount != refund+cancelled {
+		return nil, ErrReversalPlanPartial
+	}
+
+	account, err := t.AccountRepository.FindByIDForUpdate(ctx, original.Account)
+	if err != nil {
+		t.Logger.Errorf("t.AccountRepository.FindByIDForUpdate failed with %s\n", err)
+		return nil, err
+	}
+
+	if err := t.AccountService.ReleaseLimit(ctx, account, refund+cancelled); err != nil {
+		t.Logger.Errorf("t.AccountService.ReleaseLimit failed with %s\n", err)
+		return nil, err
+	}
+
+	return t.credit(ctx, original, refund)
+}
+
+// credit books the reversal of the original, a credit under the reversal operation linked to
+// the original through parent_id.
+func (t *Transaction) credit(ctx context.Context, original *entity.Transaction, amount int64) (*entity.Transaction, error) {
+	operation, err := t.Operation.FindReversal(ctx)
+	if err != nil {
+		t.Logger.Errorf("t.Operation.FindReversal failed with %s\n", err)
+		return nil, err
+	}
+
+	reversal, err := t.TransactionRepository.Create(ctx, entity.Transaction{
+		Account:   original.Account,
+		Type:      operation.ID,
 		Amount:    amount,
+		Parent:    &original.ID,
+		CreatedBy: auth.Client(ctx),
 		CreatedAt: time.Now(),
 	})
 
 	if err != nil {
-		_ = t.AccountService.UpdateLimit(ctx, account, request.Amount, false)
 		t.Logger.Errorf("t.TransactionRepository.Create failed with %s\n", err)
 		return nil, err
 	}
 
-	return transaction, nil
+	return reversal, nil
+}
+
+// replay returns the transaction the client previously booked under the request idempotency
+// key, or nil when the key is unknown or expired and the request must be processed.
+func (t *Transaction) replay(ctx context.Context, request *contract.TransactionRequest) (*entity.Transaction, error) {
+	ctx, span := jaeger.Span(ctx)
+	defer span.End()
+
+	stored, err := t.IdempotencyKey.FindByKey(ctx, auth.Client(ctx), request.IdempotencyKey)
+	if xerrors.Is(err, repository.ErrIdempotencyKeyNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		t.Logger.Errorf("t.IdempotencyKey.FindByKey failed with %s\n", err)
+		return nil, err
+	}
+
+	if stored.Expired(time.Now()) {
+		return nil, t.IdempotencyKey.Delete(ctx, stored)
+	}
+
+	if stored.Hash != request.Hash() {
+		t.Logger.Errorf("idempotency key %s reused with a different payload\n", request.IdempotencyKey)
+		return nil, ErrIdempotencyKeyMismatch
+	}
+
+	return t.TransactionRepository.FindByID(ctx, stored.Transaction)
+}
+
+// merchant copies the merchant context of the request, empty when the request carries none.
+func merchant(request *contract.TransactionRequest) entity.Merchant {
+	return entity.Merchant{
+		MerchantID:      request.MerchantID,
+		MerchantName:    request.MerchantName,
+		MCC:             request.MCC,
+		MerchantCity:    request.MerchantCity,
+		MerchantCountry: request.MerchantCountry,
+		TerminalID:      request.TerminalID,
+		EntryMode:       request.EntryMode,
+	}
 }
@@ -6,9 +6,13 @@ import (
 	"golang.org/x/net/context"
 	"ms/card/pkg/common"
 	"ms/card/pkg/contract"
+	"ms/card/pkg/fx"
 	"ms/card/pkg/persistence/entity"
 	"ms/card/pkg/persistence/repository"
+	"ms/card/pkg/risk"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestServiceTransaction_Create(t *testing.T) {
@@ -16,12 +20,18 @@ func TestServiceTransaction_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAccountEntity := &entity.Account{
-		ID:       1,
-		Document: "56077053074",
-		Limit:    2000,
+		ID:             1,
+		Document:       "56077053074",
+		CreditLimit:    2000,
+		AvailableLimit: 2000,
 	}
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+
 	mockAccountRepository := repository.NewMockAccounts(ctrl)
-	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
 
 	mockOperationRepository := repository.NewMockOperations(ctrl)
 	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{
@@ -41,20 +51,451 @@ func TestServiceTransaction_Create(t *testing.T) {
 	accountServiceMock := NewMockAccounts(ctrl)
 	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1000), true).Return(nil)
 
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		AccountService:         accountServiceMock,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWork,
+		AccountRepository:      mockAccountRepository,
+		TransactionRepository:  mockTransactionRepository,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            mockRiskApprove(ctrl),
+		AuthorizationService:   mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:   1,
+		Operation: 1,
+		Amount:    1000,
+	})
+	assert.NotNil(t, transaction)
+	assert.Nil(t, err)
+}
+
+func TestServiceTransaction_Create_Account_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cases := []struct {
+		status   string
+		expected string
+	}{
+		{status: entity.AccountStatusBlocked, expected: DeclineCodeAccountBlocked},
+		{status: entity.AccountStatusClosed, expected: DeclineCodeAccountClosed},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.status, func(t *testing.T) {
+			mockAccountRepository := repository.NewMockAccounts(ctrl)
+			mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000, Status: tt.status}, nil)
+
+			mockOperationRepository := repository.NewMockOperations(ctrl)
+			mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+			transactionService := NewTransaction(TransactionOpts{
+				Logger:               common.NewMockLogger(ctrl),
+				Operation:            mockOperationRepository,
+				UnitOfWork:           mockUnitOfWorkPassthrough(ctrl),
+				AccountRepository:    mockAccountRepository,
+				AuthorizationService: mockAuthorizationDeclined(t, ctrl, tt.expected),
+			})
+
+			transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+				Account:   1,
+				Operation: 1,
+				Amount:    1000,
+			})
+			assert.Nil(t, transaction)
+			assert.Equal(t, tt.expected, DeclineCode(err))
+		})
+	}
+}
+
+func TestServiceTransaction_Create_ForeignCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 10000, AvailableLimit: 10000, Currency: "BRL"}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{
+		ID:          1,
+		Description: "COMPRA A VISTA",
+		Debit:       true,
+	}, nil)
+
+	mockConverter := fx.NewMockConverters(ctrl)
+	mockConverter.EXPECT().Convert(gomock.Any(), int64(1000), "USD", "BRL", true).Return(&fx.Conversion{
+		Amount:   5668,
+		Original: 1000,
+		Rate:     "5.32833600",
+		Tax:      340,
+	}, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(5668), true).Return(nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, int64(-5668), structure.Amount)
+		assert.Equal(t, "USD", structure.Currency)
+		assert.Equal(t, int64(-1000), structure.OriginalAmount)
+		assert.Equal(t, "5.32833600", structure.Rate)
+		assert.Equal(t, int64(340), structure.Tax)
+		structure.ID = 1
+		return &structure, nil
+	})
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		AccountService:         accountServiceMock,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:      mockAccountRepository,
+		TransactionRepository:  mockTransactionRepository,
+		Converter:              mockConverter,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            mockRiskApprove(ctrl),
+		AuthorizationService:   mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:   1,
+		Operation: 1,
+		Amount:    1000,
+		Currency:  "USD",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), transaction.ID)
+}
+
+func TestServiceTransaction_Create_ForeignCurrency_Credit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 10000, AvailableLimit: 4000, Currency: "BRL"}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(4)).Return(&entity.Operation{ID: 4, Debit: false}, nil)
+
+	mockConverter := fx.NewMockConverters(ctrl)
+	mockConverter.EXPECT().Convert(gomock.Any(), int64(1000), "USD", "BRL", false).Return(&fx.Conversion{
+		Amount:   5123,
+		Original: 1000,
+		Rate:     "5.12340000",
+	}, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(5123), false).Return(nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, int64(5123), structure.Amount)
+		assert.Equal(t, int64(1000), structure.OriginalAmount)
+		assert.Equal(t, int64(0), structure.Tax)
+		return &structure, nil
+	})
+
 	transactionService := NewTransaction(TransactionOpts{
 		AccountService:        accountServiceMock,
 		Operation:             mockOperationRepository,
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
 		AccountRepository:     mockAccountRepository,
 		TransactionRepository: mockTransactionRepository,
+		Converter:             mockConverter,
+		AuthorizationService:  mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:   1,
+		Operation: 4,
+		Amount:    1000,
+		Currency:  "USD",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5123), transaction.Amount)
+}
+
+func TestServiceTransaction_Create_ForeignCurrency_RateNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf("t.Converter.Convert failed with %s\n", fx.ErrRateNotFound)
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 10000, AvailableLimit: 10000, Currency: "BRL"}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+	mockConverter := fx.NewMockConverters(ctrl)
+	mockConverter.EXPECT().Convert(gomock.Any(), int64(1000), "JPY", "BRL", true).Return(nil, fx.ErrRateNotFound)
+
+	transactionService := NewTransaction(TransactionOpts{
+		Logger:               mockLogger,
+		Operation:            mockOperationRepository,
+		UnitOfWork:           mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:    mockAccountRepository,
+		Converter:            mockConverter,
+		AuthorizationService: mockAuthorizationRecorded(ctrl),
 	})
 
 	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
 		Account:   1,
 		Operation: 1,
 		Amount:    1000,
+		Currency:  "JPY",
 	})
-	assert.NotNil(t, transaction)
-	assert.Nil(t, err)
+	assert.Nil(t, transaction)
+	assert.ErrorIs(t, err, fx.ErrRateNotFound)
+}
+
+func TestServiceTransaction_Create_ForeignCurrency_Installments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 10000, AvailableLimit: 10000, Currency: "BRL"}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(2)).Return(&entity.Operation{ID: 2, Debit: true}, nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		Operation:            mockOperationRepository,
+		UnitOfWork:           mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:    mockAccountRepository,
+		AuthorizationService: mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:      1,
+		Operation:    2,
+		Amount:       1200,
+		Installments: 3,
+		Currency:     "USD",
+	})
+	assert.Nil(t, transaction)
+	assert.ErrorIs(t, err, ErrInstallmentsForeignCurrency)
+}
+
+func TestServiceTransaction_Create_Card(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	mockCardService := NewMockCards(ctrl)
+	mockCardService.EXPECT().Usable(gomock.Any(), uint(1), uint(3)).Return(&entity.Card{ID: 3, Account: 1}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1000), true).Return(nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, uint(3), *structure.Card)
+		assert.Equal(t, entity.Merchant{MerchantName: "SUPER MARKET", MCC: "5411", MerchantCountry: "BR"}, structure.Merchant)
+		return &structure, nil
+	})
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		AccountService:         accountServiceMock,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:      mockAccountRepository,
+		TransactionRepository:  mockTransactionRepository,
+		CardService:            mockCardService,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            mockRiskApprove(ctrl),
+		AuthorizationService:   mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:         1,
+		Card:            3,
+		Operation:       1,
+		Amount:          1000,
+		MerchantName:    "SUPER MARKET",
+		MCC:             "5411",
+		MerchantCountry: "BR",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1000), transaction.Amount)
+}
+
+func TestServiceTransaction_Create_SpendingControl_Declined(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf("t.SpendingControlService.Evaluate failed with %s\n", ErrDeclineMCC)
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+	request := &contract.TransactionRequest{
+		Account:   1,
+		Operation: 1,
+		Amount:    1000,
+		MCC:       "7995",
+	}
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), request, int64(1000)).Return(ErrDeclineMCC)
+
+	transactionService := NewTransaction(TransactionOpts{
+		Logger:                 mockLogger,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:      mockAccountRepository,
+		SpendingControlService: spendingControlServiceMock,
+		AuthorizationService:   mockAuthorizationDeclined(t, ctrl, DeclineCodeMCC),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), request)
+	assert.Nil(t, transaction)
+
+	var decline *Decline
+	if assert.ErrorAs(t, err, &decline) {
+		assert.Equal(t, DeclineCodeMCC, decline.Code)
+	}
+}
+
+func TestServiceTransaction_Create_Card_Blocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf("t.CardService.Usable failed with %s\n", ErrCardNotActive)
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}, nil)
+
+	mockCardService := NewMockCards(ctrl)
+	mockCardService.EXPECT().Usable(gomock.Any(), uint(1), uint(3)).Return(nil, ErrCardNotActive)
+
+	transactionService := NewTransaction(TransactionOpts{
+		Logger:               mockLogger,
+		UnitOfWork:           mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:    mockAccountRepository,
+		CardService:          mockCardService,
+		AuthorizationService: mockAuthorizationDeclined(t, ctrl, DeclineCodeCardNotActive),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:   1,
+		Card:      3,
+		Operation: 1,
+		Amount:    1000,
+	})
+	assert.Nil(t, transaction)
+	assert.ErrorIs(t, err, ErrCardNotActive)
+}
+
+func TestServiceTransaction_Create_Installments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(2)).Return(&entity.Operation{
+		ID:          2,
+		Description: "COMPRA PARCELADA",
+		Debit:       true,
+	}, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1200), true).Return(nil)
+
+	request := &contract.TransactionRequest{
+		Account:      1,
+		Operation:    2,
+		Amount:       1200,
+		Installments: 3,
+	}
+
+	plan := uint(1)
+	installmentServiceMock := NewMockInstallments(ctrl)
+	installmentServiceMock.EXPECT().Purchase(gomock.Any(), request).Return(&entity.Transaction{
+		ID:      1,
+		Account: 1,
+		Type:    2,
+		Amount:  -400,
+		Plan:    &plan,
+	}, nil)
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		AccountService:         accountServiceMock,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:      mockAccountRepository,
+		InstallmentService:     installmentServiceMock,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            mockRiskApprove(ctrl),
+		AuthorizationService:   mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-400), transaction.Amount)
+	assert.Equal(t, plan, *transaction.Plan)
+}
+
+func TestServiceTransaction_Create_Installments_Credit_Operation_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(4)).Return(&entity.Operation{
+		ID:          4,
+		Description: "PAGAMENTO",
+		Debit:       false,
+	}, nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		Operation:            mockOperationRepository,
+		UnitOfWork:           mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:    mockAccountRepository,
+		AuthorizationService: mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:      1,
+		Operation:    4,
+		Amount:       1200,
+		Installments: 3,
+	})
+	assert.Nil(t, transaction)
+	assert.EqualError(t, err, ErrInstallmentsCreditOperation.Error())
 }
 
 func TestServiceTransaction_Create_Limit_Error(t *testing.T) {
@@ -65,12 +506,18 @@ func TestServiceTransaction_Create_Limit_Error(t *testing.T) {
 	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
 
 	mockAccountEntity := &entity.Account{
-		ID:       1,
-		Document: "56077053074",
-		Limit:    100,
+		ID:             1,
+		Document:       "56077053074",
+		CreditLimit:    100,
+		AvailableLimit: 100,
 	}
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+
 	mockAccountRepository := repository.NewMockAccounts(ctrl)
-	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
 
 	mockOperationRepository := repository.NewMockOperations(ctrl)
 	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{
@@ -82,11 +529,18 @@ func TestServiceTransaction_Create_Limit_Error(t *testing.T) {
 	accountServiceMock := NewMockAccounts(ctrl)
 	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1000), true).Return(ErrLimitExceeded)
 
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
 	transactionService := NewTransaction(TransactionOpts{
-		Logger:            mockLogger,
-		AccountService:    accountServiceMock,
-		Operation:         mockOperationRepository,
-		AccountRepository: mockAccountRepository,
+		Logger:                 mockLogger,
+		AccountService:         accountServiceMock,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWork,
+		AccountRepository:      mockAccountRepository,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            mockRiskApprove(ctrl),
+		AuthorizationService:   mockAuthorizationDeclined(t, ctrl, DeclineCodeLimit),
 	})
 
 	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
@@ -105,13 +559,25 @@ func TestServiceTransaction_Create_Validate_Error(t *testing.T) {
 	mockLogger := common.NewMockLogger(ctrl)
 	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
 
+	mockAuthorizationService := NewMockAuthorizations(ctrl)
+	mockAuthorizationService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, authorization entity.Authorization, cause error) error {
+		assert.Equal(t, uint(1), authorization.Account)
+		assert.Empty(t, authorization.MerchantName)
+		assert.Equal(t, DeclineCodeInvalid, DeclineCode(cause))
+		return nil
+	})
+
 	transactionService := NewTransaction(TransactionOpts{
-		Logger: mockLogger,
+		Logger:               mockLogger,
+		AuthorizationService: mockAuthorizationService,
 	})
 
-	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{})
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:      1,
+		MerchantName: strings.Repeat("a", 101),
+	})
 	assert.Nil(t, transaction)
-	assert.EqualError(t, err, "account_id: cannot be blank; amount: cannot be blank; operation_id: cannot be blank.")
+	assert.EqualError(t, err, "amount: cannot be blank; merchant_name: the length must be no more than 100; operation_id: cannot be blank.")
 }
 
 func TestServiceTransaction_Create_Persist_Error(t *testing.T) {
@@ -122,12 +588,18 @@ func TestServiceTransaction_Create_Persist_Error(t *testing.T) {
 	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
 
 	mockAccountEntity := &entity.Account{
-		ID:       1,
-		Document: "56077053074",
-		Limit:    2000,
+		ID:             1,
+		Document:       "56077053074",
+		CreditLimit:    2000,
+		AvailableLimit: 2000,
 	}
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+
 	mockAccountRepository := repository.NewMockAccounts(ctrl)
-	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
 
 	mockOperationRepository := repository.NewMockOperations(ctrl)
 	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{
@@ -138,17 +610,23 @@ func TestServiceTransaction_Create_Persist_Error(t *testing.T) {
 
 	accountServiceMock := NewMockAccounts(ctrl)
 	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1000), true).Return(nil)
-	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1000), false).Return(nil)
 
 	mockTransactionRepository := repository.NewMockTransactions(ctrl)
 	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrTransactionCreate)
 
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
 	transactionService := NewTransaction(TransactionOpts{
-		Logger:                mockLogger,
-		AccountService:        accountServiceMock,
-		Operation:             mockOperationRepository,
-		AccountRepository:     mockAccountRepository,
-		TransactionRepository: mockTransactionRepository,
+		Logger:                 mockLogger,
+		AccountService:         accountServiceMock,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWork,
+		AccountRepository:      mockAccountRepository,
+		TransactionRepository:  mockTransactionRepository,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            mockRiskApprove(ctrl),
+		AuthorizationService:   mockAuthorizationRecorded(ctrl),
 	})
 
 	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
@@ -167,12 +645,19 @@ func TestServiceTransaction_Create_Persist_Account_NotFound_Error(t *testing.T)
 	mockLogger := common.NewMockLogger(ctrl)
 	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
 
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+
 	mockAccountRepository := repository.NewMockAccounts(ctrl)
-	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(nil, repository.ErrAccountCreateNotFound)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(nil, repository.ErrAccountCreateNotFound)
 
 	transactionService := NewTransaction(TransactionOpts{
-		Logger:            mockLogger,
-		AccountRepository: mockAccountRepository,
+		Logger:               mockLogger,
+		UnitOfWork:           mockUnitOfWork,
+		AccountRepository:    mockAccountRepository,
+		AuthorizationService: mockAuthorizationDeclined(t, ctrl, DeclineCodeAccountNotFound),
 	})
 
 	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
@@ -191,8 +676,13 @@ func TestServiceTransaction_Create_Persist_Operation_NotFound_Error(t *testing.T
 	mockLogger := common.NewMockLogger(ctrl)
 	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
 
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+
 	mockAccountRepository := repository.NewMockAccounts(ctrl)
-	mockAccountRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Account{
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{
 		ID:       1,
 		Document: "56077053074",
 	}, nil)
@@ -201,9 +691,11 @@ func TestServiceTransaction_Create_Persist_Operation_NotFound_Error(t *testing.T
 	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(nil, repository.ErrOperationCreateNotFound)
 
 	transactionService := NewTransaction(TransactionOpts{
-		Logger:            mockLogger,
-		Operation:         mockOperationRepository,
-		AccountRepository: mockAccountRepository,
+		Logger:               mockLogger,
+		Operation:            mockOperationRepository,
+		UnitOfWork:           mockUnitOfWork,
+		AccountRepository:    mockAccountRepository,
+		AuthorizationService: mockAuthorizationDeclined(t, ctrl, DeclineCodeOperationNotFound),
 	})
 
 	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
@@ -214,3 +706,412 @@ func TestServiceTransaction_Create_Persist_Operation_NotFound_Error(t *testing.T
 	assert.Nil(t, transaction)
 	assert.EqualError(t, err, repository.ErrOperationCreateNotFound.Error())
 }
+
+func TestServiceTransaction_Create_IdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountEntity := &entity.Account{
+		ID:             1,
+		Document:       "56077053074",
+		CreditLimit:    2000,
+		AvailableLimit: 2000,
+	}
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	mockIdempotencyKeyRepository := repository.NewMockIdempotencyKeys(ctrl)
+	mockIdempotencyKeyRepository.EXPECT().FindByKey(gomock.Any(), "", "key").Return(nil, repository.ErrIdempotencyKeyNotFound)
+	mockIdempotencyKeyRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+		assert.Equal(t, "key", structure.Key)
+		assert.Equal(t, uint(1), structure.Transaction)
+		assert.Equal(t, 24*time.Hour, structure.ExpiresAt.Sub(structure.CreatedAt))
+		return &structure, nil
+	})
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{
+		ID:          1,
+		Description: "COMPRA A VISTA",
+		Debit:       true,
+	}, nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{
+		ID:        1,
+		Account:   1,
+		Type:      1,
+		Amount:    -1000,
+		CreatedAt: time.Now(),
+	}, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1000), true).Return(nil)
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		AccountService:         accountServiceMock,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWork,
+		AccountRepository:      mockAccountRepository,
+		TransactionRepository:  mockTransactionRepository,
+		IdempotencyKey:         mockIdempotencyKeyRepository,
+		IdempotencyRetention:   24 * time.Hour,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            mockRiskApprove(ctrl),
+		AuthorizationService:   mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:        1,
+		Operation:      1,
+		Amount:         1000,
+		IdempotencyKey: "key",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), transaction.ID)
+}
+
+func TestServiceTransaction_Create_IdempotencyKey_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	request := &contract.TransactionRequest{
+		Account:        1,
+		Operation:      1,
+		Amount:         1000,
+		IdempotencyKey: "key",
+	}
+
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 1000, AvailableLimit: 1000}, nil)
+
+	mockIdempotencyKeyRepository := repository.NewMockIdempotencyKeys(ctrl)
+	mockIdempotencyKeyRepository.EXPECT().FindByKey(gomock.Any(), "", "key").Return(&entity.IdempotencyKey{
+		Key:         "key",
+		Hash:        request.Hash(),
+		Transaction: 7,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}, nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByID(gomock.Any(), uint(7)).Return(&entity.Transaction{
+		ID:      7,
+		Account: 1,
+		Type:    1,
+		Amount:  -1000,
+	}, nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		UnitOfWork:            mockUnitOfWork,
+		AccountRepository:     mockAccountRepository,
+		TransactionRepository: mockTransactionRepository,
+		IdempotencyKey:        mockIdempotencyKeyRepository,
+	})
+
+	transaction, err := transactionService.Create(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), transaction.ID)
+}
+
+func TestServiceTransaction_Create_IdempotencyKey_Mismatch_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := common.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+
+	mockUnitOfWork := repository.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 1000, AvailableLimit: 1000}, nil)
+
+	mockIdempotencyKeyRepository := repository.NewMockIdempotencyKeys(ctrl)
+	mockIdempotencyKeyRepository.EXPECT().FindByKey(gomock.Any(), "", "key").Return(&entity.IdempotencyKey{
+		Key:         "key",
+		Hash:        "another payload",
+		Transaction: 7,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}, nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		Logger:               mockLogger,
+		UnitOfWork:           mockUnitOfWork,
+		AccountRepository:    mockAccountRepository,
+		IdempotencyKey:       mockIdempotencyKeyRepository,
+		AuthorizationService: mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:        1,
+		Operation:      1,
+		Amount:         1000,
+		IdempotencyKey: "key",
+	})
+	assert.Nil(t, transaction)
+	assert.EqualError(t, err, ErrIdempotencyKeyMismatch.Error())
+}
+
+func TestServiceTransaction_Reverse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Transaction{
+		ID:      1,
+		Account: 1,
+		Type:    1,
+		Amount:  -1000,
+	}, nil)
+	mockTransactionRepository.EXPECT().SumByParent(gomock.Any(), uint(1)).Return(int64(300), nil)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, int64(700), structure.Amount)
+		assert.Equal(t, uint(6), structure.Type)
+		assert.Equal(t, uint(1), *structure.Parent)
+		structure.ID = 2
+		return &structure, nil
+	})
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindReversal(gomock.Any()).Return(&entity.Operation{ID: 6, Reversal: true}, nil)
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 0, AvailableLimit: 0}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().ReleaseLimit(gomock.Any(), mockAccountEntity, int64(700)).Return(nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		AccountService:        accountServiceMock,
+		AccountRepository:     mockAccountRepository,
+		TransactionRepository: mockTransactionRepository,
+		Operation:             mockOperationRepository,
+	})
+
+	reversal, err := transactionService.Reverse(context.Background(), 1, &contract.ReversalRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), reversal.ID)
+}
+
+func TestServiceTransaction_Reverse_Installments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	plan := uint(5)
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Transaction{
+		ID:      1,
+		Account: 1,
+		Type:    1,
+		Amount:  -334,
+		Plan:    &plan,
+	}, nil)
+	mockTransactionRepository.EXPECT().SumByPlan(gomock.Any(), plan).Return(int64(0), nil)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, structure entity.Transaction) (*entity.Transaction, error) {
+		assert.Equal(t, int64(667), structure.Amount)
+		assert.Equal(t, uint(6), structure.Type)
+		assert.Equal(t, uint(1), *structure.Parent)
+		structure.ID = 2
+		return &structure, nil
+	})
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindReversal(gomock.Any()).Return(&entity.Operation{ID: 6, Reversal: true}, nil)
+
+	mockInstallmentService := NewMockInstallments(ctrl)
+	mockInstallmentService.EXPECT().Cancel(gomock.Any(), plan).Return(int64(667), int64(333), nil)
+
+	mockAccountEntity := &entity.Account{ID: 1}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().ReleaseLimit(gomock.Any(), mockAccountEntity, int64(1000)).Return(nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		AccountService:        accountServiceMock,
+		AccountRepository:     mockAccountRepository,
+		TransactionRepository: mockTransactionRepository,
+		InstallmentService:    mockInstallmentService,
+		Operation:             mockOperationRepository,
+	})
+
+	reversal, err := transactionService.Reverse(context.Background(), 1, &contract.ReversalRequest{Amount: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), reversal.ID)
+}
+
+func TestServiceTransaction_Reverse_Installments_Partial_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	plan := uint(5)
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Transaction{
+		ID:      1,
+		Account: 1,
+		Amount:  -334,
+		Plan:    &plan,
+	}, nil)
+	mockTransactionRepository.EXPECT().SumByPlan(gomock.Any(), plan).Return(int64(0), nil)
+
+	mockInstallmentService := NewMockInstallments(ctrl)
+	mockInstallmentService.EXPECT().Cancel(gomock.Any(), plan).Return(int64(334), int64(666), nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		TransactionRepository: mockTransactionRepository,
+		InstallmentService:    mockInstallmentService,
+	})
+
+	reversal, err := transactionService.Reverse(context.Background(), 1, &contract.ReversalRequest{Amount: 334})
+	assert.Nil(t, reversal)
+	assert.Equal(t, ErrReversalPlanPartial, err)
+}
+
+func TestServiceTransaction_Reverse_Exceeded_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Transaction{
+		ID:      1,
+		Account: 1,
+		Amount:  -1000,
+	}, nil)
+	mockTransactionRepository.EXPECT().SumByParent(gomock.Any(), uint(1)).Return(int64(800), nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		TransactionRepository: mockTransactionRepository,
+	})
+
+	reversal, err := transactionService.Reverse(context.Background(), 1, &contract.ReversalRequest{Amount: 300})
+	assert.Nil(t, reversal)
+	assert.EqualError(t, err, ErrReversalExceeded.Error())
+}
+
+func TestServiceTransaction_Reverse_NotDebit_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(2)).Return(&entity.Transaction{
+		ID:      2,
+		Account: 1,
+		Amount:  700,
+	}, nil)
+
+	transactionService := NewTransaction(TransactionOpts{
+		UnitOfWork:            mockUnitOfWorkPassthrough(ctrl),
+		TransactionRepository: mockTransactionRepository,
+	})
+
+	reversal, err := transactionService.Reverse(context.Background(), 2, &contract.ReversalRequest{})
+	assert.Nil(t, reversal)
+	assert.EqualError(t, err, ErrReversalNotDebit.Error())
+}
+
+func TestServiceTransaction_Create_Risk_Declined(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(&entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	decision := &entity.RiskDecision{Account: 1, Outcome: risk.OutcomeDecline, Score: 90}
+	riskServiceMock := NewMockRisks(ctrl)
+	riskServiceMock.EXPECT().Assess(gomock.Any(), gomock.Any(), gomock.Any(), int64(1000)).Return(decision, nil)
+	riskServiceMock.EXPECT().Record(gomock.Any(), decision).DoAndReturn(func(ctx context.Context, decision *entity.RiskDecision) error {
+		assert.Nil(t, decision.Transaction)
+		return nil
+	})
+
+	transactionService := NewTransaction(TransactionOpts{
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:      mockAccountRepository,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            riskServiceMock,
+		AuthorizationService:   mockAuthorizationDeclined(t, ctrl, DeclineCodeRisk),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:   1,
+		Operation: 1,
+		Amount:    1000,
+	})
+	assert.Nil(t, transaction)
+	assert.Equal(t, ErrDeclineRisk, err)
+}
+
+func TestServiceTransaction_Create_Risk_Recorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountEntity := &entity.Account{ID: 1, CreditLimit: 2000, AvailableLimit: 2000}
+	mockAccountRepository := repository.NewMockAccounts(ctrl)
+	mockAccountRepository.EXPECT().FindByIDForUpdate(gomock.Any(), uint(1)).Return(mockAccountEntity, nil)
+
+	mockOperationRepository := repository.NewMockOperations(ctrl)
+	mockOperationRepository.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Operation{ID: 1, Debit: true}, nil)
+
+	mockTransactionRepository := repository.NewMockTransactions(ctrl)
+	mockTransactionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&entity.Transaction{ID: 7, Account: 1, Amount: -1000}, nil)
+
+	accountServiceMock := NewMockAccounts(ctrl)
+	accountServiceMock.EXPECT().UpdateLimit(gomock.Any(), mockAccountEntity, int64(1000), true).Return(nil)
+
+	spendingControlServiceMock := NewMockSpendingControls(ctrl)
+	spendingControlServiceMock.EXPECT().Evaluate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	riskServiceMock := NewMockRisks(ctrl)
+	riskServiceMock.EXPECT().Assess(gomock.Any(), gomock.Any(), gomock.Any(), int64(1000)).Return(&entity.RiskDecision{Account: 1, Outcome: risk.OutcomeReview, Score: 60}, nil)
+	riskServiceMock.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, decision *entity.RiskDecision) error {
+		assert.Equal(t, uint(7), *decision.Transaction)
+		return nil
+	})
+
+	transactionService := NewTransaction(TransactionOpts{
+		AccountService:         accountServiceMock,
+		Operation:              mockOperationRepository,
+		UnitOfWork:             mockUnitOfWorkPassthrough(ctrl),
+		AccountRepository:      mockAccountRepository,
+		TransactionRepository:  mockTransactionRepository,
+		SpendingControlService: spendingControlServiceMock,
+		RiskService:            riskServiceMock,
+		AuthorizationService:   mockAuthorizationRecorded(ctrl),
+	})
+
+	transaction, err := transactionService.Create(context.Background(), &contract.TransactionRequest{
+		Account:   1,
+		Operation: 1,
+		Amount:    1000,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), transaction.ID)
+}
@@ -1,6 +1,9 @@
 #!/bin/sh
 
+# API_KEY must be granted operations:admin, see cmd/apikey.
+
 docker-compose exec api curl -X 'POST' 'http://127.0.0.1:8000/operations' -H 'accept: application/json' \
+-H "X-API-Key: $API_KEY" \
 -H 'Content-Type: application/json' \
 -d '{
 "description": "COMPRA A VISTA",
@@ -8,6 +11,7 @@ docker-compose exec api curl -X 'POST' 'http://127.0.0.1:8000/operations' -H 'ac
 }'
 
 docker-compose exec api curl -X 'POST' 'http://127.0.0.1:8000/operations' -H 'accept: application/json' \
+-H "X-API-Key: $API_KEY" \
 -H 'Content-Type: application/json' \
 -d '{
 "description": "COMPRA PARCELADA",
@@ -15,6 +19,7 @@ docker-compose exec api curl -X 'POST' 'http://127.0.0.1:8000/operations' -H 'ac
 }'
 
 docker-compose exec api curl -X 'POST' 'http://127.0.0.1:8000/operations' -H 'accept: application/json' \
+-H "X-API-Key: $API_KEY" \
 -H 'Content-Type: application/json' \
 -d '{
 "description": "SAQUE",
@@ -22,8 +27,18 @@ docker-compose exec api curl -X 'POST' 'http://127.0.0.1:8000/operations' -H 'ac
 }'
 
 docker-compose exec api curl -X 'POST' 'http://127.0.0.1:8000/operations' -H 'accept: application/json' \
+-H "X-API-Key: $API_KEY" \
 -H 'Content-Type: application/json' \
 -d '{
 "description": "PAGAMENTO",
 "debit": "false"
-}'
\ No newline at end of file
+}'
+
+docker-compose exec api curl -X 'POST' 'http://127.0.0.1:8000/operations' -H 'accept: application/json' \
+-H "X-API-Key: $API_KEY" \
+-H 'Content-Type: application/json' \
+-d '{
+"description": "ANUIDADE",
+"debit": "true",
+"fee": "true"
+}'